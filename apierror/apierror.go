@@ -0,0 +1,187 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Package apierror provides a typed error that handlers can return to control the error response
+// returned to clients, including its status code, a machine readable code, violations of individual
+// request fields and when the client may retry the request.
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Error is an error to be returned to a client.
+type Error struct {
+	// The HTTP status code of the response.
+	Status int
+
+	// A machine readable code identifying the cause of the error, ie object_not_found.
+	Code string
+
+	// A human readable message describing the error, which is returned to the client.
+	Message string
+
+	// Violations of individual request fields that caused the error.
+	Details []FieldViolation
+
+	// How long clients should wait before retrying the request, returned with the Retry-After header.
+	RetryAfter time.Duration
+
+	// The underlying cause of the error. It is never returned to the client, but can be inspected
+	// with errors.Is and errors.As.
+	Err error
+}
+
+// FieldViolation describes why a single field of a request is invalid.
+type FieldViolation struct {
+	// The path of the invalid field within the request, ie spec.replicas.
+	Field string
+
+	// A description of why the field is invalid.
+	Description string
+}
+
+// New returns an error with the status, code and message.
+func New(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// Newf returns an error with the status and code, and a message formatted with fmt.Sprintf.
+func Newf(status int, code, format string, args ...any) *Error {
+	return New(status, code, fmt.Sprintf(format, args...))
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.Status)
+	}
+
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// WithCause sets the underlying cause of the error.
+func (e *Error) WithCause(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// WithDetails adds violations of request fields to the error.
+func (e *Error) WithDetails(details ...FieldViolation) *Error {
+	e.Details = append(e.Details, details...)
+	return e
+}
+
+// WithField adds a violation of a single request field to the error.
+func (e *Error) WithField(field, description string) *Error {
+	return e.WithDetails(FieldViolation{Field: field, Description: description})
+}
+
+// WithRetryAfter sets how long clients should wait before retrying the request.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	e.RetryAfter = d
+	return e
+}
+
+// As returns the first *Error within err's tree, or false if there is none.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+
+	return nil, false
+}
+
+// StatusOf returns the status code of the first *Error within err's tree, or 500 if there is none.
+func StatusOf(err error) int {
+	if e, ok := As(err); ok {
+		return e.Status
+	}
+
+	return http.StatusInternalServerError
+}
+
+func BadRequest(message string) *Error {
+	return New(http.StatusBadRequest, "bad_request", message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(http.StatusUnauthorized, "unauthorized", message)
+}
+
+func Forbidden(message string) *Error {
+	return New(http.StatusForbidden, "forbidden", message)
+}
+
+func NotFound(message string) *Error {
+	return New(http.StatusNotFound, "not_found", message)
+}
+
+func MethodNotAllowed(message string) *Error {
+	return New(http.StatusMethodNotAllowed, "method_not_allowed", message)
+}
+
+func NotAcceptable(message string) *Error {
+	return New(http.StatusNotAcceptable, "not_acceptable", message)
+}
+
+func Conflict(message string) *Error {
+	return New(http.StatusConflict, "conflict", message)
+}
+
+func PreconditionFailed(message string) *Error {
+	return New(http.StatusPreconditionFailed, "precondition_failed", message)
+}
+
+func RequestEntityTooLarge(message string) *Error {
+	return New(http.StatusRequestEntityTooLarge, "request_entity_too_large", message)
+}
+
+func UnsupportedMediaType(message string) *Error {
+	return New(http.StatusUnsupportedMediaType, "unsupported_media_type", message)
+}
+
+func UnprocessableEntity(message string, details ...FieldViolation) *Error {
+	return New(http.StatusUnprocessableEntity, "unprocessable_entity", message).WithDetails(details...)
+}
+
+func TooManyRequests(message string, retryAfter time.Duration) *Error {
+	return New(http.StatusTooManyRequests, "too_many_requests", message).WithRetryAfter(retryAfter)
+}
+
+func Internal(message string) *Error {
+	return New(http.StatusInternalServerError, "internal", message)
+}
+
+func NotImplemented(message string) *Error {
+	return New(http.StatusNotImplemented, "not_implemented", message)
+}
+
+func Unavailable(message string, retryAfter time.Duration) *Error {
+	return New(http.StatusServiceUnavailable, "unavailable", message).WithRetryAfter(retryAfter)
+}
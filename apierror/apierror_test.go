@@ -0,0 +1,76 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAs(t *testing.T) {
+	cause := errors.New("connection refused")
+	e := fmt.Errorf("unable to load object: %w", NotFound("object was not found").WithCause(cause))
+
+	apiErr, ok := As(e)
+	if !ok || apiErr.Status != http.StatusNotFound || apiErr.Code != "not_found" {
+		t.Fatalf("As() = %+v, %v, want a 404", apiErr, ok)
+	}
+
+	if !errors.Is(e, cause) {
+		t.Errorf("cause is not unwrapped")
+	}
+
+	if apiErr.Error() != "object was not found: connection refused" {
+		t.Errorf("Error() = %s", apiErr.Error())
+	}
+
+	if status := StatusOf(cause); status != http.StatusInternalServerError {
+		t.Errorf("StatusOf() = %d, want 500", status)
+	}
+
+	if status := StatusOf(e); status != http.StatusNotFound {
+		t.Errorf("StatusOf() = %d, want 404", status)
+	}
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		err    *Error
+		status int
+		want   string
+	}{
+		{Conflict("object exists"), http.StatusConflict, "object exists"},
+		{PreconditionFailed(""), http.StatusPreconditionFailed, "Precondition Failed"},
+		{RequestEntityTooLarge(""), http.StatusRequestEntityTooLarge, "Request Entity Too Large"},
+		{UnprocessableEntity("invalid object", FieldViolation{"name", "must be set"}), http.StatusUnprocessableEntity, "invalid object"},
+		{Newf(http.StatusTeapot, "teapot", "%d cups", 2), http.StatusTeapot, "2 cups"},
+	}
+
+	for _, tt := range tests {
+		if tt.err.Status != tt.status || tt.err.Error() != tt.want {
+			t.Errorf("%+v = %d %s, want %d %s", tt.err, tt.err.Status, tt.err.Error(), tt.status, tt.want)
+		}
+	}
+
+	if e := UnprocessableEntity("invalid").WithField("spec.replicas", "must be positive"); len(e.Details) != 1 || e.Details[0].Field != "spec.replicas" {
+		t.Errorf("details = %+v", e.Details)
+	}
+}
@@ -0,0 +1,175 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Package buildinfo describes how the running binary was built. Values can be set at
+// link time, for example:
+//
+//	go build -ldflags "-X github.com/fire833/go-api-utils/buildinfo.Version=v1.2.3 -X github.com/fire833/go-api-utils/buildinfo.Commit=$(git rev-parse HEAD)"
+//
+// Any values that aren't set at link time are filled in from the build information embedded
+// by the Go toolchain, when available.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Variables settable with -ldflags -X, which take precedence over the embedded build information.
+var (
+	// The version of the application, such as v1.2.3.
+	Version string
+
+	// The VCS revision the application was built from.
+	Commit string
+
+	// The time the application was built, preferably in RFC 3339 format.
+	BuildTime string
+
+	// Whether the working tree had uncommitted changes when built, "true" or "false".
+	Dirty string
+)
+
+// Info is the resolved build information of the running binary.
+type Info struct {
+	Version   string
+	Commit    string
+	BuildTime string
+	Dirty     bool
+
+	GoVersion string
+	Os        string
+	Arch      string
+
+	// The main module path of the binary.
+	Path string
+
+	// The module dependencies of the binary.
+	Deps []Dependency
+}
+
+// Dependency is a module dependency compiled into the binary.
+type Dependency struct {
+	Path    string
+	Version string
+	Sum     string
+
+	// If the dependency is replaced, the module path and version it was replaced with.
+	Replace string
+}
+
+// Get returns the build information of the running binary.
+func Get() Info { return get() }
+
+var get = sync.OnceValue(func() Info {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		bi = nil
+	}
+
+	return resolve(bi)
+})
+
+// resolve merges the link time variables with the embedded build information, which may be nil.
+func resolve(bi *debug.BuildInfo) Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+		Os:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	info.Dirty, _ = strconv.ParseBool(Dirty)
+
+	if bi == nil {
+		return info
+	}
+
+	info.Path = bi.Main.Path
+	if bi.GoVersion != "" {
+		info.GoVersion = bi.GoVersion
+	}
+
+	if info.Version == "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if BuildTime == "" {
+				info.BuildTime = setting.Value
+			}
+		case "vcs.modified":
+			if Dirty == "" {
+				info.Dirty, _ = strconv.ParseBool(setting.Value)
+			}
+		case "GOOS":
+			info.Os = setting.Value
+		case "GOARCH":
+			info.Arch = setting.Value
+		}
+	}
+
+	for _, dep := range bi.Deps {
+		d := Dependency{
+			Path:    dep.Path,
+			Version: dep.Version,
+			Sum:     dep.Sum,
+		}
+
+		if dep.Replace != nil {
+			d.Replace = dep.Replace.Path
+			if dep.Replace.Version != "" {
+				d.Replace += "@" + dep.Replace.Version
+			}
+		}
+
+		info.Deps = append(info.Deps, d)
+	}
+
+	return info
+}
+
+// NewCollector returns a collector exporting the <namespace>_build_info gauge, which is always 1
+// and labeled with the version, commit, Go version and dirty flag of the binary.
+func NewCollector(namespace string) prometheus.Collector {
+	info := Get()
+
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "build_info",
+		Help:      "A metric with a constant '1' value labeled by the version, commit, Go version and dirty flag the binary was built with.",
+		ConstLabels: prometheus.Labels{
+			"version":   info.Version,
+			"commit":    info.Commit,
+			"goversion": info.GoVersion,
+			"dirty":     strconv.FormatBool(info.Dirty),
+		},
+	}, func() float64 { return 1 })
+}
@@ -0,0 +1,113 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package buildinfo
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func setVars(t *testing.T, version, commit, buildTime, dirty string) {
+	v, c, b, d := Version, Commit, BuildTime, Dirty
+	t.Cleanup(func() { Version, Commit, BuildTime, Dirty = v, c, b, d })
+
+	Version, Commit, BuildTime, Dirty = version, commit, buildTime, dirty
+}
+
+func testBuildInfo() *debug.BuildInfo {
+	return &debug.BuildInfo{
+		GoVersion: "go1.25.3",
+		Path:      "github.com/example/app/cmd/app",
+		Main:      debug.Module{Path: "github.com/example/app", Version: "v1.0.0"},
+		Deps: []*debug.Module{
+			{Path: "github.com/foo/bar", Version: "v0.1.0", Sum: "h1:abc="},
+			{Path: "github.com/foo/baz", Version: "v0.2.0", Replace: &debug.Module{Path: "../baz"}},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "3c03823782098c24e57cf779643a5a2d6883e1b6"},
+			{Key: "vcs.time", Value: "2025-01-01T00:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+			{Key: "GOOS", Value: "linux"},
+			{Key: "GOARCH", Value: "arm64"},
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("embedded", func(t *testing.T) {
+		setVars(t, "", "", "", "")
+
+		info := resolve(testBuildInfo())
+		if info.Version != "v1.0.0" || info.Commit != "3c03823782098c24e57cf779643a5a2d6883e1b6" || info.BuildTime != "2025-01-01T00:00:00Z" || !info.Dirty {
+			t.Errorf("info = %+v, want values from embedded build info", info)
+		}
+		if info.GoVersion != "go1.25.3" || info.Os != "linux" || info.Arch != "arm64" || info.Path != "github.com/example/app" {
+			t.Errorf("info = %+v, want toolchain values from embedded build info", info)
+		}
+		if len(info.Deps) != 2 || info.Deps[0].Sum != "h1:abc=" || info.Deps[1].Replace != "../baz" {
+			t.Errorf("deps = %+v, want both dependencies", info.Deps)
+		}
+	})
+
+	t.Run("linkerFlagsTakePrecedence", func(t *testing.T) {
+		setVars(t, "v2.0.0", "abc123", "yesterday", "false")
+
+		info := resolve(testBuildInfo())
+		if info.Version != "v2.0.0" || info.Commit != "abc123" || info.BuildTime != "yesterday" || info.Dirty {
+			t.Errorf("info = %+v, want values from linker flags", info)
+		}
+	})
+
+	t.Run("develVersion", func(t *testing.T) {
+		setVars(t, "", "", "", "")
+
+		bi := testBuildInfo()
+		bi.Main.Version = "(devel)"
+		if info := resolve(bi); info.Version != "" {
+			t.Errorf("version = %q, want empty for devel builds", info.Version)
+		}
+	})
+
+	t.Run("noBuildInfo", func(t *testing.T) {
+		setVars(t, "v3.0.0", "", "", "")
+
+		info := resolve(nil)
+		if info.Version != "v3.0.0" || info.GoVersion != runtime.Version() || info.Os != runtime.GOOS || info.Arch != runtime.GOARCH {
+			t.Errorf("info = %+v, want linker flags and runtime values", info)
+		}
+	})
+}
+
+func TestNewCollector(t *testing.T) {
+	info := Get()
+	want := fmt.Sprintf(`
+# HELP foo_build_info A metric with a constant '1' value labeled by the version, commit, Go version and dirty flag the binary was built with.
+# TYPE foo_build_info gauge
+foo_build_info{commit="%s",dirty="%t",goversion="%s",version="%s"} 1
+`, info.Commit, info.Dirty, info.GoVersion, info.Version)
+
+	if e := testutil.CollectAndCompare(NewCollector("foo"), strings.NewReader(want)); e != nil {
+		t.Error(e)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Package faketokenreview provides an in-process stand-in for the kubernetes
+// TokenReview API (authentication.k8s.io/v1), so code that validates bearer tokens
+// against a kubernetes apiserver can be exercised offline.
+package faketokenreview
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ReviewPath is the path TokenReviews are submitted to on the server.
+const ReviewPath string = "/apis/authentication.k8s.io/v1/tokenreviews"
+
+// Server is an in-process fake TokenReview server. Please create new instances with New(),
+// and be sure to call Close() once you are done with the server.
+type Server struct {
+	m sync.RWMutex
+
+	server *httptest.Server
+
+	// If set, the bearer token reviewers must present in order to submit reviews.
+	reviewerToken string
+
+	// Map of valid tokens to the users they authenticate as.
+	tokens map[string]*User
+
+	reviews int
+}
+
+// User is the identity that a token authenticates as.
+type User struct {
+	Username  string   `json:"username"`
+	UID       string   `json:"uid,omitempty"`
+	Groups    []string `json:"groups,omitempty"`
+	Audiences []string `json:"-"`
+}
+
+type tokenReview struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Spec       tokenReviewSpec   `json:"spec"`
+	Status     tokenReviewStatus `json:"status"`
+}
+
+type tokenReviewSpec struct {
+	Token     string   `json:"token"`
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+type tokenReviewStatus struct {
+	Authenticated bool     `json:"authenticated"`
+	User          *User    `json:"user,omitempty"`
+	Audiences     []string `json:"audiences,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// Return a new fake TokenReview server listening on a random local port.
+func New() *Server {
+	s := &Server{
+		tokens: make(map[string]*User),
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() { s.server.Close() }
+
+// URL returns the full URL TokenReviews should be submitted to.
+func (s *Server) URL() string { return s.server.URL + ReviewPath }
+
+// RequireReviewerToken requires reviewers to present the provided bearer token in
+// order to submit reviews, like the kubernetes apiserver requires of its clients.
+func (s *Server) RequireReviewerToken(token string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.reviewerToken = token
+}
+
+// AddToken registers a token that will be authenticated as the provided user.
+// If the user has audiences, reviews must request at least one of them.
+func (s *Server) AddToken(token string, user *User) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.tokens[token] = user
+}
+
+// Reviews returns the number of reviews submitted to the server.
+func (s *Server) Reviews() int {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return s.reviews
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != ReviewPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.reviewerToken != "" && r.Header.Get("Authorization") != "Bearer "+s.reviewerToken {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"kind":    "Status",
+			"status":  "Failure",
+			"message": "Unauthorized",
+			"code":    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	review := &tokenReview{}
+	if e := json.NewDecoder(r.Body).Decode(review); e != nil || strings.TrimSpace(review.Spec.Token) == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"kind":    "Status",
+			"status":  "Failure",
+			"message": "invalid TokenReview",
+			"code":    http.StatusBadRequest,
+		})
+		return
+	}
+
+	s.reviews++
+
+	review.APIVersion = "authentication.k8s.io/v1"
+	review.Kind = "TokenReview"
+
+	user, ok := s.tokens[review.Spec.Token]
+	switch {
+	case !ok:
+		review.Status = tokenReviewStatus{Error: "invalid bearer token"}
+	case len(user.Audiences) > 0 && !intersects(user.Audiences, review.Spec.Audiences):
+		review.Status = tokenReviewStatus{Error: "token audiences are invalid for the target audiences"}
+	default:
+		review.Status = tokenReviewStatus{
+			Authenticated: true,
+			User:          user,
+			Audiences:     review.Spec.Audiences,
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, review)
+}
+
+func intersects(a, b []string) bool {
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,622 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Package fakevault provides an in-memory stand-in for a HashiCorp Vault server
+// that speaks enough of the Vault HTTP API for the vault client code paths within
+// this project to be exercised offline. It supports KV v2 reads, writes and version
+// metadata, token and kubernetes logins, token and lease renewals, and issuing
+// credentials from database secret engine roles.
+package fakevault
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// DefaultLeaseDuration is the lease duration (in seconds) handed out for tokens and
+// dynamic credentials unless overridden with SetLeaseDuration.
+const DefaultLeaseDuration int = 3600
+
+// Server is an in-process fake Vault server. Please create new instances with New(),
+// and be sure to call Close() once you are done with the server.
+type Server struct {
+	m sync.RWMutex
+
+	server *httptest.Server
+
+	rootToken string
+
+	leaseDuration int
+
+	// Map of issued client tokens to their backing token state.
+	tokens map[string]*token
+
+	// Map of all enabled KV v2 mounts, each of which contain a map of secret
+	// paths to their versioned secrets.
+	kv map[string]map[string]*kvSecret
+
+	// Map of kubernetes auth mounts to their configured roles, each role being
+	// mapped to the service account JWT that is allowed to login with it.
+	k8s map[string]map[string]string
+
+	// Map of database secret engine mounts to the set of roles configured within them.
+	db map[string]map[string]bool
+
+	// Map of issued lease IDs to the leases themselves.
+	leases map[string]*lease
+
+	logins   int
+	renewals int
+}
+
+type token struct {
+	policies  []string
+	renewable bool
+	root      bool
+}
+
+type kvSecret struct {
+	versions []*kvVersion
+	created  time.Time
+	updated  time.Time
+}
+
+type kvVersion struct {
+	data    map[string]interface{}
+	created time.Time
+	deleted time.Time
+}
+
+type lease struct {
+	id       string
+	duration int
+}
+
+// New creates and starts a new fake Vault server listening on a random loopback port.
+func New() *Server {
+	s := &Server{
+		rootToken:     "root-" + randomID(),
+		leaseDuration: DefaultLeaseDuration,
+		tokens:        make(map[string]*token),
+		kv:            make(map[string]map[string]*kvSecret),
+		k8s:           make(map[string]map[string]string),
+		db:            make(map[string]map[string]bool),
+		leases:        make(map[string]*lease),
+	}
+
+	s.tokens[s.rootToken] = &token{
+		policies: []string{"root"},
+		root:     true,
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Close shuts down the server and blocks until all outstanding requests have completed.
+func (s *Server) Close() { s.server.Close() }
+
+// Address returns the base address of the server, suitable for use as the
+// vault client address or the vaultAddress configuration key.
+func (s *Server) Address() string { return s.server.URL }
+
+// RootToken returns the root token of the server, which is valid for all paths.
+func (s *Server) RootToken() string { return s.rootToken }
+
+// Client returns a new vault client configured to talk to this server. The
+// client will be authenticated with the provided token, or no token at all if
+// token is empty.
+func (s *Server) Client(token string) (*api.Client, error) {
+	conf := api.DefaultConfig()
+	conf.Address = s.Address()
+
+	client, e := api.NewClient(conf)
+	if e != nil {
+		return nil, e
+	}
+
+	client.ClearToken()
+	if token != "" {
+		client.SetToken(token)
+	}
+
+	return client, nil
+}
+
+// SetLeaseDuration overrides the lease duration (in seconds) of all tokens
+// and credentials issued from this point forward.
+func (s *Server) SetLeaseDuration(seconds int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.leaseDuration = seconds
+}
+
+// PutSecret writes a new version of the secret at <mount>/<path> within a KV v2
+// engine, enabling the mount if it is not enabled already, and returns the version written.
+func (s *Server) PutSecret(mount, path string, data map[string]interface{}) int {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	mount = strings.Trim(mount, "/")
+	if _, ok := s.kv[mount]; !ok {
+		s.kv[mount] = make(map[string]*kvSecret)
+	}
+
+	return putVersion(s.kv[mount], strings.Trim(path, "/"), data).version()
+}
+
+// EnableKV enables a KV v2 engine at the provided mount without writing any secrets to it.
+func (s *Server) EnableKV(mount string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	mount = strings.Trim(mount, "/")
+	if _, ok := s.kv[mount]; !ok {
+		s.kv[mount] = make(map[string]*kvSecret)
+	}
+}
+
+// AddKubernetesRole configures a role within the kubernetes auth engine at mount,
+// that will issue a token to any login request presenting the provided JWT.
+func (s *Server) AddKubernetesRole(mount, role, jwt string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	mount = strings.Trim(mount, "/")
+	if _, ok := s.k8s[mount]; !ok {
+		s.k8s[mount] = make(map[string]string)
+	}
+
+	s.k8s[mount][role] = jwt
+}
+
+// AddDatabaseRole configures a role within the database secrets engine at mount
+// from which credentials can be issued.
+func (s *Server) AddDatabaseRole(mount, role string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	mount = strings.Trim(mount, "/")
+	if _, ok := s.db[mount]; !ok {
+		s.db[mount] = make(map[string]bool)
+	}
+
+	s.db[mount][role] = true
+}
+
+// Logins returns the number of successful logins made against any auth engine.
+func (s *Server) Logins() int {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return s.logins
+}
+
+// Renewals returns the number of successful token and lease renewals.
+func (s *Server) Renewals() int {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	return s.renewals
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, "/v1/") {
+		writeErrors(w, http.StatusNotFound)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	body := map[string]interface{}{}
+	if r.Body != nil && r.ContentLength != 0 {
+		if e := json.NewDecoder(r.Body).Decode(&body); e != nil && e != io.EOF {
+			writeErrors(w, http.StatusBadRequest, "failed to parse JSON input: "+e.Error())
+			return
+		}
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	// Login endpoints are the only endpoints that can be accessed without a token.
+	if mount, ok := strings.CutSuffix(path, "/login"); ok && strings.HasPrefix(mount, "auth/") && mount != "auth/token" {
+		s.handleK8sLogin(w, strings.TrimPrefix(mount, "auth/"), body)
+		return
+	}
+
+	tok, ok := s.tokens[r.Header.Get("X-Vault-Token")]
+	if !ok {
+		writeErrors(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	switch path {
+	case "auth/token/lookup-self":
+		s.handleLookupSelf(w, r.Header.Get("X-Vault-Token"), tok)
+		return
+	case "auth/token/renew-self":
+		s.handleRenewSelf(w, r.Header.Get("X-Vault-Token"), tok, body)
+		return
+	case "sys/leases/renew":
+		s.handleLeaseRenew(w, body)
+		return
+	}
+
+	for mount := range s.kv {
+		if rest, ok := strings.CutPrefix(path, mount+"/data/"); ok {
+			s.handleKVData(w, r, s.kv[mount], rest, body)
+			return
+		}
+
+		if rest, ok := strings.CutPrefix(path, mount+"/metadata/"); ok {
+			s.handleKVMetadata(w, r, s.kv[mount], rest)
+			return
+		}
+	}
+
+	for mount, roles := range s.db {
+		if role, ok := strings.CutPrefix(path, mount+"/creds/"); ok {
+			s.handleDBCreds(w, r, mount, roles, role)
+			return
+		}
+	}
+
+	writeErrors(w, http.StatusNotFound, "no handler for route \""+path+"\"")
+}
+
+func (s *Server) handleK8sLogin(w http.ResponseWriter, mount string, body map[string]interface{}) {
+	roles, ok := s.k8s[mount]
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "no handler for route \"auth/"+mount+"/login\"")
+		return
+	}
+
+	role, _ := body["role"].(string)
+	jwt, _ := body["jwt"].(string)
+
+	if want, ok := roles[role]; !ok {
+		writeErrors(w, http.StatusBadRequest, "invalid role name \""+role+"\"")
+		return
+	} else if want != jwt || jwt == "" {
+		writeErrors(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	id := "s." + randomID()
+	s.tokens[id] = &token{
+		policies:  []string{"default", role},
+		renewable: true,
+	}
+	s.logins++
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"request_id": randomID(),
+		"auth": map[string]interface{}{
+			"client_token":   id,
+			"accessor":       randomID(),
+			"policies":       s.tokens[id].policies,
+			"token_policies": s.tokens[id].policies,
+			"metadata":       map[string]interface{}{"role": role},
+			"lease_duration": s.leaseDuration,
+			"renewable":      true,
+		},
+	})
+}
+
+func (s *Server) handleLookupSelf(w http.ResponseWriter, id string, tok *token) {
+	ttl := s.leaseDuration
+	if tok.root {
+		ttl = 0
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"request_id": randomID(),
+		"data": map[string]interface{}{
+			"id":        id,
+			"policies":  tok.policies,
+			"renewable": tok.renewable,
+			"ttl":       ttl,
+		},
+	})
+}
+
+func (s *Server) handleRenewSelf(w http.ResponseWriter, id string, tok *token, body map[string]interface{}) {
+	if !tok.renewable {
+		writeErrors(w, http.StatusBadRequest, "token not renewable")
+		return
+	}
+
+	s.renewals++
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"request_id": randomID(),
+		"auth": map[string]interface{}{
+			"client_token":   id,
+			"policies":       tok.policies,
+			"token_policies": tok.policies,
+			"lease_duration": increment(body, s.leaseDuration),
+			"renewable":      true,
+		},
+	})
+}
+
+func (s *Server) handleLeaseRenew(w http.ResponseWriter, body map[string]interface{}) {
+	id, _ := body["lease_id"].(string)
+
+	l, ok := s.leases[id]
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, "lease not found")
+		return
+	}
+
+	l.duration = increment(body, l.duration)
+	s.renewals++
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"request_id":     randomID(),
+		"lease_id":       l.id,
+		"renewable":      true,
+		"lease_duration": l.duration,
+	})
+}
+
+func (s *Server) handleDBCreds(w http.ResponseWriter, r *http.Request, mount string, roles map[string]bool, role string) {
+	if r.Method != http.MethodGet {
+		writeErrors(w, http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !roles[role] {
+		writeErrors(w, http.StatusBadRequest, "unknown role: "+role)
+		return
+	}
+
+	l := &lease{
+		id:       mount + "/creds/" + role + "/" + randomID(),
+		duration: s.leaseDuration,
+	}
+	s.leases[l.id] = l
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"request_id":     randomID(),
+		"lease_id":       l.id,
+		"renewable":      true,
+		"lease_duration": l.duration,
+		"data": map[string]interface{}{
+			"username": "v-" + role + "-" + randomID()[:8],
+			"password": randomID(),
+		},
+	})
+}
+
+func (s *Server) handleKVData(w http.ResponseWriter, r *http.Request, secrets map[string]*kvSecret, path string, body map[string]interface{}) {
+	switch r.Method {
+	case http.MethodGet:
+		sec, ok := secrets[path]
+		if !ok {
+			writeErrors(w, http.StatusNotFound)
+			return
+		}
+
+		v := sec.version()
+		if q := r.URL.Query().Get("version"); q != "" && q != "0" {
+			if i, e := strconv.Atoi(q); e != nil || i < 1 || i > len(sec.versions) {
+				writeErrors(w, http.StatusNotFound)
+				return
+			} else {
+				v = i
+			}
+		}
+
+		ver := sec.versions[v-1]
+		var data interface{}
+		if ver.deleted.IsZero() {
+			data = ver.data
+		}
+
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"request_id": randomID(),
+			"data": map[string]interface{}{
+				"data":     data,
+				"metadata": ver.metadata(v),
+			},
+		})
+	case http.MethodPost, http.MethodPut:
+		data, ok := body["data"].(map[string]interface{})
+		if !ok {
+			writeErrors(w, http.StatusBadRequest, "no data provided")
+			return
+		}
+
+		if opts, ok := body["options"].(map[string]interface{}); ok {
+			if cas, ok := opts["cas"].(float64); ok {
+				current := 0
+				if sec, ok := secrets[path]; ok {
+					current = sec.version()
+				}
+
+				if int(cas) != current {
+					writeErrors(w, http.StatusBadRequest, "check-and-set parameter did not match the current version")
+					return
+				}
+			}
+		}
+
+		sec := putVersion(secrets, path, data)
+		v := sec.version()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"request_id": randomID(),
+			"data":       sec.versions[v-1].metadata(v),
+		})
+	case http.MethodDelete:
+		sec, ok := secrets[path]
+		if !ok {
+			writeErrors(w, http.StatusNotFound)
+			return
+		}
+
+		sec.versions[sec.version()-1].deleted = time.Now().UTC()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleKVMetadata(w http.ResponseWriter, r *http.Request, secrets map[string]*kvSecret, path string) {
+	if r.Method != http.MethodGet {
+		writeErrors(w, http.StatusMethodNotAllowed)
+		return
+	}
+
+	sec, ok := secrets[path]
+	if !ok {
+		writeErrors(w, http.StatusNotFound)
+		return
+	}
+
+	versions := map[string]interface{}{}
+	for i, ver := range sec.versions {
+		m := ver.metadata(i + 1)
+		delete(m, "version")
+		delete(m, "custom_metadata")
+		versions[strconv.Itoa(i+1)] = m
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"request_id": randomID(),
+		"data": map[string]interface{}{
+			"cas_required":         false,
+			"created_time":         sec.created.Format(time.RFC3339Nano),
+			"current_version":      sec.version(),
+			"custom_metadata":      nil,
+			"delete_version_after": "0s",
+			"max_versions":         0,
+			"oldest_version":       1,
+			"updated_time":         sec.updated.Format(time.RFC3339Nano),
+			"versions":             versions,
+		},
+	})
+}
+
+func putVersion(secrets map[string]*kvSecret, path string, data map[string]interface{}) *kvSecret {
+	now := time.Now().UTC()
+
+	sec, ok := secrets[path]
+	if !ok {
+		sec = &kvSecret{created: now}
+		secrets[path] = sec
+	}
+
+	// Copy the provided data so callers can't mutate stored versions.
+	copied := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		copied[k] = v
+	}
+
+	sec.versions = append(sec.versions, &kvVersion{
+		data:    copied,
+		created: now,
+	})
+	sec.updated = now
+
+	return sec
+}
+
+func (k *kvSecret) version() int { return len(k.versions) }
+
+func (v *kvVersion) metadata(version int) map[string]interface{} {
+	deleted := ""
+	if !v.deleted.IsZero() {
+		deleted = v.deleted.Format(time.RFC3339Nano)
+	}
+
+	return map[string]interface{}{
+		"created_time":    v.created.Format(time.RFC3339Nano),
+		"custom_metadata": nil,
+		"deletion_time":   deleted,
+		"destroyed":       false,
+		"version":         version,
+	}
+}
+
+// Versions returns the sorted list of versions that exist for the secret at <mount>/<path>.
+func (s *Server) Versions(mount, path string) []int {
+	s.m.RLock()
+	defer s.m.RUnlock()
+
+	secrets, ok := s.kv[strings.Trim(mount, "/")]
+	if !ok {
+		return nil
+	}
+
+	sec, ok := secrets[strings.Trim(path, "/")]
+	if !ok {
+		return nil
+	}
+
+	versions := []int{}
+	for i := range sec.versions {
+		versions = append(versions, i+1)
+	}
+
+	return versions
+}
+
+func increment(body map[string]interface{}, def int) int {
+	switch i := body["increment"].(type) {
+	case float64:
+		if i > 0 {
+			return int(i)
+		}
+	case string:
+		if d, e := time.ParseDuration(i); e == nil && d > 0 {
+			return int(d.Seconds())
+		} else if n, e := strconv.Atoi(i); e == nil && n > 0 {
+			return n
+		}
+	}
+
+	return def
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeErrors(w http.ResponseWriter, code int, errs ...string) {
+	if errs == nil {
+		errs = []string{}
+	}
+
+	writeJSON(w, code, map[string]interface{}{"errors": errs})
+}
+
+func randomID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
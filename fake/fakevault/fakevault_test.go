@@ -0,0 +1,93 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package fakevault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+)
+
+func TestKVv2(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	client, e := s.Client(s.RootToken())
+	if e != nil {
+		t.Fatalf("unable to create client: %v", e)
+	}
+
+	kv := client.KVv2("secret")
+	s.EnableKV("secret")
+
+	t.Run("notFound", func(t *testing.T) {
+		if _, e := kv.Get(context.Background(), "foo"); e == nil {
+			t.Errorf("Get() wanted error for missing secret")
+		}
+	})
+
+	t.Run("putAndGet", func(t *testing.T) {
+		if _, e := kv.Put(context.Background(), "foo", map[string]interface{}{"data": "v1"}); e != nil {
+			t.Fatalf("Put() error = %v", e)
+		}
+		if v := s.PutSecret("secret", "foo", map[string]interface{}{"data": "v2"}); v != 2 {
+			t.Errorf("PutSecret() = %d, want 2", v)
+		}
+
+		sec, e := kv.Get(context.Background(), "foo")
+		if e != nil {
+			t.Fatalf("Get() error = %v", e)
+		}
+		if sec.Data["data"] != "v2" || sec.VersionMetadata.Version != 2 {
+			t.Errorf("Get() = %v (version %d), want v2 (version 2)", sec.Data["data"], sec.VersionMetadata.Version)
+		}
+
+		old, e := kv.GetVersion(context.Background(), "foo", 1)
+		if e != nil {
+			t.Fatalf("GetVersion() error = %v", e)
+		}
+		if old.Data["data"] != "v1" {
+			t.Errorf("GetVersion() = %v, want v1", old.Data["data"])
+		}
+	})
+
+	t.Run("versions", func(t *testing.T) {
+		versions, e := kv.GetVersionsAsList(context.Background(), "foo")
+		if e != nil {
+			t.Fatalf("GetVersionsAsList() error = %v", e)
+		}
+		if len(versions) != 2 || versions[1].Version != 2 {
+			t.Errorf("GetVersionsAsList() = %v, want 2 versions", versions)
+		}
+	})
+
+	t.Run("checkAndSet", func(t *testing.T) {
+		if _, e := kv.Put(context.Background(), "foo", map[string]interface{}{"data": "v3"}, api.WithCheckAndSet(1)); e == nil {
+			t.Errorf("Put() wanted check-and-set error")
+		}
+	})
+
+	t.Run("permissionDenied", func(t *testing.T) {
+		anon, _ := s.Client("bad-token")
+		if _, e := anon.KVv2("secret").Get(context.Background(), "foo"); e == nil {
+			t.Errorf("Get() wanted permission denied error")
+		}
+	})
+}
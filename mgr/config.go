@@ -20,10 +20,10 @@ package mgr
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
 
 	"github.com/spf13/viper"
-	"k8s.io/klog/v2"
 )
 
 type genericValue struct {
@@ -33,6 +33,62 @@ type genericValue struct {
 	defaultVal interface{}
 }
 
+// valueMeta is the serialized form of the metadata of a ConfigValue or SecretValue.
+type valueMeta struct {
+	Name        string      `json:"name" yaml:"name" xml:"name"`
+	Description string      `json:"description" yaml:"description" xml:"description"`
+	TypeOf      string      `json:"typeOf" yaml:"typeOf" xml:"typeOf"`
+	DefaultVal  interface{} `json:"defaultVal" yaml:"defaultVal" xml:"defaultVal"`
+	IsSecret    bool        `json:"isSecret" yaml:"isSecret" xml:"isSecret"`
+
+	// Only populated for secrets, describes where the secret is sourced from.
+	Source    string `json:"source,omitempty" yaml:"source,omitempty" xml:"source,omitempty"`
+	MountPath string `json:"mountPath,omitempty" yaml:"mountPath,omitempty" xml:"mountPath,omitempty"`
+	Path      string `json:"path,omitempty" yaml:"path,omitempty" xml:"path,omitempty"`
+}
+
+// Return the key of this value.
+func (g *genericValue) Key() string { return g.key }
+
+// Return the description of this value.
+func (g *genericValue) Description() string { return g.desc }
+
+// Return the name of the type of this value, as derived from its default value.
+func (g *genericValue) TypeOf() string {
+	if g.defaultVal == nil {
+		return ""
+	}
+
+	t := reflect.TypeOf(g.defaultVal)
+	switch t.Kind() {
+	case reflect.String:
+		return "String"
+	case reflect.Bool:
+		return "Bool"
+	case reflect.Int:
+		return "Int"
+	case reflect.Uint:
+		return "Uint"
+	case reflect.Uint16:
+		return "Uint16"
+	case reflect.Uint32:
+		return "Uint32"
+	case reflect.Uint64:
+		return "Uint64"
+	case reflect.Float64:
+		return "Float64"
+	case reflect.Slice:
+		switch t.Elem().Kind() {
+		case reflect.String:
+			return "StringSlice"
+		case reflect.Int:
+			return "IntSlice"
+		}
+	}
+
+	return t.String()
+}
+
 type ConfigValue struct {
 	genericValue
 }
@@ -47,6 +103,16 @@ func NewConfigValue(key, desc string, defVal interface{}) *ConfigValue {
 	}
 }
 
+func (c *ConfigValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&valueMeta{
+		Name:        c.key,
+		Description: c.desc,
+		TypeOf:      c.TypeOf(),
+		DefaultVal:  c.defaultVal,
+		IsSecret:    false,
+	})
+}
+
 func (c *ConfigValue) Get() interface{} {
 	defer panicHandler(c.key)
 	return mgrLGet(false, c.key, c.defaultVal)
@@ -124,7 +190,11 @@ func NewSecretValue(key, desc string, defVal interface{}) *SecretValue {
 
 // Create a new secret vault value that will be retrieved from
 // <secretmountpath>/<secretpath> within the remote vault instance.
-// The provided <key> will be retrieved fro
+// The provided <key> will be retrieved from the data of that secret.
+//
+// Secrets are loaded from vault upon first access and cached within the process
+// thereafter. They are reloaded whenever the process receives a SIGHUP, or when
+// refreshed or pinned to a specific version through the sysAPI.
 func NewSecretVaultValue(key, desc string, defVal interface{}, secretmountpath, secretpath string) *SecretValue {
 	return &SecretValue{
 		genericValue: genericValue{
@@ -138,6 +208,51 @@ func NewSecretVaultValue(key, desc string, defVal interface{}, secretmountpath,
 	}
 }
 
+// Returns whether this secret is sourced from vault.
+func (s *SecretValue) IsVault() bool { return s.vault }
+
+// Returns the mount path of the KV v2 engine this secret is stored within, if sourced from vault.
+func (s *SecretValue) MountPath() string { return s.secretmountpath }
+
+// Returns the path of this secret within its KV v2 engine, if sourced from vault.
+func (s *SecretValue) Path() string { return s.secretpath }
+
+// Returns the version of the vault secret currently loaded into the process. Will
+// return 0 if the secret isn't sourced from vault or hasn't been loaded yet.
+func (s *SecretValue) LoadedVersion() int {
+	if !s.vault || mgr == nil {
+		return 0
+	}
+
+	mgr.vaultLock.RLock()
+	defer mgr.vaultLock.RUnlock()
+
+	if sec, ok := mgr.vaultSecrets[vaultSecretID(s.secretmountpath, s.secretpath)]; ok {
+		return sec.version
+	}
+
+	return 0
+}
+
+func (s *SecretValue) MarshalJSON() ([]byte, error) {
+	meta := &valueMeta{
+		Name:        s.key,
+		Description: s.desc,
+		TypeOf:      s.TypeOf(),
+		DefaultVal:  "*****", // Defaults for secrets may be sensitive as well.
+		IsSecret:    true,
+		Source:      "file",
+	}
+
+	if s.vault {
+		meta.Source = "vault"
+		meta.MountPath = s.secretmountpath
+		meta.Path = s.secretpath
+	}
+
+	return json.Marshal(meta)
+}
+
 func (s *SecretValue) Get() interface{} {
 	defer panicHandler(s.key)
 
@@ -250,7 +365,7 @@ func (s *SecretValue) GetFloat64() float64 {
 
 func mgrLGet(secret bool, key string, def interface{}) interface{} {
 	if mgr == nil {
-		klog.V(3).Infof("using default value for key %s: %v", key, def)
+		logger.Log(context.Background(), Verbosity(3), "using default value for key", "key", key, "default", def)
 		return def
 	}
 
@@ -300,18 +415,18 @@ func mgrLGet(secret bool, key string, def interface{}) interface{} {
 
 func mgrVGet(key, mountpath, path string, def interface{}) interface{} {
 	if mgr.vault == nil {
-		klog.Warningf("vault not enabled in manager, unable to access secret %s, relying on defaults", key)
+		logger.Warn("vault not enabled in manager, unable to access secret, relying on defaults", "key", key)
 		return def
 	}
 
-	if s, e := mgr.vault.KVv2(mountpath).Get(context.Background(), path); e != nil {
-		klog.Warningf("unable to retrieve vault secret (%s/%s): %v, relying on defaults", mountpath, path, e)
+	if data, e := mgr.getVaultSecret(mountpath, path, key); e != nil {
+		logger.Warn("unable to retrieve vault secret, relying on defaults", "mountPath", mountpath, "path", path, "error", e)
 		return def
 	} else {
-		if v, ok := s.Data[key]; ok {
+		if v, ok := data[key]; ok {
 			return v
 		} else {
-			klog.Warningf("key not found within secret %s, relying on defaults", key)
+			logger.Warn("key not found within secret, relying on defaults", "key", key)
 			return def
 		}
 	}
@@ -319,6 +434,6 @@ func mgrVGet(key, mountpath, path string, def interface{}) interface{} {
 
 func panicHandler(name string) {
 	if r := recover(); r != nil {
-		klog.Errorf("unable to cast secret %s to desired type: %v", name, r)
+		logger.Error("unable to cast secret to desired type", "key", name, "error", r)
 	}
 }
@@ -48,3 +48,60 @@ func TestConfigValues(t *testing.T) {
 		}
 	}
 }
+
+func TestSecretVaultValues(t *testing.T) {
+	m, v := loadVaultApp(t)
+	t.Setenv("VAULT_TOKEN", v.RootToken())
+	m.initVault()
+
+	v.PutSecret("kv", "app/db", map[string]interface{}{"password": "first"})
+	v.PutSecret("kv", "app/db", map[string]interface{}{"password": "second", "enabled": true})
+
+	tests := []struct {
+		name  string
+		value *SecretValue
+		want  interface{}
+	}{
+		{
+			name:  "latest",
+			value: NewSecretVaultValue("password", "desc", "default", "kv", "app/db"),
+			want:  "second",
+		},
+		{
+			name:  "bool",
+			value: NewSecretVaultValue("enabled", "desc", false, "kv", "app/db"),
+			want:  true,
+		},
+		{
+			name:  "missingKey",
+			value: NewSecretVaultValue("username", "desc", "default", "kv", "app/db"),
+			want:  "default",
+		},
+		{
+			name:  "missingPath",
+			value: NewSecretVaultValue("password", "desc", "default", "kv", "app/none"),
+			want:  "default",
+		},
+		{
+			name:  "missingMount",
+			value: NewSecretVaultValue("password", "desc", "default", "other", "app/db"),
+			want:  "default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.value.Get(); got != tt.want {
+				t.Errorf("SecretValue.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("vaultDisabled", func(t *testing.T) {
+		m.vault = nil
+
+		value := NewSecretVaultValue("password", "desc", "default", "kv", "app/db")
+		if got := value.GetString(); got != "default" {
+			t.Errorf("SecretValue.GetString() = %v, want %v", got, "default")
+		}
+	})
+}
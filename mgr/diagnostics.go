@@ -0,0 +1,504 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fire833/go-api-utils/serialization"
+	"github.com/go-openapi/spec"
+	"github.com/valyala/fasthttp"
+)
+
+var (
+	sysAPIDiagnosticsEnabled *ConfigValue = NewConfigValue(
+		"sysAPIDiagnosticsEnabled",
+		"Toggle whether sysAPI exposes pprof profiles, runtime metrics, and runtime tuning endpoints under /debug. All of these routes require admin access, and are only registered if sysAPIAuthEnabled is set as well.",
+		false,
+	)
+)
+
+// Named profiles that can be retrieved from /debug/pprof/{PROFILE}.
+var pprofProfiles []interface{} = []interface{}{"heap", "allocs", "goroutine", "block", "mutex", "threadcreate"}
+
+// The runtime doesn't provide a way to read the block profile rate back, so keep track of
+// the last rate that was set through sysAPI.
+var (
+	blockProfileRate     int
+	blockProfileRateLock sync.Mutex
+)
+
+// durationParam returns the number of seconds provided by the "seconds" query parameter, or the
+// default if it wasn't provided. Durations must be shorter than the sysAPI write timeout, otherwise
+// the connection would be closed before the profile could be written.
+func durationParam(ctx *fasthttp.RequestCtx, def int) (time.Duration, error) {
+	seconds := def
+	if val := ctx.QueryArgs().Peek("seconds"); len(val) > 0 {
+		s, e := strconv.Atoi(string(val))
+		if e != nil || s < 1 {
+			return 0, fmt.Errorf("seconds must be a positive integer")
+		}
+
+		seconds = s
+	}
+
+	if limit := int(sysAPIWriteTimeout.GetUint()); seconds >= limit {
+		return 0, fmt.Errorf("seconds must be less than the sysAPI write timeout of %d seconds", limit)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// Collect a CPU profile of the process for the requested duration.
+func cpuProfileHandler(ctx *fasthttp.RequestCtx) {
+	duration, e := durationParam(ctx, 30)
+	if e != nil {
+		serialization.BadRequestResponseHandler(ctx, e.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if e := pprof.StartCPUProfile(buf); e != nil {
+		// Only one CPU profile can be collected at a time.
+		serialization.BadRequestResponseHandler(ctx, fmt.Sprintf("unable to start CPU profile: %v", e))
+		return
+	}
+
+	time.Sleep(duration)
+	pprof.StopCPUProfile()
+
+	writeProfile(ctx, "profile", buf.Bytes(), false)
+}
+
+// Collect an execution trace of the process for the requested duration.
+func traceHandler(ctx *fasthttp.RequestCtx) {
+	duration, e := durationParam(ctx, 1)
+	if e != nil {
+		serialization.BadRequestResponseHandler(ctx, e.Error())
+		return
+	}
+
+	buf := &bytes.Buffer{}
+	if e := trace.Start(buf); e != nil {
+		// Only one trace can be collected at a time.
+		serialization.BadRequestResponseHandler(ctx, fmt.Sprintf("unable to start trace: %v", e))
+		return
+	}
+
+	time.Sleep(duration)
+	trace.Stop()
+
+	writeProfile(ctx, "trace", buf.Bytes(), false)
+}
+
+// Return a named profile of the process.
+func namedProfileHandler(ctx *fasthttp.RequestCtx) {
+	name := ctx.UserValue("PROFILE").(string)
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		serialization.NotFoundResponseHandler(ctx, fmt.Sprintf("profile %s not found", name))
+		return
+	}
+
+	debugLevel := 0
+	if val := ctx.QueryArgs().Peek("debug"); len(val) > 0 {
+		d, e := strconv.Atoi(string(val))
+		if e != nil || d < 0 {
+			serialization.BadRequestResponseHandler(ctx, "debug must be a non-negative integer")
+			return
+		}
+
+		debugLevel = d
+	}
+
+	if name == "heap" && ctx.QueryArgs().GetBool("gc") {
+		runtime.GC()
+	}
+
+	buf := &bytes.Buffer{}
+	if e := profile.WriteTo(buf, debugLevel); e != nil {
+		serialization.InternalErrorResponseHandler(ctx, fmt.Sprintf("unable to write profile: %v", e))
+		return
+	}
+
+	writeProfile(ctx, name, buf.Bytes(), debugLevel > 0)
+}
+
+// Return a human-readable dump of the stacks of all goroutines within the process.
+func goroutineDumpHandler(ctx *fasthttp.RequestCtx) {
+	buf := &bytes.Buffer{}
+	if e := pprof.Lookup("goroutine").WriteTo(buf, 2); e != nil {
+		serialization.InternalErrorResponseHandler(ctx, fmt.Sprintf("unable to dump goroutines: %v", e))
+		return
+	}
+
+	writeProfile(ctx, "goroutines", buf.Bytes(), true)
+}
+
+func writeProfile(ctx *fasthttp.RequestCtx, name string, data []byte, text bool) {
+	if text {
+		ctx.SetContentType("text/plain; charset=utf-8")
+	} else {
+		ctx.SetContentType("application/octet-stream")
+		ctx.Response.Header.Set(fasthttp.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name))
+	}
+
+	ctx.Response.Header.Set("X-Content-Type-Options", "nosniff")
+	ctx.SetStatusCode(fasthttp.StatusOK)
+	ctx.SetBody(data)
+}
+
+// runtimeMetrics returns a snapshot of all metrics supported by the runtime/metrics package.
+func runtimeMetrics() *RuntimeMetricList {
+	descs := metrics.All()
+
+	samples := make([]metrics.Sample, len(descs))
+	for i, desc := range descs {
+		samples[i].Name = desc.Name
+	}
+
+	metrics.Read(samples)
+
+	list := &RuntimeMetricList{Items: []*RuntimeMetric{}}
+	for i, sample := range samples {
+		metric := &RuntimeMetric{
+			Name:        sample.Name,
+			Description: descs[i].Description,
+			Cumulative:  descs[i].Cumulative,
+		}
+
+		switch sample.Value.Kind() {
+		case metrics.KindUint64:
+			metric.Kind = "uint64"
+			metric.Uint64Value = sample.Value.Uint64()
+		case metrics.KindFloat64:
+			metric.Kind = "float64"
+			metric.Float64Value = finite(sample.Value.Float64())
+		case metrics.KindFloat64Histogram:
+			h := sample.Value.Float64Histogram()
+			metric.Kind = "histogram"
+			metric.Histogram = &RuntimeMetricHistogram{
+				Counts:     h.Counts,
+				Boundaries: make([]float64, len(h.Buckets)),
+			}
+
+			for j, b := range h.Buckets {
+				metric.Histogram.Boundaries[j] = finite(b)
+			}
+		default:
+			// Metrics unsupported by this version of the runtime.
+			continue
+		}
+
+		list.AddItem(metric)
+	}
+
+	return list
+}
+
+// finite clamps infinite values so they can be serialized by all encodings.
+func finite(f float64) float64 {
+	switch {
+	case math.IsInf(f, 1):
+		return math.MaxFloat64
+	case math.IsInf(f, -1):
+		return -math.MaxFloat64
+	default:
+		return f
+	}
+}
+
+// Return a snapshot of all runtime metrics.
+func runtimeMetricsHandler(ctx *fasthttp.RequestCtx) {
+	serialization.MarshalBodyByAcceptHeader(ctx, runtimeMetrics())
+}
+
+// Trigger a garbage collection, optionally returning as much memory to the OS as possible.
+func gcHandler(ctx *fasthttp.RequestCtx) {
+	before := &runtime.MemStats{}
+	runtime.ReadMemStats(before)
+
+	start := time.Now()
+	if ctx.QueryArgs().GetBool("freeOSMemory") {
+		debug.FreeOSMemory()
+	} else {
+		runtime.GC()
+	}
+	took := time.Since(start)
+
+	after := &runtime.MemStats{}
+	runtime.ReadMemStats(after)
+
+	serialization.OKResponseHandler(ctx, fasthttp.StatusOK, fmt.Sprintf("garbage collection completed in %s, heap in use went from %d to %d bytes", took, before.HeapInuse, after.HeapInuse))
+}
+
+// runtimeSettings returns the current tunable settings of the runtime.
+func runtimeSettings() *RuntimeSettings {
+	gogc := []metrics.Sample{{Name: "/gc/gogc:percent"}}
+	metrics.Read(gogc)
+
+	gcPercent := int64(-1)
+	if gogc[0].Value.Kind() == metrics.KindUint64 {
+		// The runtime reports GOGC=off as the maximum int64.
+		if v := gogc[0].Value.Uint64(); v < math.MaxInt64 {
+			gcPercent = int64(v)
+		}
+	}
+
+	blockProfileRateLock.Lock()
+	rate := blockProfileRate
+	blockProfileRateLock.Unlock()
+
+	return &RuntimeSettings{
+		MaxProcs:             int32(runtime.GOMAXPROCS(0)),
+		MemoryLimit:          debug.SetMemoryLimit(-1),
+		GcPercent:            gcPercent,
+		BlockProfileRate:     int32(rate),
+		MutexProfileFraction: int32(runtime.SetMutexProfileFraction(-1)),
+		NumCPU:               int32(runtime.NumCPU()),
+		NumGoroutine:         int32(runtime.NumGoroutine()),
+	}
+}
+
+// Return the current tunable settings of the runtime.
+func getRuntimeSettingsHandler(ctx *fasthttp.RequestCtx) {
+	serialization.MarshalBodyByAcceptHeader(ctx, runtimeSettings())
+}
+
+// Update the tunable settings of the runtime, only updating those provided.
+func setRuntimeSettingsHandler(ctx *fasthttp.RequestCtx) {
+	args := ctx.QueryArgs()
+
+	parse := func(name string, min int64) (int64, bool, error) {
+		val := args.Peek(name)
+		if len(val) == 0 {
+			return 0, false, nil
+		}
+
+		i, e := strconv.ParseInt(string(val), 10, 64)
+		if e != nil || i < min {
+			return 0, false, fmt.Errorf("%s must be an integer of at least %d", name, min)
+		}
+
+		return i, true, nil
+	}
+
+	// Validate everything before changing anything, so a bad request doesn't partially apply.
+	maxProcs, setMaxProcs, e1 := parse("maxProcs", 1)
+	memoryLimit, setMemoryLimit, e2 := parse("memoryLimit", 0)
+	gcPercent, setGCPercent, e3 := parse("gcPercent", -1)
+	blockRate, setBlockRate, e4 := parse("blockProfileRate", 0)
+	mutexFraction, setMutexFraction, e5 := parse("mutexProfileFraction", 0)
+
+	for _, e := range []error{e1, e2, e3, e4, e5} {
+		if e != nil {
+			serialization.BadRequestResponseHandler(ctx, e.Error())
+			return
+		}
+	}
+
+	if maxProcs > math.MaxInt32 || blockRate > math.MaxInt32 || mutexFraction > math.MaxInt32 || gcPercent > math.MaxInt32 {
+		serialization.BadRequestResponseHandler(ctx, "value out of range")
+		return
+	}
+
+	if setMaxProcs {
+		runtime.GOMAXPROCS(int(maxProcs))
+	}
+
+	if setMemoryLimit {
+		debug.SetMemoryLimit(memoryLimit)
+	}
+
+	if setGCPercent {
+		debug.SetGCPercent(int(gcPercent))
+	}
+
+	if setBlockRate {
+		blockProfileRateLock.Lock()
+		runtime.SetBlockProfileRate(int(blockRate))
+		blockProfileRate = int(blockRate)
+		blockProfileRateLock.Unlock()
+	}
+
+	if setMutexFraction {
+		runtime.SetMutexProfileFraction(int(mutexFraction))
+	}
+
+	serialization.MarshalBodyByAcceptHeader(ctx, runtimeSettings())
+}
+
+// initSysAPIDiagnostics registers all diagnostics routes with sysAPI and documents them within
+// the provided spec, if diagnostics are enabled. Since these routes are able to take the process
+// down, they are never registered unless sysAPI authentication is enabled as well.
+func (m *APIManager) initSysAPIDiagnostics(s *spec.Swagger) {
+	if !sysAPIDiagnosticsEnabled.GetBool() {
+		return
+	}
+
+	if !sysAPIAuthEnabled.GetBool() {
+		logger.Error("ALERT: sysAPI diagnostics require sysAPIAuthEnabled to be set, not registering diagnostics routes.")
+		return
+	}
+
+	m.handleSysAPI(fasthttp.MethodGet, "/debug/pprof/profile", SysAPIRoleAdmin, cpuProfileHandler)
+	m.handleSysAPI(fasthttp.MethodGet, "/debug/pprof/trace", SysAPIRoleAdmin, traceHandler)
+	m.handleSysAPI(fasthttp.MethodGet, "/debug/pprof/{PROFILE}", SysAPIRoleAdmin, namedProfileHandler)
+	m.handleSysAPI(fasthttp.MethodGet, "/debug/goroutines", SysAPIRoleAdmin, goroutineDumpHandler)
+	m.handleSysAPI(fasthttp.MethodGet, "/debug/runtime/metrics", SysAPIRoleAdmin, runtimeMetricsHandler)
+	m.handleSysAPI(fasthttp.MethodPost, "/debug/runtime/gc", SysAPIRoleAdmin, gcHandler)
+	m.handleSysAPI(fasthttp.MethodGet, "/debug/runtime/settings", SysAPIRoleAdmin, getRuntimeSettingsHandler)
+	m.handleSysAPI(fasthttp.MethodPut, "/debug/runtime/settings", SysAPIRoleAdmin, setRuntimeSettingsHandler)
+
+	secondsParam := func(def int) *spec.Parameter {
+		return spec.QueryParam("seconds").Typed("integer", "int32").
+			WithDefault(def).
+			WithDescription("The number of seconds to collect for, must be less than the sysAPI write timeout.")
+	}
+
+	s.Paths.Paths["/debug/pprof/profile"] = spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getCPUProfile").
+				WithTags("sys", "debug").
+				WithProduces("application/octet-stream").
+				WithDescription("Collect a CPU profile of the process in pprof format. Only one CPU profile can be collected at a time.").
+				AddParam(secondsParam(30)).
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the CPU profile in pprof format.")).
+				RespondsWith(400, spec.NewResponse().
+					WithDescription("Returned if the duration is invalid, or a CPU profile is already being collected.").
+					WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+		},
+	}
+
+	s.Paths.Paths["/debug/pprof/trace"] = spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getTrace").
+				WithTags("sys", "debug").
+				WithProduces("application/octet-stream").
+				WithDescription("Collect an execution trace of the process, to be viewed with 'go tool trace'. Only one trace can be collected at a time.").
+				AddParam(secondsParam(1)).
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the execution trace.")).
+				RespondsWith(400, spec.NewResponse().
+					WithDescription("Returned if the duration is invalid, or a trace is already being collected.").
+					WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+		},
+	}
+
+	s.Paths.Paths["/debug/pprof/{PROFILE}"] = spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getProfile").
+				WithTags("sys", "debug").
+				WithProduces("application/octet-stream", "text/plain").
+				WithDescription("Return a named profile of the process. Block and mutex profiles will be empty unless their rates have been set through /debug/runtime/settings.").
+				AddParam(spec.PathParam("PROFILE").Typed("string", "").WithEnum(pprofProfiles...)).
+				AddParam(spec.QueryParam("debug").Typed("integer", "int32").WithDefault(0).
+					WithDescription("If 0, the profile is returned in pprof format, otherwise it is returned as human-readable text.")).
+				AddParam(spec.QueryParam("gc").Typed("boolean", "").
+					WithDescription("Run a garbage collection before collecting a heap profile.")).
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the profile.")).
+				RespondsWith(400, spec.NewResponse().
+					WithDescription("Returned if the debug level is invalid.").
+					WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))).
+				RespondsWith(404, spec.NewResponse().
+					WithDescription("Returned if the profile does not exist.").
+					WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+		},
+	}
+
+	s.Paths.Paths["/debug/goroutines"] = spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getGoroutineDump").
+				WithTags("sys", "debug").
+				WithProduces("text/plain").
+				WithDescription("Return the stacks of all goroutines within the process, in the same format as an unrecovered panic.").
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the goroutine dump.")),
+		},
+	}
+
+	s.Paths.Paths["/debug/runtime/metrics"] = spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getRuntimeMetrics").
+				WithTags("sys", "debug").
+				WithDescription("Return a snapshot of every metric exported by the Go runtime/metrics package.").
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the runtime metrics.").
+					WithSchema(spec.RefSchema("#/definitions/RuntimeMetricList"))),
+		},
+	}
+
+	s.Paths.Paths["/debug/runtime/gc"] = spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Post: spec.NewOperation("triggerGC").
+				WithTags("sys", "debug").
+				WithDescription("Run a garbage collection, blocking until it completes.").
+				AddParam(spec.QueryParam("freeOSMemory").Typed("boolean", "").
+					WithDescription("Additionally return as much memory to the operating system as possible.")).
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns once garbage collection has completed.").
+					WithSchema(spec.RefSchema("#/definitions/OKResponse"))),
+		},
+	}
+
+	s.Paths.Paths["/debug/runtime/settings"] = spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getRuntimeSettings").
+				WithTags("sys", "debug").
+				WithDescription("Return the current tunable settings of the Go runtime.").
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the runtime settings.").
+					WithSchema(spec.RefSchema("#/definitions/RuntimeSettings"))),
+			Put: spec.NewOperation("setRuntimeSettings").
+				WithTags("sys", "debug").
+				WithDescription("Update the tunable settings of the Go runtime. Only the provided settings are changed, and changes are lost when the process restarts.").
+				AddParam(spec.QueryParam("maxProcs").Typed("integer", "int32").WithMinimum(1, false).
+					WithDescription("Set GOMAXPROCS.")).
+				AddParam(spec.QueryParam("memoryLimit").Typed("integer", "int64").WithMinimum(0, false).
+					WithDescription("Set the soft memory limit (GOMEMLIMIT) in bytes.")).
+				AddParam(spec.QueryParam("gcPercent").Typed("integer", "int32").WithMinimum(-1, false).
+					WithDescription("Set the garbage collection target percentage (GOGC), -1 disables garbage collection.")).
+				AddParam(spec.QueryParam("blockProfileRate").Typed("integer", "int32").WithMinimum(0, false).
+					WithDescription("Sample one blocking event per this many nanoseconds spent blocked for the block profile, 0 disables block profiling.")).
+				AddParam(spec.QueryParam("mutexProfileFraction").Typed("integer", "int32").WithMinimum(0, false).
+					WithDescription("Sample 1/n mutex contention events for the mutex profile, 0 disables mutex profiling.")).
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the updated runtime settings.").
+					WithSchema(spec.RefSchema("#/definitions/RuntimeSettings"))).
+				RespondsWith(400, spec.NewResponse().
+					WithDescription("Returned if any of the settings are invalid, in which case no settings are changed.").
+					WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+		},
+	}
+
+	addProtoDefinitions(s.Definitions, &RuntimeMetricList{}, &RuntimeSettings{})
+}
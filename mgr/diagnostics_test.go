@@ -0,0 +1,192 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"bytes"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func loadDiagnosticsApp(t *testing.T, enabled, auth bool) *APIManager {
+	mgr = nil
+	t.Cleanup(func() { mgr = nil })
+
+	m := New(&APIManagerOpts{EnableSysAPI: true})
+	m.config.Set("sysAPIDiagnosticsEnabled", enabled)
+	m.config.Set("sysAPIAuthEnabled", auth)
+	m.Initialize(&SystemRegistrar{AppName: "foo"})
+
+	m.secrets.Set("sysAPIAdminTokens", []string{"admintoken"})
+	m.secrets.Set("sysAPIReadOnlyTokens", []string{"readtoken"})
+	return m
+}
+
+func TestSysAPIDiagnostics(t *testing.T) {
+	m := loadDiagnosticsApp(t, true, true)
+
+	gzip := []byte{0x1f, 0x8b}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+		prefix []byte
+		want   string
+	}{
+		{"readOnlyDenied", fasthttp.MethodGet, "/debug/goroutines", "readtoken", 403, nil, ""},
+		{"goroutines", fasthttp.MethodGet, "/debug/goroutines", "admintoken", 200, nil, "goroutine "},
+		{"heap", fasthttp.MethodGet, "/debug/pprof/heap?gc=true", "admintoken", 200, gzip, ""},
+		{"goroutineText", fasthttp.MethodGet, "/debug/pprof/goroutine?debug=1", "admintoken", 200, nil, "goroutine profile:"},
+		{"mutex", fasthttp.MethodGet, "/debug/pprof/mutex", "admintoken", 200, gzip, ""},
+		{"unknownProfile", fasthttp.MethodGet, "/debug/pprof/nope", "admintoken", 404, nil, ""},
+		{"badDebug", fasthttp.MethodGet, "/debug/pprof/heap?debug=x", "admintoken", 400, nil, ""},
+		{"cpu", fasthttp.MethodGet, "/debug/pprof/profile?seconds=1", "admintoken", 200, gzip, ""},
+		{"cpuZeroSeconds", fasthttp.MethodGet, "/debug/pprof/profile?seconds=0", "admintoken", 400, nil, ""},
+		{"cpuPastWriteTimeout", fasthttp.MethodGet, "/debug/pprof/profile?seconds=120", "admintoken", 400, nil, ""},
+		{"trace", fasthttp.MethodGet, "/debug/pprof/trace?seconds=1", "admintoken", 200, []byte("go 1."), ""},
+		{"runtimeMetrics", fasthttp.MethodGet, "/debug/runtime/metrics", "admintoken", 200, nil, "/gc/heap/allocs:bytes"},
+		{"gc", fasthttp.MethodPost, "/debug/runtime/gc?freeOSMemory=true", "admintoken", 200, nil, "garbage collection completed"},
+		{"gcReadOnlyDenied", fasthttp.MethodPost, "/debug/runtime/gc", "readtoken", 403, nil, ""},
+		{"settings", fasthttp.MethodGet, "/debug/runtime/settings", "admintoken", 200, nil, "maxProcs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := sysAPIRequest(m, tt.method, tt.path, tt.token)
+
+			if ctx.Response.StatusCode() != tt.code {
+				t.Fatalf("status = %d, want %d: %s", ctx.Response.StatusCode(), tt.code, ctx.Response.Body())
+			}
+			if tt.prefix != nil && !bytes.HasPrefix(ctx.Response.Body(), tt.prefix) {
+				t.Errorf("body does not start with %q", tt.prefix)
+			}
+			if tt.want != "" && !strings.Contains(string(ctx.Response.Body()), tt.want) {
+				t.Errorf("body does not contain %q", tt.want)
+			}
+		})
+	}
+
+	t.Run("documented", func(t *testing.T) {
+		for _, path := range []string{"/debug/pprof/profile", "/debug/pprof/trace", "/debug/pprof/{PROFILE}", "/debug/goroutines", "/debug/runtime/metrics", "/debug/runtime/gc", "/debug/runtime/settings"} {
+			if _, ok := m.spec.Paths.Paths[path]; !ok {
+				t.Errorf("path %s not documented within sysAPI spec", path)
+			}
+		}
+
+		if role, _ := m.spec.Paths.Paths["/debug/goroutines"].Get.Extensions.GetString("x-sysapi-role"); role != "admin" {
+			t.Errorf("x-sysapi-role = %s, want admin", role)
+		}
+	})
+}
+
+func TestSysAPIRuntimeSettings(t *testing.T) {
+	m := loadDiagnosticsApp(t, true, true)
+
+	procs := runtime.GOMAXPROCS(0)
+	limit := debug.SetMemoryLimit(-1)
+	gcPercent := debug.SetGCPercent(100)
+	debug.SetGCPercent(gcPercent)
+	fraction := runtime.SetMutexProfileFraction(-1)
+
+	t.Cleanup(func() {
+		runtime.GOMAXPROCS(procs)
+		debug.SetMemoryLimit(limit)
+		debug.SetGCPercent(gcPercent)
+		runtime.SetMutexProfileFraction(fraction)
+		runtime.SetBlockProfileRate(0)
+		blockProfileRate = 0
+	})
+
+	t.Run("set", func(t *testing.T) {
+		ctx := sysAPIRequest(m, fasthttp.MethodPut, "/debug/runtime/settings?maxProcs=1&memoryLimit=1073741824&gcPercent=150&blockProfileRate=1000&mutexProfileFraction=5", "admintoken")
+		if ctx.Response.StatusCode() != 200 {
+			t.Fatalf("status = %d, want 200: %s", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+
+		settings := &RuntimeSettings{}
+		if e := protojson.Unmarshal(ctx.Response.Body(), settings); e != nil {
+			t.Fatal(e)
+		}
+
+		if settings.MaxProcs != 1 || settings.MemoryLimit != 1073741824 || settings.GcPercent != 150 ||
+			settings.BlockProfileRate != 1000 || settings.MutexProfileFraction != 5 {
+			t.Errorf("settings = %+v, not updated", settings)
+		}
+
+		if runtime.GOMAXPROCS(0) != 1 {
+			t.Errorf("GOMAXPROCS = %d, want 1", runtime.GOMAXPROCS(0))
+		}
+	})
+
+	t.Run("invalidAppliesNothing", func(t *testing.T) {
+		ctx := sysAPIRequest(m, fasthttp.MethodPut, "/debug/runtime/settings?maxProcs=2&mutexProfileFraction=-4", "admintoken")
+		if ctx.Response.StatusCode() != 400 {
+			t.Fatalf("status = %d, want 400", ctx.Response.StatusCode())
+		}
+
+		if runtime.GOMAXPROCS(0) != 1 {
+			t.Errorf("GOMAXPROCS = %d, want 1 as the request was invalid", runtime.GOMAXPROCS(0))
+		}
+	})
+
+	t.Run("readOnlyDenied", func(t *testing.T) {
+		ctx := sysAPIRequest(m, fasthttp.MethodPut, "/debug/runtime/settings?maxProcs=2", "readtoken")
+		if ctx.Response.StatusCode() != 403 {
+			t.Errorf("status = %d, want 403", ctx.Response.StatusCode())
+		}
+	})
+}
+
+func TestSysAPIDiagnosticsDisabled(t *testing.T) {
+	m := loadDiagnosticsApp(t, false, true)
+
+	if ctx := sysAPIRequest(m, fasthttp.MethodGet, "/debug/goroutines", "admintoken"); ctx.Response.StatusCode() != 404 {
+		t.Errorf("status = %d, want 404 with diagnostics disabled", ctx.Response.StatusCode())
+	}
+
+	if _, ok := m.spec.Paths.Paths["/debug/goroutines"]; ok {
+		t.Errorf("diagnostics documented within sysAPI spec while disabled")
+	}
+}
+
+func TestSysAPIDiagnosticsWithoutAuth(t *testing.T) {
+	m := loadDiagnosticsApp(t, true, false)
+
+	for _, route := range [][2]string{
+		{fasthttp.MethodGet, "/debug/goroutines"},
+		{fasthttp.MethodGet, "/debug/pprof/heap"},
+		{fasthttp.MethodPost, "/debug/runtime/gc"},
+		{fasthttp.MethodPut, "/debug/runtime/settings?gcPercent=-1"},
+	} {
+		if ctx := sysAPIRequest(m, route[0], route[1], ""); ctx.Response.StatusCode() != 404 {
+			t.Errorf("%s %s status = %d, want 404 with sysAPI authentication disabled", route[0], route[1], ctx.Response.StatusCode())
+		}
+	}
+
+	if _, ok := m.spec.Paths.Paths["/debug/runtime/settings"]; ok {
+		t.Errorf("diagnostics documented within sysAPI spec while sysAPI authentication is disabled")
+	}
+}
@@ -18,7 +18,25 @@
 
 package mgr
 
+import (
+	_ "embed"
+
+	"github.com/fire833/go-api-utils/serialization"
+)
+
 //go:generate protoc --go_out=. --go_opt=Mmanager.proto=../manager manager.proto
 //go:generate protoc --go_out=. --go_opt=Mmanager.proto=../manager manager_list.proto
+//go:generate protoc --include_source_info --descriptor_set_out=manager.binpb manager.proto manager_list.proto
+
+// Source info of the manager protos, so schemas generated from their messages are documented.
+//
+//go:embed manager.binpb
+var managerSourceInfo []byte
+
+func init() {
+	if e := serialization.RegisterProtoSourceInfo(managerSourceInfo); e != nil {
+		panic(e)
+	}
+}
 
 var mgr *APIManager
@@ -19,88 +19,49 @@
 package mgr
 
 import (
+	"github.com/fire833/go-api-utils/object"
 	"github.com/fire833/go-api-utils/serialization"
 	"github.com/go-openapi/spec"
 )
 
-var (
-	subsystemStatusSchema *spec.Schema = serialization.NewSchema("SubsystemStatus", "Serialized object describing the status and additional metadata about a particular subsystem.", []spec.Schema{
-		serialization.NewSchemaStringProperty("name", "The name of this subsystem from the source code."),
-		serialization.NewSchemaBooleanProperty("isInitialized", "Boolean value of whether this subsystem is initialized."),
-		serialization.NewSchemaBooleanProperty("isShutdown", "Boolean value of whether this subsystem is shutdown."),
-		serialization.NewSchemaObjectProperty("meta", "Arbitrary metadata object emitted by this subsystem."),
-	})
+var configKeySchema *spec.Schema = serialization.NewSchema("ConfigKey", "Serialized object describing the value of a config/secret key/value within the current process.", []spec.Schema{
+	serialization.NewSchemaObjectProperty("value", "The current value of that config key in memory."),
+	// Meta sub-object
+	*serialization.NewSchema("meta", "Metadata associated with this config key.", []spec.Schema{
+		serialization.NewSchemaStringProperty("name", "Specify the actual key name for this property. This can be something like 'serverConcurrency', 'sqlDbUser', 'sqlDbPass', etc."),
+		serialization.NewSchemaStringProperty("description", "Description of this key/value pair, what its used for, and any edge case information about it."),
+		serialization.NewSchemaEnumProperty("typeOf", "The type of this value", "string", "",
+			[]interface{}{"String", "StringSlice", "Bool", "Int", "IntSlice", "Uint", "Uint16", "Uint32", "Uint64", "Float64", "Time"}),
+		serialization.NewSchemaObjectProperty("defaultVal", "Default value for this config key."),
+		serialization.NewSchemaBooleanProperty("isSecret", "Whether or not this configkey value is to be regarded as a secret."),
+		serialization.NewSchemaEnumProperty("source", "Where this secret is sourced from, only present for secrets.", "string", "",
+			[]interface{}{"file", "vault"}),
+		serialization.NewSchemaStringProperty("mountPath", "The mount path of the vault KV engine this secret is stored within, only present for vault secrets."),
+		serialization.NewSchemaStringProperty("path", "The path of this secret within its vault KV engine, only present for vault secrets."),
+	}),
+	serialization.NewSchemaInt64Property("version", "The version of the backing vault secret loaded into the process, only present for vault secrets."),
+})
 
-	buildInfoSchema *spec.Schema = &spec.Schema{
-		SwaggerSchemaProps: spec.SwaggerSchemaProps{
-			Example: BuildInfo{
-				Version:   "1.0.0",
-				Commit:    "3c03823782098c24e57cf779643a5a2d6883e1b6",
-				BuildTime: "Sun Jan 1 00:00:01 CDT 2022",
-				Os:        "linux",
-				Arch:      "amd64",
-			},
-		},
-		SchemaProps: spec.SchemaProps{
-			Title:       "BuildInfo",
-			Description: "Serialized object describing the build information used for creating this application instance.",
-			Type:        []string{"object"},
-			Format:      "",
-			Properties: spec.SchemaProperties{
-				"version": spec.Schema{
-					SchemaProps: spec.SchemaProps{
-						Description: "The specific version of application.",
-						Title:       "version",
-						Type:        []string{"string"},
-						Format:      "",
-					},
-				},
-				"commit": spec.Schema{
-					SchemaProps: spec.SchemaProps{
-						Description: "The git commit from which this application instance is derived.",
-						Title:       "commit",
-						Type:        []string{"string"},
-						Format:      "",
-					},
-				},
-				"buildTime": spec.Schema{
-					SchemaProps: spec.SchemaProps{
-						Description: "The time at which this instance of application was compiled.",
-						Title:       "buildTime",
-						Type:        []string{"string"},
-						Format:      "",
-					},
-				},
-				"os": spec.Schema{
-					SchemaProps: spec.SchemaProps{
-						Description: "The OS this binary is meant for.",
-						Title:       "os",
-						Type:        []string{"string"},
-						Format:      "",
-					},
-				},
-				"arch": spec.Schema{
-					SchemaProps: spec.SchemaProps{
-						Description: "The platform this binary is meant for.",
-						Title:       "arch",
-						Type:        []string{"string"},
-						Format:      "",
-					},
-				},
-			},
-		},
-	}
+// Example build information, included with the generated BuildInfo definition.
+var buildInfoExample *BuildInfo = &BuildInfo{
+	Version:   "1.0.0",
+	Commit:    "3c03823782098c24e57cf779643a5a2d6883e1b6",
+	BuildTime: "Sun Jan 1 00:00:01 CDT 2022",
+	Os:        "linux",
+	Arch:      "amd64",
+	GoVersion: "go1.25.3",
+	Path:      "github.com/example/app",
+	Dependencies: []*BuildDependency{
+		{Path: "github.com/fire833/go-api-utils", Version: "v0.1.0", Sum: "h1:2mS4Fz4cG8IYBZr7WnHx4i0PZ4N9z+0tC+f9TS06hF0="},
+	},
+}
 
-	configKeySchema *spec.Schema = serialization.NewSchema("ConfigKey", "Serialized object describing the value of a config/secret key/value within the current process.", []spec.Schema{
-		serialization.NewSchemaObjectProperty("value", "The current value of that config key in memory."),
-		// Meta sub-object
-		*serialization.NewSchema("meta", "Metadata associated with this config key.", []spec.Schema{
-			serialization.NewSchemaStringProperty("name", "Specify the actual key name for this property. This can be something like 'serverConcurrency', 'sqlDbUser', 'sqlDbPass', etc."),
-			serialization.NewSchemaStringProperty("description", "Description of this key/value pair, what its used for, and any edge case information about it."),
-			serialization.NewSchemaEnumProperty("typeOf", "The type of this value", "string", "",
-				[]interface{}{"String", "StringSlice", "Bool", "Int", "IntSlice", "Uint", "Uint16", "Uint32", "Uint64", "Float64", "Time"}),
-			serialization.NewSchemaObjectProperty("defaultVal", "Default value for this config key."),
-			serialization.NewSchemaBooleanProperty("isSecret", "Whether or not this configkey value is to be regarded as a secret."),
-		}),
-	})
-)
+// addProtoDefinitions adds definitions generated from the descriptors of each object, and every
+// message they reference, to defs.
+func addProtoDefinitions(defs spec.Definitions, objs ...object.Object) {
+	for _, obj := range objs {
+		for _, schema := range serialization.NewSchemasFromObject(obj) {
+			defs[schema.Title] = *schema
+		}
+	}
+}
@@ -0,0 +1,359 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+var (
+	logFormat *ConfigValue = NewConfigValue(
+		"logFormat",
+		"Specify the format of process logs. Can be one of klog, which writes human readable klog lines, json, or logfmt.",
+		"klog",
+	)
+
+	logSource *ConfigValue = NewConfigValue(
+		"logSource",
+		"Include the source file and line of the call site within json and logfmt logs.",
+		false,
+	)
+)
+
+// Where json and logfmt logs are written to.
+var logOutput io.Writer = os.Stderr
+
+// logs is the sink behind every logger returned by Logger. It starts out writing through klog,
+// and is reconfigured once the manager has read in the process configuration.
+var logs *logSink = newLogSink()
+
+// Logger returns a structured logger for a subsystem of the process. Records logged through it
+// carry the app name and subsystem name as fields, and when logged with a request context (including
+// a *fasthttp.RequestCtx), the request ID and OpenTelemetry trace and span IDs of the request.
+//
+// Loggers can be created at any point, including before the manager is initialized, and will follow
+// the log format the process is configured with.
+func Logger(subsystem string) *slog.Logger {
+	if subsystem == "" {
+		return slog.New(&logHandler{sink: logs})
+	}
+
+	return slog.New(&logHandler{sink: logs, attrs: []slog.Attr{slog.String("subsystem", subsystem)}})
+}
+
+// Package logger for the manager itself.
+var logger *slog.Logger = Logger("manager")
+
+type logContextKey int
+
+const (
+	requestIDKey logContextKey = iota
+	spanContextKey
+)
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID, which is added to all
+// records logged with the returned context.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, or an empty string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// SetRequestLogContext attaches the request ID and trace span context to a request, so that
+// records logged with the request as their context carry them. Since *fasthttp.RequestCtx
+// can't carry arbitrary context values, this stores them as user values instead.
+func SetRequestLogContext(ctx *fasthttp.RequestCtx, id string, span trace.SpanContext) {
+	if id != "" {
+		ctx.SetUserValue(requestIDKey, id)
+	}
+
+	if span.IsValid() {
+		ctx.SetUserValue(spanContextKey, span)
+	}
+}
+
+// SpanContextFromContext returns the trace span context of ctx, either from an OpenTelemetry
+// span within ctx, or one attached to a request with SetRequestLogContext.
+func SpanContextFromContext(ctx context.Context) trace.SpanContext {
+	if ctx == nil {
+		return trace.SpanContext{}
+	}
+
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		return span
+	}
+
+	span, _ := ctx.Value(spanContextKey).(trace.SpanContext)
+	return span
+}
+
+// Verbosity returns the level of records that are only written at klog verbosity n or higher,
+// the same as klog.V(n). Verbosity(4) is equal to slog.LevelDebug.
+func Verbosity(n int) slog.Level { return slog.Level(-n) }
+
+// logLeveler follows the klog verbosity, so the -v and -vmodule flags and sysAPI /logging route
+// control json and logfmt logs the same way as klog logs. It enables the lowest level granted by
+// either flag, and vmoduleHandler then drops records below the verbosity of the file they were
+// logged from.
+type logLeveler struct{}
+
+func (logLeveler) Level() slog.Level {
+	return Verbosity(max(logFlagVerbosity(), currentVmodule().max))
+}
+
+func logFlagVerbosity() int {
+	if v, ok := logFlags.Lookup("v").Value.(flag.Getter).Get().(klog.Level); ok {
+		return int(v)
+	}
+
+	return 0
+}
+
+// logVmodule is a parsed -vmodule flag value.
+type logVmodule struct {
+	filters []logVmoduleFilter
+
+	// The highest verbosity granted to any file.
+	max int
+}
+
+type logVmoduleFilter struct {
+	pattern string
+	level   int
+}
+
+// The parsed -vmodule flag. klog holds its own lock while writing records through the sink, and
+// the same lock is needed to read the flag, so it is parsed whenever the manager configures the sink
+// or changes the verbosity rather than on every record.
+var logVmoduleState atomic.Pointer[logVmodule]
+
+func currentVmodule() *logVmodule {
+	if vm := logVmoduleState.Load(); vm != nil {
+		return vm
+	}
+
+	return &logVmodule{}
+}
+
+// loadVmodule parses the current -vmodule flag. It must not be called while logging.
+func loadVmodule() {
+	// klog has already validated the value when the flag was set, so entries are
+	// only skipped the same way klog skips them.
+	vm := &logVmodule{}
+	for _, filter := range strings.Split(logFlags.Lookup("vmodule").Value.String(), ",") {
+		pattern, level, _ := strings.Cut(filter, "=")
+		if n, e := strconv.Atoi(level); e == nil && n > 0 && pattern != "" {
+			vm.filters = append(vm.filters, logVmoduleFilter{pattern: pattern, level: n})
+			vm.max = max(vm.max, n)
+		}
+	}
+
+	logVmoduleState.Store(vm)
+}
+
+// level returns the verbosity granted to the file containing pc by the first matching filter,
+// or 0 if none match. Like klog, filters are matched against the file name without ".go".
+func (vm *logVmodule) level(pc uintptr) int {
+	if len(vm.filters) == 0 || pc == 0 {
+		return 0
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	file := strings.TrimSuffix(path.Base(frame.File), ".go")
+
+	for _, filter := range vm.filters {
+		if match, _ := path.Match(filter.pattern, file); match {
+			return filter.level
+		}
+	}
+
+	return 0
+}
+
+// vmoduleHandler drops records that are enabled by logLeveler only because another file has
+// been granted a higher verbosity with -vmodule.
+type vmoduleHandler struct {
+	slog.Handler
+}
+
+func (h vmoduleHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < Verbosity(logFlagVerbosity()) && r.Level < Verbosity(currentVmodule().level(r.PC)) {
+		return nil
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h vmoduleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return vmoduleHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h vmoduleHandler) WithGroup(name string) slog.Handler {
+	return vmoduleHandler{h.Handler.WithGroup(name)}
+}
+
+// Handler held by the sink, wrapped so it can be stored atomically.
+type logBackend struct {
+	handler slog.Handler
+}
+
+// logSink holds the handler that all logHandlers write to, which can be swapped at runtime.
+type logSink struct {
+	backend atomic.Pointer[logBackend]
+}
+
+func newLogSink() *logSink {
+	s := &logSink{}
+	s.set(logr.ToSlogHandler(klog.NewKlogr()))
+	return s
+}
+
+func (s *logSink) set(h slog.Handler) { s.backend.Store(&logBackend{handler: h}) }
+
+// configure switches the sink to the provided format. All records are tagged with the app name.
+// For json and logfmt, klog is also redirected into the sink, so that logs from klog (including
+// from dependencies) are written in the same format.
+func (s *logSink) configure(format, app string, source bool) error {
+	loadVmodule()
+
+	opts := &slog.HandlerOptions{AddSource: source, Level: logLeveler{}}
+
+	var h slog.Handler
+	switch format {
+	case "klog":
+		klog.ClearLogger()
+		s.set(logr.ToSlogHandler(klog.NewKlogr()).WithAttrs([]slog.Attr{slog.String("app", app)}))
+		return nil
+	case "json":
+		h = slog.NewJSONHandler(logOutput, opts)
+	case "logfmt":
+		h = slog.NewTextHandler(logOutput, opts)
+	default:
+		return fmt.Errorf("unknown log format %q, must be one of klog, json or logfmt", format)
+	}
+
+	h = vmoduleHandler{h.WithAttrs([]slog.Attr{slog.String("app", app)})}
+	s.set(h)
+	klog.SetSlogLogger(slog.New(&logHandler{sink: s}))
+	return nil
+}
+
+// logHandler is the slog.Handler returned by Logger. It resolves the current sink handler on
+// every record, and adds request and trace fields from the record context.
+type logHandler struct {
+	sink *logSink
+
+	// Either attrs or group is set, and is applied on top of the parent handler.
+	parent *logHandler
+	attrs  []slog.Attr
+	group  string
+
+	// The resolved handler for the last seen backend.
+	cache atomic.Pointer[resolvedHandler]
+}
+
+type resolvedHandler struct {
+	backend *logBackend
+	handler slog.Handler
+}
+
+func (h *logHandler) resolve() slog.Handler {
+	backend := h.sink.backend.Load()
+	if c := h.cache.Load(); c != nil && c.backend == backend {
+		return c.handler
+	}
+
+	handler := backend.handler
+	if h.parent != nil {
+		handler = h.parent.resolve()
+	}
+
+	if h.group != "" {
+		handler = handler.WithGroup(h.group)
+	} else if len(h.attrs) > 0 {
+		handler = handler.WithAttrs(h.attrs)
+	}
+
+	h.cache.Store(&resolvedHandler{backend: backend, handler: handler})
+	return handler
+}
+
+func (h *logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.resolve().Enabled(ctx, level)
+}
+
+func (h *logHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if id := RequestIDFromContext(ctx); id != "" {
+			r.AddAttrs(slog.String("request_id", id))
+		}
+
+		if span := SpanContextFromContext(ctx); span.IsValid() {
+			r.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+		}
+	}
+
+	return h.resolve().Handle(ctx, r)
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	return &logHandler{sink: h.sink, parent: h, attrs: attrs}
+}
+
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &logHandler{sink: h.sink, parent: h, group: name}
+}
+
+// initLogging configures the process log format from configuration.
+func (m *APIManager) initLogging() {
+	if e := logs.configure(logFormat.GetString(), m.registrar.AppName, logSource.GetBool()); e != nil {
+		logger.Error("unable to configure logging, continuing to log with klog", "error", e)
+	}
+}
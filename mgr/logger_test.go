@@ -0,0 +1,181 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+// captureLogs reconfigures the log sink to write in format to a buffer for the duration of the test.
+func captureLogs(t *testing.T, format string) *bytes.Buffer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	output := logOutput
+	logOutput = buf
+
+	original := currentLogVerbosity()
+	t.Cleanup(func() {
+		logOutput = output
+		logs.configure("klog", "", false)
+		setLogVerbosity(original)
+	})
+
+	setLogVerbosity(logVerbosity{verbosity: 0})
+	if e := logs.configure(format, "foo", false); e != nil {
+		t.Fatal(e)
+	}
+
+	return buf
+}
+
+func testSpanContext() trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func TestLoggerJSON(t *testing.T) {
+	// Loggers created before the sink is configured should follow it.
+	log := Logger("sub")
+	buf := captureLogs(t, "json")
+
+	decode := func(t *testing.T) map[string]any {
+		t.Helper()
+
+		record := map[string]any{}
+		if e := json.Unmarshal(buf.Bytes(), &record); e != nil {
+			t.Fatalf("invalid json record %q: %v", buf.String(), e)
+		}
+
+		buf.Reset()
+		return record
+	}
+
+	t.Run("standardFields", func(t *testing.T) {
+		log.Info("hello", "key", "value")
+
+		record := decode(t)
+		if record["app"] != "foo" || record["subsystem"] != "sub" || record["msg"] != "hello" || record["key"] != "value" {
+			t.Errorf("record = %v, missing standard fields", record)
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		ctx := trace.ContextWithSpanContext(ContextWithRequestID(context.Background(), "abc"), testSpanContext())
+		log.InfoContext(ctx, "hello")
+
+		record := decode(t)
+		if record["request_id"] != "abc" || record["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || record["span_id"] != "00f067aa0ba902b7" {
+			t.Errorf("record = %v, missing request fields", record)
+		}
+	})
+
+	t.Run("requestCtx", func(t *testing.T) {
+		ctx := &fasthttp.RequestCtx{}
+		SetRequestLogContext(ctx, "def", testSpanContext())
+		log.InfoContext(ctx, "hello")
+
+		record := decode(t)
+		if record["request_id"] != "def" || record["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("record = %v, missing request fields", record)
+		}
+	})
+
+	t.Run("verbosity", func(t *testing.T) {
+		log.Debug("hidden")
+		if buf.Len() != 0 {
+			t.Fatalf("debug record logged at verbosity 0: %s", buf.String())
+		}
+
+		setLogVerbosity(logVerbosity{verbosity: 4})
+		log.Debug("shown")
+		if record := decode(t); record["msg"] != "shown" {
+			t.Errorf("record = %v, want debug record at verbosity 4", record)
+		}
+
+		setLogVerbosity(logVerbosity{verbosity: 3})
+		log.Log(context.Background(), Verbosity(4), "hidden")
+		if buf.Len() != 0 {
+			t.Fatalf("verbosity 4 record logged at verbosity 3: %s", buf.String())
+		}
+
+		log.Log(context.Background(), Verbosity(3), "shown")
+		if record := decode(t); record["msg"] != "shown" || record["level"] != "DEBUG+1" {
+			t.Errorf("record = %v, want verbosity 3 record at verbosity 3", record)
+		}
+	})
+
+	t.Run("vmodule", func(t *testing.T) {
+		setLogVerbosity(logVerbosity{verbosity: 0, vmodule: "other=5"})
+		log.Log(context.Background(), Verbosity(5), "hidden")
+		if buf.Len() != 0 {
+			t.Fatalf("verbosity 5 record logged for a file without vmodule verbosity: %s", buf.String())
+		}
+
+		setLogVerbosity(logVerbosity{verbosity: 0, vmodule: "other=1,logger_*=5"})
+		log.Log(context.Background(), Verbosity(5), "shown")
+		if record := decode(t); record["msg"] != "shown" {
+			t.Errorf("record = %v, want verbosity 5 record with vmodule verbosity 5", record)
+		}
+
+		log.Log(context.Background(), Verbosity(6), "hidden")
+		if buf.Len() != 0 {
+			t.Fatalf("verbosity 6 record logged with vmodule verbosity 5: %s", buf.String())
+		}
+
+		setLogVerbosity(logVerbosity{verbosity: 0})
+	})
+
+	t.Run("klog", func(t *testing.T) {
+		klog.Info("from klog")
+
+		if record := decode(t); record["msg"] != "from klog" || record["app"] != "foo" {
+			t.Errorf("record = %v, want klog record routed through sink", record)
+		}
+	})
+}
+
+func TestLoggerLogfmt(t *testing.T) {
+	buf := captureLogs(t, "logfmt")
+
+	Logger("sub").InfoContext(ContextWithRequestID(context.Background(), "abc"), "hello world")
+
+	for _, want := range []string{`msg="hello world"`, "app=foo", "subsystem=sub", "request_id=abc"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("record %q does not contain %s", buf.String(), want)
+		}
+	}
+}
+
+func TestLoggerUnknownFormat(t *testing.T) {
+	if e := logs.configure("xml", "foo", false); e == nil {
+		t.Errorf("configure() wanted error for unknown format")
+	}
+}
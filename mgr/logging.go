@@ -0,0 +1,246 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fire833/go-api-utils/serialization"
+	"github.com/go-openapi/spec"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/klog/v2"
+)
+
+// logFlags is bound to the global klog state, so verbosity can be read and changed
+// at runtime the same way it is set at startup with flags.
+var logFlags *flag.FlagSet = func() *flag.FlagSet {
+	fs := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(fs)
+	return fs
+}()
+
+// logVerbosity is a snapshot of the klog verbosity settings.
+type logVerbosity struct {
+	verbosity int32
+	vmodule   string
+}
+
+// logState tracks temporary changes to the klog verbosity so they can be reverted.
+type logState struct {
+	m sync.Mutex
+
+	// If non-nil, the timer that will revert the verbosity back to baseline.
+	revert   *time.Timer
+	revertAt time.Time
+	baseline logVerbosity
+}
+
+func currentLogVerbosity() logVerbosity {
+	v, _ := strconv.Atoi(logFlags.Lookup("v").Value.String())
+	return logVerbosity{
+		verbosity: int32(v),
+		vmodule:   logFlags.Lookup("vmodule").Value.String(),
+	}
+}
+
+func setLogVerbosity(v logVerbosity) error {
+	if e := logFlags.Set("v", strconv.Itoa(int(v.verbosity))); e != nil {
+		return e
+	}
+
+	e := logFlags.Set("vmodule", v.vmodule)
+	loadVmodule()
+	return e
+}
+
+// setLogging updates the klog verbosity. If duration is nonzero, the verbosity is reverted to what
+// it was before the first temporary change after the duration. Otherwise, any pending revert is cancelled
+// and the change is kept.
+func (s *logState) setLogging(v logVerbosity, duration time.Duration) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	previous := currentLogVerbosity()
+	if e := setLogVerbosity(v); e != nil {
+		// Don't leave the verbosity half updated.
+		setLogVerbosity(previous)
+		return e
+	}
+
+	logger.Info("log verbosity changed", "previousVerbosity", previous.verbosity, "previousVmodule", previous.vmodule, "verbosity", v.verbosity, "vmodule", v.vmodule)
+
+	if s.revert != nil {
+		s.revert.Stop()
+		s.revert = nil
+	} else {
+		s.baseline = previous
+	}
+
+	if duration > 0 {
+		var t *time.Timer
+		t = time.AfterFunc(duration, func() {
+			// t is assigned while s.m is held, so it must be read under s.m as well.
+			s.m.Lock()
+			timer := t
+			s.m.Unlock()
+
+			s.revertLogging(timer)
+		})
+
+		s.revertAt = time.Now().Add(duration)
+		s.revert = t
+	}
+
+	return nil
+}
+
+// revertLogging restores the verbosity from before a temporary change. If timer is non-nil,
+// the verbosity is only reverted if that timer is still the pending revert, so a timer that
+// fired while being replaced can't revert a newer change early.
+func (s *logState) revertLogging(timer *time.Timer) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.revert == nil || (timer != nil && s.revert != timer) {
+		return
+	}
+
+	s.revert.Stop()
+	s.revert = nil
+
+	if e := setLogVerbosity(s.baseline); e != nil {
+		logger.Error("unable to revert log verbosity", "error", e)
+		return
+	}
+
+	logger.Info("log verbosity reverted", "verbosity", s.baseline.verbosity, "vmodule", s.baseline.vmodule)
+}
+
+func (s *logState) settings() *LoggingSettings {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	current := currentLogVerbosity()
+	settings := &LoggingSettings{
+		Verbosity: current.verbosity,
+		Vmodule:   current.vmodule,
+	}
+
+	if s.revert != nil {
+		settings.RevertTime = timestamppb.New(s.revertAt)
+		settings.RevertVerbosity = s.baseline.verbosity
+		settings.RevertVmodule = s.baseline.vmodule
+	}
+
+	return settings
+}
+
+// Return the current logging settings.
+func (m *APIManager) getLoggingHandler(ctx *fasthttp.RequestCtx) {
+	serialization.MarshalBodyByAcceptHeader(ctx, m.logging.settings())
+}
+
+// Update the logging settings, optionally reverting them after a duration.
+func (m *APIManager) setLoggingHandler(ctx *fasthttp.RequestCtx) {
+	args := ctx.QueryArgs()
+	v := currentLogVerbosity()
+
+	if !args.Has("verbosity") && !args.Has("vmodule") {
+		serialization.BadRequestResponseHandler(ctx, "at least one of verbosity or vmodule must be provided")
+		return
+	}
+
+	if args.Has("verbosity") {
+		level, e := strconv.ParseInt(string(args.Peek("verbosity")), 10, 32)
+		if e != nil || level < 0 {
+			serialization.BadRequestResponseHandler(ctx, "verbosity must be a non-negative integer")
+			return
+		}
+
+		v.verbosity = int32(level)
+	}
+
+	if args.Has("vmodule") {
+		v.vmodule = string(args.Peek("vmodule"))
+	}
+
+	var duration time.Duration
+	if args.Has("duration") {
+		d, e := time.ParseDuration(string(args.Peek("duration")))
+		if e != nil || d <= 0 {
+			serialization.BadRequestResponseHandler(ctx, "duration must be a positive duration, such as 5m")
+			return
+		}
+
+		duration = d
+	}
+
+	if e := m.logging.setLogging(v, duration); e != nil {
+		serialization.BadRequestResponseHandler(ctx, fmt.Sprintf("invalid logging settings: %v", e))
+		return
+	}
+
+	serialization.MarshalBodyByAcceptHeader(ctx, m.logging.settings())
+}
+
+// Immediately revert a temporary change to the logging settings.
+func (m *APIManager) revertLoggingHandler(ctx *fasthttp.RequestCtx) {
+	m.logging.revertLogging(nil)
+	serialization.MarshalBodyByAcceptHeader(ctx, m.logging.settings())
+}
+
+// loggingPathItem returns the swagger documentation for the /logging routes.
+func loggingPathItem() spec.PathItem {
+	return spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getLogging").
+				WithTags("sys", "logging").
+				WithDescription("Return the current log verbosity of the process, and if a temporary change was made, when and to what it will be reverted.").
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the current logging settings.").
+					WithSchema(spec.RefSchema("#/definitions/LoggingSettings"))),
+			Put: spec.NewOperation("setLogging").
+				WithTags("sys", "logging").
+				WithDescription("Change the log verbosity of the process. If a duration is provided, the verbosity is reverted to what it was before the change once the duration elapses, otherwise the change is kept and any pending revert is cancelled.").
+				AddParam(spec.QueryParam("verbosity").Typed("integer", "int32").WithMinimum(0, false).
+					WithDescription("The global klog verbosity, the same as the -v flag.")).
+				AddParam(spec.QueryParam("vmodule").Typed("string", "").
+					WithDescription("Comma separated list of <pattern>=<level> per-file verbosity overrides, the same as the -vmodule flag. An empty value clears all overrides.")).
+				AddParam(spec.QueryParam("duration").Typed("string", "").
+					WithDescription("How long the change should last before being reverted, such as 5m.")).
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the updated logging settings.").
+					WithSchema(spec.RefSchema("#/definitions/LoggingSettings"))).
+				RespondsWith(400, spec.NewResponse().
+					WithDescription("Returned if any of the settings are invalid, in which case nothing is changed.").
+					WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+			Delete: spec.NewOperation("revertLogging").
+				WithTags("sys", "logging").
+				WithDescription("Immediately revert a temporary change to the log verbosity. Does nothing if no temporary change is pending.").
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the reverted logging settings.").
+					WithSchema(spec.RefSchema("#/definitions/LoggingSettings"))),
+		},
+	}
+}
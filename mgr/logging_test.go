@@ -0,0 +1,134 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/klog/v2"
+)
+
+func loggingRequest(t *testing.T, m *APIManager, method, query string, code int) *LoggingSettings {
+	t.Helper()
+
+	ctx := sysAPIRequest(m, method, "/logging?"+query, "admintoken")
+	if ctx.Response.StatusCode() != code {
+		t.Fatalf("%s /logging?%s = %d, want %d: %s", method, query, ctx.Response.StatusCode(), code, ctx.Response.Body())
+	}
+
+	settings := &LoggingSettings{}
+	if code == 200 {
+		if e := protojson.Unmarshal(ctx.Response.Body(), settings); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	return settings
+}
+
+func TestSysAPILogging(t *testing.T) {
+	m := loadAuthApp(t)
+
+	original := currentLogVerbosity()
+	t.Cleanup(func() { setLogVerbosity(original) })
+	setLogVerbosity(logVerbosity{verbosity: 2})
+
+	t.Run("get", func(t *testing.T) {
+		settings := loggingRequest(t, m, fasthttp.MethodGet, "", 200)
+		if settings.Verbosity != 2 || settings.RevertTime != nil {
+			t.Errorf("settings = %+v, want verbosity 2 with no pending revert", settings)
+		}
+	})
+
+	t.Run("readOnlyDenied", func(t *testing.T) {
+		if ctx := sysAPIRequest(m, fasthttp.MethodPut, "/logging?verbosity=5", "readtoken"); ctx.Response.StatusCode() != 403 {
+			t.Errorf("status = %d, want 403", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		loggingRequest(t, m, fasthttp.MethodPut, "", 400)
+		loggingRequest(t, m, fasthttp.MethodPut, "verbosity=-1", 400)
+		loggingRequest(t, m, fasthttp.MethodPut, "verbosity=5&duration=soon", 400)
+		loggingRequest(t, m, fasthttp.MethodPut, "verbosity=5&vmodule=nolevel", 400)
+
+		if current := currentLogVerbosity(); current.verbosity != 2 || current.vmodule != "" {
+			t.Errorf("verbosity = %+v after invalid requests, want unchanged", current)
+		}
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		settings := loggingRequest(t, m, fasthttp.MethodPut, "verbosity=3&vmodule=sysapi*=6", 200)
+		if settings.Verbosity != 3 || settings.Vmodule != "sysapi*=6" || settings.RevertTime != nil {
+			t.Errorf("settings = %+v, want permanent change", settings)
+		}
+
+		if !klog.V(3).Enabled() || klog.V(4).Enabled() {
+			t.Errorf("klog verbosity not applied")
+		}
+	})
+
+	t.Run("temporary", func(t *testing.T) {
+		settings := loggingRequest(t, m, fasthttp.MethodPut, "verbosity=8&duration=1h", 200)
+		if settings.Verbosity != 8 || settings.RevertTime == nil || settings.RevertVerbosity != 3 {
+			t.Errorf("settings = %+v, want temporary change reverting to 3", settings)
+		}
+
+		// A second temporary change should still revert to the original settings.
+		settings = loggingRequest(t, m, fasthttp.MethodPut, "verbosity=9&duration=50ms", 200)
+		if settings.RevertVerbosity != 3 || settings.RevertVmodule != "sysapi*=6" {
+			t.Errorf("settings = %+v, want revert to original settings", settings)
+		}
+
+		deadline := time.Now().Add(time.Second * 5)
+		for currentLogVerbosity().verbosity != 3 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond * 10)
+		}
+
+		settings = loggingRequest(t, m, fasthttp.MethodGet, "", 200)
+		if settings.Verbosity != 3 || settings.Vmodule != "sysapi*=6" || settings.RevertTime != nil {
+			t.Errorf("settings = %+v, want reverted settings", settings)
+		}
+	})
+
+	t.Run("revertNow", func(t *testing.T) {
+		loggingRequest(t, m, fasthttp.MethodPut, "verbosity=7&vmodule=&duration=1h", 200)
+
+		settings := loggingRequest(t, m, fasthttp.MethodDelete, "", 200)
+		if settings.Verbosity != 3 || settings.Vmodule != "sysapi*=6" || settings.RevertTime != nil {
+			t.Errorf("settings = %+v, want reverted settings", settings)
+		}
+	})
+
+	t.Run("permanentCancelsRevert", func(t *testing.T) {
+		loggingRequest(t, m, fasthttp.MethodPut, "verbosity=7&duration=50ms", 200)
+		settings := loggingRequest(t, m, fasthttp.MethodPut, "verbosity=4", 200)
+		if settings.RevertTime != nil {
+			t.Errorf("settings = %+v, want no pending revert", settings)
+		}
+
+		time.Sleep(time.Millisecond * 100)
+		if v := currentLogVerbosity().verbosity; v != 4 {
+			t.Errorf("verbosity = %d, want 4", v)
+		}
+	})
+}
@@ -28,7 +28,6 @@ import (
 
 	"github.com/hashicorp/vault/api"
 	k8sauth "github.com/hashicorp/vault/api/auth/kubernetes"
-	"k8s.io/klog/v2"
 )
 
 // Global method used for initializing an APIManager instance. This includes registering
@@ -36,7 +35,7 @@ import (
 // provided registrar.
 func (m *APIManager) Initialize(registrar *SystemRegistrar) {
 	if registrar == nil {
-		klog.Error("nil registrar pointer provided to the process")
+		logger.Error("nil registrar pointer provided to the process")
 		return
 	}
 
@@ -51,30 +50,77 @@ func (m *APIManager) Initialize(registrar *SystemRegistrar) {
 
 	for name, sys := range m.systems {
 		configs := *sys.Configs()
-		klog.V(5).Infof("registering %d config keys for subsystem %s", len(configs), name)
+		logger.Log(context.Background(), Verbosity(5), "registering config keys for subsystem", "name", name, "count", len(configs))
 
 		m.ckeys = append(m.ckeys, configs...)
 
 		secrets := *sys.Secrets()
-		klog.V(5).Infof("registering %d secret keys for subsystem %s", len(secrets), name)
+		logger.Log(context.Background(), Verbosity(5), "registering secret keys for subsystem", "name", name, "count", len(secrets))
 
 		m.skeys = append(m.skeys, secrets...)
 	}
 
+	m.ckeys = append(m.ckeys, logFormat)
+	m.ckeys = append(m.ckeys, logSource)
+	m.ckeys = append(m.ckeys, serializationEmitUnpopulated)
+	m.ckeys = append(m.ckeys, serializationEnumNumbers)
+	m.ckeys = append(m.ckeys, serializationProtoNames)
+	m.ckeys = append(m.ckeys, serializationDiscardUnknown)
+	m.ckeys = append(m.ckeys, serializationMaxBodySize)
+	m.ckeys = append(m.ckeys, serializationMaxDepth)
+	m.ckeys = append(m.ckeys, serializationMaxYAMLAliases)
+	m.ckeys = append(m.ckeys, compressionMinSize)
+	m.ckeys = append(m.ckeys, compressionContentTypes)
+	m.ckeys = append(m.ckeys, compressionGzipLevel)
+	m.ckeys = append(m.ckeys, compressionDeflateLevel)
+	m.ckeys = append(m.ckeys, compressionBrotliLevel)
+	m.ckeys = append(m.ckeys, compressionZstdLevel)
+	m.ckeys = append(m.ckeys, compressionMaxDecompressedSize)
+	m.ckeys = append(m.ckeys, paginationDefaultLimit)
+	m.ckeys = append(m.ckeys, paginationMaxLimit)
+	m.ckeys = append(m.ckeys, paginationTokenTTL)
+	m.skeys = append(m.skeys, paginationTokenKey)
+
 	//
 	if m.opts.EnableSysAPI {
 		m.ckeys = append(m.ckeys, sysAPIListenAddress)
 		m.ckeys = append(m.ckeys, sysAPIListenPort)
+		m.ckeys = append(m.ckeys, sysAPISocketPath)
+		m.ckeys = append(m.ckeys, sysAPISocketMode)
+		m.ckeys = append(m.ckeys, sysAPISocketOwner)
+		m.ckeys = append(m.ckeys, sysAPISocketGroup)
+		m.ckeys = append(m.ckeys, sysAPIProbeListenAddress)
+		m.ckeys = append(m.ckeys, sysAPIProbeListenPort)
 		m.ckeys = append(m.ckeys, sysAPIConcurrency)
 		m.ckeys = append(m.ckeys, sysAPIIdleTimeout)
 		m.ckeys = append(m.ckeys, sysAPIReadTimeout)
 		m.ckeys = append(m.ckeys, sysAPIWriteTimeout)
 		m.ckeys = append(m.ckeys, sysAPIWriteBufferSize)
 		m.ckeys = append(m.ckeys, sysAPIReadBufferSize)
+		m.ckeys = append(m.ckeys, sysAPIAuthEnabled)
+		m.ckeys = append(m.ckeys, sysAPIDiagnosticsEnabled)
+		m.ckeys = append(m.ckeys, sysAPIDocsEnabled)
+		m.ckeys = append(m.ckeys, sysAPITLSCertFile)
+		m.ckeys = append(m.ckeys, sysAPITLSKeyFile)
+		m.ckeys = append(m.ckeys, sysAPITLSClientCAFile)
+		m.ckeys = append(m.ckeys, sysAPIAdminIdentities)
+		m.ckeys = append(m.ckeys, sysAPIReadOnlyIdentities)
+		m.ckeys = append(m.ckeys, sysAPIAdminGroups)
+		m.ckeys = append(m.ckeys, sysAPIReadOnlyGroups)
+		m.ckeys = append(m.ckeys, sysAPITokenReviewURL)
+		m.ckeys = append(m.ckeys, sysAPITokenReviewTokenFile)
+		m.ckeys = append(m.ckeys, sysAPITokenReviewCAFile)
+		m.ckeys = append(m.ckeys, sysAPITokenReviewAudiences)
+		m.ckeys = append(m.ckeys, sysAPITokenReviewCacheTTL)
+		m.skeys = append(m.skeys, sysAPIAdminTokens)
+		m.skeys = append(m.skeys, sysAPIReadOnlyTokens)
 	}
 
 	// read in configuration and secrets before booting further, or at least attempt to.
 	m.initConfigs()
+	m.initLogging()
+	m.initSerialization()
+	m.initPagination()
 
 	if m.opts.EnableVault {
 		m.initVault()
@@ -91,7 +137,7 @@ func (m *APIManager) Initialize(registrar *SystemRegistrar) {
 
 	// register collectors with the registry
 	if registrar.Registration != nil && m.registry != nil {
-		klog.V(5).Infof("registering collectors with manager registry")
+		logger.Log(context.Background(), Verbosity(5), "registering collectors with manager registry")
 		registrar.Registration.RegisterCollectors(m.registry)
 	}
 }
@@ -102,11 +148,19 @@ func (m *APIManager) SyncStartProcess() {
 	go m.handleSignals() // start signal handler
 
 	if m.opts.EnableSysAPI {
-		go m.startSysAPI() // start sysAPI.
+		// start sysAPI.
+		go func() {
+			// sysAPI serves the probes and admin endpoints, so don't keep running without it.
+			if e := m.startSysAPI(); e != nil {
+				logger.Error("sysAPI exited with error, shutting down", "error", e)
+				m.shutdownSubsystems()
+				os.Exit(1)
+			}
+		}()
 	}
 
 	for name, sys := range m.systems {
-		klog.V(4).Infof("synchronously starting subsystem %s", name)
+		logger.Log(context.Background(), Verbosity(4), "synchronously starting subsystem", "name", name)
 		go sys.SyncStart()
 	}
 
@@ -123,9 +177,9 @@ func (m *APIManager) SyncStartProcess() {
 			case <-m.shutdown:
 				return
 			case done := <-m.secretRenewer.DoneCh():
-				klog.Errorf("received error for vault credential renewals: %s", done)
+				logger.Error("received error for vault credential renewals", "error", done)
 			case renew := <-m.secretRenewer.RenewCh():
-				klog.Infof("successfully renewed vault credentials at %s for %d seconds", renew.RenewedAt, renew.Secret.LeaseDuration)
+				logger.Info("successfully renewed vault credentials", "renewedAt", renew.RenewedAt, "leaseSeconds", renew.Secret.LeaseDuration)
 			}
 		}
 	}
@@ -168,7 +222,7 @@ func (m *APIManager) initConfigs() {
 	}
 
 	if e := m.config.ReadInConfig(); e != nil {
-		klog.Errorf("ALERT: unable to read in configuration file! Relying on system defaults. Error: %v", e)
+		logger.Error("ALERT: unable to read in configuration file! Relying on system defaults.", "error", e)
 	}
 
 	// Register defualt values into secrets map.
@@ -181,7 +235,7 @@ func (m *APIManager) initConfigs() {
 	m.secrets.SetConfigName("secrets")
 
 	if e := m.secrets.ReadInConfig(); e != nil {
-		klog.Errorf("ALERT: unable to read in secrets file! Relying on system defaults. Error: %v", e)
+		logger.Error("ALERT: unable to read in secrets file! Relying on system defaults.", "error", e)
 	}
 }
 
@@ -214,23 +268,23 @@ func (m *APIManager) initVault() {
 	vaultToken := os.Getenv("VAULT_TOKEN")
 
 	if vaultToken == "" {
-		klog.Info("no VAULT_TOKEN provided, attempting to login with k8s")
+		logger.Info("no VAULT_TOKEN provided, attempting to login with k8s")
 		opts := []k8sauth.LoginOption{k8sauth.WithMountPath(m.config.GetString("vaultK8sAuthMountPath"))}
 
 		// Check if we have the serviceaccount token stored somewhere else, if so, add it to the options.
 		saToken := os.Getenv("VAULT_SA_TOKEN")
 		if saToken != "" {
-			klog.Info("found VAULT_SA_TOKEN, using for k8s auth process")
+			logger.Info("found VAULT_SA_TOKEN, using for k8s auth process")
 			opts = append(opts, k8sauth.WithServiceAccountToken(saToken))
 		}
 
 		k8s, e := k8sauth.NewKubernetesAuth(m.config.GetString("vaultK8sRole"), opts...)
 		if e != nil {
-			klog.Errorf("unable to retrieve kubernetes auth credentials: %v", e)
+			logger.Error("unable to retrieve kubernetes auth credentials", "error", e)
 		}
 		secret, e := client.Auth().Login(context.Background(), k8s)
 		if e != nil {
-			klog.Errorf("unable to login with kubernetes auth: %v", e)
+			logger.Error("unable to login with kubernetes auth", "error", e)
 		}
 
 		renewer, e := client.NewLifetimeWatcher(&api.LifetimeWatcherInput{
@@ -240,13 +294,13 @@ func (m *APIManager) initVault() {
 		})
 
 		if e == nil {
-			klog.V(3).Info("created renewer for automatically renewing client credentials")
+			logger.Log(context.Background(), Verbosity(3), "created renewer for automatically renewing client credentials")
 			m.secretRenewer = renewer
 		} else {
-			klog.Errorf("unable to create renewer for k8s auth: %s", e)
+			logger.Error("unable to create renewer for k8s auth", "error", e)
 		}
 	} else {
-		klog.Info("found VAULT_TOKEN, using for vault auth")
+		logger.Info("found VAULT_TOKEN, using for vault auth")
 		client.SetToken(vaultToken)
 	}
 
@@ -267,6 +321,7 @@ func (m *APIManager) handleSignals() {
 			}
 		case syscall.SIGHUP:
 			{
+				m.refreshVaultSecrets()
 				m.reloadSubsystems()
 			}
 		default:
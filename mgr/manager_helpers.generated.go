@@ -27,3 +27,13 @@ func (a *SubsystemStatusList) AddItem(item *SubsystemStatus) {
 func (a *BuildInfoList) AddItem(item *BuildInfo) {
 	a.Items = append(a.Items, item)
 }
+
+// AddItem appends a new VaultSecretStatus object to the existing list of items within the existing VaultSecretStatusList.
+func (a *VaultSecretStatusList) AddItem(item *VaultSecretStatus) {
+	a.Items = append(a.Items, item)
+}
+
+// AddItem appends a new RuntimeMetric object to the existing list of items within the existing RuntimeMetricList.
+func (a *RuntimeMetricList) AddItem(item *RuntimeMetric) {
+	a.Items = append(a.Items, item)
+}
@@ -39,6 +39,7 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SubsystemStatusList is a list of the statuses of subsystems within the process.
 type SubsystemStatusList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -86,6 +87,7 @@ func (x *SubsystemStatusList) GetItems() []*SubsystemStatus {
 	return nil
 }
 
+// BuildInfoList is a list of build information objects.
 type BuildInfoList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -133,6 +135,102 @@ func (x *BuildInfoList) GetItems() []*BuildInfo {
 	return nil
 }
 
+// VaultSecretStatusList is a list of the statuses of vault secrets backing the process.
+type VaultSecretStatusList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*VaultSecretStatus `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *VaultSecretStatusList) Reset() {
+	*x = VaultSecretStatusList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_manager_list_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VaultSecretStatusList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VaultSecretStatusList) ProtoMessage() {}
+
+func (x *VaultSecretStatusList) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_list_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VaultSecretStatusList.ProtoReflect.Descriptor instead.
+func (*VaultSecretStatusList) Descriptor() ([]byte, []int) {
+	return file_manager_list_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VaultSecretStatusList) GetItems() []*VaultSecretStatus {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// RuntimeMetricList is a list of runtime metric samples.
+type RuntimeMetricList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*RuntimeMetric `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *RuntimeMetricList) Reset() {
+	*x = RuntimeMetricList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_manager_list_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuntimeMetricList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeMetricList) ProtoMessage() {}
+
+func (x *RuntimeMetricList) ProtoReflect() protoreflect.Message {
+	mi := &file_manager_list_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeMetricList.ProtoReflect.Descriptor instead.
+func (*RuntimeMetricList) Descriptor() ([]byte, []int) {
+	return file_manager_list_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RuntimeMetricList) GetItems() []*RuntimeMetric {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 var File_manager_list_proto protoreflect.FileDescriptor
 
 var file_manager_list_proto_rawDesc = []byte{
@@ -146,9 +244,18 @@ var file_manager_list_proto_rawDesc = []byte{
 	0x65, 0x6d, 0x73, 0x22, 0x39, 0x0a, 0x0d, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f,
 	0x4c, 0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20,
 	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x42, 0x75,
-	0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x42, 0x0c,
-	0x5a, 0x0a, 0x2e, 0x2e, 0x2f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x49,
+	0x0a, 0x15, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x41, 0x0a, 0x11, 0x52, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2c,
+	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x42, 0x0c, 0x5a, 0x0a,
+	0x2e, 0x2e, 0x2f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -164,23 +271,29 @@ func file_manager_list_proto_rawDescGZIP() []byte {
 }
 
 var (
-	file_manager_list_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+	file_manager_list_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 	file_manager_list_proto_goTypes  = []interface{}{
-		(*SubsystemStatusList)(nil), // 0: manager.SubsystemStatusList
-		(*BuildInfoList)(nil),       // 1: manager.BuildInfoList
-		(*SubsystemStatus)(nil),     // 2: manager.SubsystemStatus
-		(*BuildInfo)(nil),           // 3: manager.BuildInfo
+		(*SubsystemStatusList)(nil),   // 0: manager.SubsystemStatusList
+		(*BuildInfoList)(nil),         // 1: manager.BuildInfoList
+		(*VaultSecretStatusList)(nil), // 2: manager.VaultSecretStatusList
+		(*RuntimeMetricList)(nil),     // 3: manager.RuntimeMetricList
+		(*SubsystemStatus)(nil),       // 4: manager.SubsystemStatus
+		(*BuildInfo)(nil),             // 5: manager.BuildInfo
+		(*VaultSecretStatus)(nil),     // 6: manager.VaultSecretStatus
+		(*RuntimeMetric)(nil),         // 7: manager.RuntimeMetric
 	}
 )
 
 var file_manager_list_proto_depIdxs = []int32{
-	2, // 0: manager.SubsystemStatusList.items:type_name -> manager.SubsystemStatus
-	3, // 1: manager.BuildInfoList.items:type_name -> manager.BuildInfo
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 0: manager.SubsystemStatusList.items:type_name -> manager.SubsystemStatus
+	5, // 1: manager.BuildInfoList.items:type_name -> manager.BuildInfo
+	6, // 2: manager.VaultSecretStatusList.items:type_name -> manager.VaultSecretStatus
+	7, // 3: manager.RuntimeMetricList.items:type_name -> manager.RuntimeMetric
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_manager_list_proto_init() }
@@ -214,6 +327,30 @@ func file_manager_list_proto_init() {
 				return nil
 			}
 		}
+		file_manager_list_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VaultSecretStatusList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_manager_list_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuntimeMetricList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -221,7 +358,7 @@ func file_manager_list_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_manager_list_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
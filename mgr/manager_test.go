@@ -21,6 +21,7 @@ package mgr
 import (
 	"testing"
 
+	"github.com/fire833/go-api-utils/fake/fakevault"
 	"k8s.io/klog/v2"
 )
 
@@ -80,3 +81,162 @@ func TestStartup(t *testing.T) {
 		m.shutdownSubsystems()
 	})
 }
+
+// loadVaultApp returns a manager backed by a fresh fake vault instance. The client
+// handed to the manager is unauthenticated, so tests are responsible for
+// logging in through initVault().
+func loadVaultApp(t *testing.T) (*APIManager, *fakevault.Server) {
+	t.Helper()
+
+	// Reset the manager to nil on every test
+	mgr = nil
+
+	v := fakevault.New()
+	t.Cleanup(v.Close)
+
+	client, e := v.Client("")
+	if e != nil {
+		t.Fatalf("unable to create fake vault client: %v", e)
+	}
+
+	m := New(&APIManagerOpts{
+		EnableVault: true,
+		VaultClient: client,
+	})
+	m.registrar = &SystemRegistrar{AppName: "foo"}
+	t.Cleanup(func() { mgr = nil })
+
+	return m, v
+}
+
+func TestInitVault(t *testing.T) {
+	t.Run("token", func(t *testing.T) {
+		m, v := loadVaultApp(t)
+		t.Setenv("VAULT_TOKEN", v.RootToken())
+
+		m.initVault()
+
+		if m.vault.Token() != v.RootToken() {
+			t.Errorf("initVault() token = %s, want %s", m.vault.Token(), v.RootToken())
+		}
+		if m.secretRenewer != nil {
+			t.Errorf("initVault() created renewer for static token")
+		}
+	})
+
+	t.Run("kubernetes", func(t *testing.T) {
+		m, v := loadVaultApp(t)
+		t.Setenv("VAULT_TOKEN", "")
+		t.Setenv("VAULT_SA_TOKEN", "sa-jwt")
+
+		v.AddKubernetesRole("k8s", "app", "sa-jwt")
+		m.config.Set("vaultK8sAuthMountPath", "k8s")
+		m.config.Set("vaultK8sRole", "app")
+
+		m.initVault()
+
+		if v.Logins() != 1 {
+			t.Errorf("initVault() logins = %d, want 1", v.Logins())
+		}
+		if m.vault.Token() == "" {
+			t.Errorf("initVault() did not set client token after kubernetes login")
+		}
+		if m.secretRenewer == nil {
+			t.Errorf("initVault() did not create renewer for kubernetes login")
+		}
+	})
+
+	t.Run("kubernetesDenied", func(t *testing.T) {
+		m, v := loadVaultApp(t)
+		t.Setenv("VAULT_TOKEN", "")
+		t.Setenv("VAULT_SA_TOKEN", "wrong-jwt")
+
+		v.AddKubernetesRole("kubernetes", "app", "sa-jwt")
+		m.config.Set("vaultK8sAuthMountPath", "kubernetes")
+		m.config.Set("vaultK8sRole", "app")
+
+		m.initVault()
+
+		if v.Logins() != 0 {
+			t.Errorf("initVault() logins = %d, want 0", v.Logins())
+		}
+		if m.secretRenewer != nil {
+			t.Errorf("initVault() created renewer for failed login")
+		}
+	})
+
+	t.Run("renewal", func(t *testing.T) {
+		m, v := loadVaultApp(t)
+		t.Setenv("VAULT_TOKEN", "")
+		t.Setenv("VAULT_SA_TOKEN", "sa-jwt")
+
+		v.AddKubernetesRole("kubernetes", "app", "sa-jwt")
+		m.config.Set("vaultK8sAuthMountPath", "kubernetes")
+		m.config.Set("vaultK8sRole", "app")
+
+		m.initVault()
+
+		if _, e := m.vault.Auth().Token().RenewSelf(3600); e != nil {
+			t.Errorf("unable to renew kubernetes login token: %v", e)
+		}
+		if v.Renewals() != 1 {
+			t.Errorf("renewals = %d, want 1", v.Renewals())
+		}
+	})
+}
+
+func TestGetVaultDbCreds(t *testing.T) {
+	t.Run("issued", func(t *testing.T) {
+		m, v := loadVaultApp(t)
+		t.Setenv("VAULT_TOKEN", v.RootToken())
+
+		v.AddDatabaseRole("database", "app")
+		m.config.Set("vaultDbMountPath", "database")
+		m.config.Set("vaultDbRole", "app")
+
+		m.initVault()
+
+		secret, watcher, e := m.GetVaultDbCreds()
+		if e != nil {
+			t.Fatalf("GetVaultDbCreds() error = %v", e)
+		}
+		if watcher == nil {
+			t.Errorf("GetVaultDbCreds() returned nil watcher")
+		}
+		if _, ok := secret.Data["username"]; !ok {
+			t.Errorf("GetVaultDbCreds() secret missing username")
+		}
+		if _, ok := secret.Data["password"]; !ok {
+			t.Errorf("GetVaultDbCreds() secret missing password")
+		}
+
+		if _, e := m.vault.Sys().Renew(secret.LeaseID, 3600); e != nil {
+			t.Errorf("unable to renew database lease: %v", e)
+		}
+	})
+
+	t.Run("unknownRole", func(t *testing.T) {
+		m, v := loadVaultApp(t)
+		t.Setenv("VAULT_TOKEN", v.RootToken())
+
+		v.AddDatabaseRole("database", "app")
+		m.config.Set("vaultDbMountPath", "database")
+		m.config.Set("vaultDbRole", "other")
+
+		m.initVault()
+
+		if _, _, e := m.GetVaultDbCreds(); e == nil {
+			t.Errorf("GetVaultDbCreds() wanted error for unknown role")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		mgr = nil
+		m := New(&APIManagerOpts{})
+		t.Cleanup(func() { mgr = nil })
+
+		if _, _, e := m.GetVaultDbCreds(); e == nil {
+			t.Errorf("GetVaultDbCreds() wanted error with vault disabled")
+		}
+	})
+}
@@ -0,0 +1,61 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"time"
+
+	"github.com/fire833/go-api-utils/pagination"
+)
+
+var (
+	paginationDefaultLimit *ConfigValue = NewConfigValue(
+		"paginationDefaultLimit",
+		"Specify the number of items within pages of lists when requests don't specify a limit.",
+		uint(pagination.DefaultOptions().DefaultLimit),
+	)
+
+	paginationMaxLimit *ConfigValue = NewConfigValue(
+		"paginationMaxLimit",
+		"Specify the maximum number of items within pages of lists, to which larger limits are reduced. Set to 0 for no limit.",
+		uint(pagination.DefaultOptions().MaxLimit),
+	)
+
+	paginationTokenTTL *ConfigValue = NewConfigValue(
+		"paginationTokenTTL",
+		"Specify how long (in seconds) page tokens are valid for after they are issued. Set to 0 for no limit.",
+		uint(pagination.DefaultOptions().TokenTTL/time.Second),
+	)
+
+	paginationTokenKey *SecretValue = NewSecretValue(
+		"paginationTokenKey",
+		"Specify the key page tokens are signed with, which must be the same for every replica of the application. If empty, a random key is generated, so tokens are only valid within the process that issued them.",
+		"",
+	)
+)
+
+// initPagination configures the size of pages and the signing of page tokens from configuration.
+func (m *APIManager) initPagination() {
+	pagination.SetOptions(pagination.Options{
+		Key:          []byte(paginationTokenKey.GetString()),
+		DefaultLimit: int(paginationDefaultLimit.GetUint()),
+		MaxLimit:     int(paginationMaxLimit.GetUint()),
+		TokenTTL:     time.Duration(paginationTokenTTL.GetUint()) * time.Second,
+	})
+}
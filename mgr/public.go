@@ -19,11 +19,13 @@
 package mgr
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 
 	"github.com/fasthttp/router"
+	"github.com/fire833/go-api-utils/serialization"
 	"github.com/go-openapi/spec"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/viper"
@@ -41,9 +43,12 @@ func New(opts *APIManagerOpts) *APIManager {
 		config:        viper.New(),
 		secrets:       viper.New(),
 		registry:      prometheus.NewRegistry(),
-		vault:         nil,
+		vault:         opts.VaultClient,
 		secretRenewer: nil,
+		vaultSecrets:  make(map[string]*vaultSecret),
 		router:        router.New(),
+		sysAPIRoles:   make(map[string]SysAPIRole),
+		logging:       &logState{},
 		spec:          nil, // Start with null, the spec should be generated on Initialize().
 		server:        nil, // Start with null, the server should be started on Initialize().
 		sigHandle:     make(chan os.Signal, 5),
@@ -70,11 +75,53 @@ func New(opts *APIManagerOpts) *APIManager {
 // careful with what handlers you are exposing with this method.
 // You have been warned.
 //
+// When sysAPI authentication is enabled, GET, HEAD and OPTIONS handlers registered with this
+// method require read-only access, and handlers for all other methods require admin access.
+// Use RegisterSysAPIHandlerWithRole to require a different role.
+//
 // Also, please ensure you are providing ACCURATE swagger specification objects
 // along with this handler registration request, as these will be integrated with the server's
 // swagger spec. These objects will then be served by /swagger.json, and it will make integration
 // MUCH easier if the actual behavior of the endpoint is reflected in the swagger documentation.
 func RegisterSysAPIHandler(method, path string, handler fasthttp.RequestHandler, swaggerdoc spec.PathItem, schemas ...*spec.Schema) error {
+	return RegisterSysAPIHandlerWithRole(method, path, defaultSysAPIRole(method), handler, swaggerdoc, schemas...)
+}
+
+// RegisterSysAPIHandlerWithRole is the same as RegisterSysAPIHandler, but callers must be
+// granted the provided role in order to call the handler when sysAPI authentication is enabled.
+//
+// If the swagger path item has an operation for method, only that operation is documented, along
+// with any parameters of the path item, so handlers for several methods can be registered on the
+// same path. Registration then fails without registering the handler or modifying the spec if there
+// is already a handler for method on the path, or a different schema with the same title.
+//
+// Otherwise, the path item is documented as provided, keeping any operations already documented
+// on the path.
+func RegisterSysAPIHandlerWithRole(method, path string, role SysAPIRole, handler fasthttp.RequestHandler, swaggerdoc spec.PathItem, schemas ...*spec.Schema) error {
+	slot := serialization.PathItemOperation(&swaggerdoc, method)
+	if slot == nil || *slot == nil {
+		return registerSysAPIPathItem(method, path, role, handler, swaggerdoc, schemas)
+	}
+
+	op := **slot
+	op.Parameters = append(append([]spec.Parameter{}, op.Parameters...), swaggerdoc.Parameters...)
+
+	return registerSysAPIOperation(method, path, role, handler, &op, schemas)
+}
+
+// RegisterSysAPIEndpoint registers an endpoint built from a serialization.Route with SysAPI, see
+// RegisterSysAPIHandler. The same cautions apply.
+func RegisterSysAPIEndpoint(e *serialization.Endpoint) error {
+	return RegisterSysAPIEndpointWithRole(e, defaultSysAPIRole(e.Method))
+}
+
+// RegisterSysAPIEndpointWithRole is the same as RegisterSysAPIEndpoint, but callers must be
+// granted the provided role in order to call the endpoint when sysAPI authentication is enabled.
+func RegisterSysAPIEndpointWithRole(e *serialization.Endpoint, role SysAPIRole) error {
+	return registerSysAPIOperation(e.Method, e.Path, role, e.Handler, e.Operation, e.Schemas)
+}
+
+func registerSysAPIOperation(method, path string, role SysAPIRole, handler fasthttp.RequestHandler, op *spec.Operation, schemas []*spec.Schema) error {
 	m := mgr
 	if m == nil {
 		return errors.New("global APIManager not initialized")
@@ -83,23 +130,50 @@ func RegisterSysAPIHandler(method, path string, handler fasthttp.RequestHandler,
 	m.m.Lock()
 	defer m.m.Unlock()
 
-	m.router.Handle(method, path, handler)
-	if err := recover(); err != nil {
-		return fmt.Errorf("handler registration error: %v", err)
+	if m.spec == nil {
+		return errors.New("sysAPI not initialized")
+	}
+
+	// Document a copy of the operation, so the caller's operation is left untouched if
+	// the registration fails.
+	data, e := json.Marshal(op)
+	if e != nil {
+		return fmt.Errorf("unable to copy sysAPI operation: %w", e)
 	}
 
-	if _, exists := m.spec.Paths.Paths[path]; exists {
-		return errors.New("path already registered with SysAPI server")
+	doc := &spec.Operation{}
+	if e := json.Unmarshal(data, doc); e != nil {
+		return fmt.Errorf("unable to copy sysAPI operation: %w", e)
 	}
 
-	m.spec.Paths.Paths[path] = swaggerdoc
+	documentSysAPIAuth(doc, role)
+
+	if e := serialization.RegisterOperation(m.spec, method, path, doc, schemas, func() {
+		m.handleSysAPI(method, path, role, handler)
+	}); e != nil {
+		return fmt.Errorf("unable to register sysAPI handler: %w", e)
+	}
+
+	return nil
+}
+
+func registerSysAPIPathItem(method, path string, role SysAPIRole, handler fasthttp.RequestHandler, item spec.PathItem, schemas []*spec.Schema) error {
+	m := mgr
+	if m == nil {
+		return errors.New("global APIManager not initialized")
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if m.spec == nil {
+		return errors.New("sysAPI not initialized")
+	}
 
-	for _, schema := range schemas {
-		if _, exists := m.spec.Definitions[schema.Title]; exists {
-			return errors.New("schema exists already within sysAPI spec")
-		} else {
-			m.spec.Definitions[schema.Title] = *schema
-		}
+	if e := serialization.RegisterPathItem(m.spec, path, item, schemas, func() {
+		m.handleSysAPI(method, path, role, handler)
+	}); e != nil {
+		return fmt.Errorf("unable to register sysAPI handler: %w", e)
 	}
 
 	return nil
@@ -20,6 +20,7 @@ package mgr
 
 import (
 	"github.com/fasthttp/router"
+	"github.com/fire833/go-api-utils/serialization"
 	"github.com/go-openapi/spec"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -77,3 +78,12 @@ type AppRegistration interface {
 	// this application. This allows for admins to toggle which spans are collected at runtime.
 	RegisterOTELTraces() []string
 }
+
+// RouteRegistration may optionally be implemented by an AppRegistration to register endpoints
+// built with serialization.Route, so that each handler is registered along with its documentation.
+// Endpoints are registered after those registered with RegisterEndpoints and RegisterSwagger2.
+type RouteRegistration interface {
+	// RegisterRoutes should return all endpoints of the application, with their paths
+	// beginning with prefix.
+	RegisterRoutes(prefix string) []*serialization.Endpoint
+}
@@ -0,0 +1,140 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import "github.com/fire833/go-api-utils/serialization"
+
+var (
+	serializationEmitUnpopulated *ConfigValue = NewConfigValue(
+		"serializationEmitUnpopulated",
+		"Include fields with their zero value within JSON, YAML, MessagePack and CBOR responses, rather than omitting them.",
+		false,
+	)
+
+	serializationEnumNumbers *ConfigValue = NewConfigValue(
+		"serializationEnumNumbers",
+		"Encode enums as their numbers within JSON, YAML, MessagePack and CBOR responses, rather than their names.",
+		false,
+	)
+
+	serializationProtoNames *ConfigValue = NewConfigValue(
+		"serializationProtoNames",
+		"Encode fields with their original .proto names within JSON, YAML, MessagePack and CBOR responses (ie error_code), rather than their JSON names (ie errorCode).",
+		false,
+	)
+
+	serializationDiscardUnknown *ConfigValue = NewConfigValue(
+		"serializationDiscardUnknown",
+		"Ignore unknown fields within request bodies. If disabled, requests containing unknown fields are rejected with the path of the field.",
+		true,
+	)
+
+	serializationMaxBodySize *ConfigValue = NewConfigValue(
+		"serializationMaxBodySize",
+		"Specify the maximum size (in bytes) of request bodies that are decoded, larger requests are rejected. Set to 0 for no limit.",
+		uint(serialization.DefaultDecodeLimits().MaxBodySize),
+	)
+
+	serializationMaxDepth *ConfigValue = NewConfigValue(
+		"serializationMaxDepth",
+		"Specify the maximum nesting depth of objects and arrays within request bodies, deeper requests are rejected. Set to 0 for no limit.",
+		uint(serialization.DefaultDecodeLimits().MaxDepth),
+	)
+
+	serializationMaxYAMLAliases *ConfigValue = NewConfigValue(
+		"serializationMaxYAMLAliases",
+		"Specify the maximum number of aliases expanded within YAML request bodies, requests expanding more are rejected. Set to 0 for no limit.",
+		uint(serialization.DefaultDecodeLimits().MaxYAMLAliases),
+	)
+
+	compressionMinSize *ConfigValue = NewConfigValue(
+		"compressionMinSize",
+		"Specify the minimum size (in bytes) of response bodies that are compressed, as smaller responses cost more to compress than is saved.",
+		uint(1024),
+	)
+
+	compressionContentTypes *ConfigValue = NewConfigValue(
+		"compressionContentTypes",
+		"Specify the media types of responses that are compressed, which may contain wildcards (ie text/* or application/*+json). Set to an empty list to disable response compression.",
+		serialization.DefaultCompressionOptions().ContentTypes,
+	)
+
+	compressionGzipLevel *ConfigValue = NewConfigValue(
+		"compressionGzipLevel",
+		"Specify the gzip compression level of responses, between 1 (best speed) and 9 (best compression).",
+		serialization.DefaultCompressionOptions().GzipLevel,
+	)
+
+	compressionDeflateLevel *ConfigValue = NewConfigValue(
+		"compressionDeflateLevel",
+		"Specify the deflate compression level of responses, between 1 (best speed) and 9 (best compression).",
+		serialization.DefaultCompressionOptions().DeflateLevel,
+	)
+
+	compressionBrotliLevel *ConfigValue = NewConfigValue(
+		"compressionBrotliLevel",
+		"Specify the brotli compression level of responses, between 0 (best speed) and 11 (best compression).",
+		serialization.DefaultCompressionOptions().BrotliLevel,
+	)
+
+	compressionZstdLevel *ConfigValue = NewConfigValue(
+		"compressionZstdLevel",
+		"Specify the zstd compression level of responses, between 1 (best speed) and 4 (best compression).",
+		serialization.DefaultCompressionOptions().ZstdLevel,
+	)
+
+	compressionMaxDecompressedSize *ConfigValue = NewConfigValue(
+		"compressionMaxDecompressedSize",
+		"Specify the maximum size (in bytes) of compressed request bodies once decompressed, larger requests are rejected. Set to 0 for no limit.",
+		uint64(serialization.DefaultCompressionOptions().MaxDecompressedSize),
+	)
+)
+
+// initSerialization configures the codecs encoding objects with the protobuf JSON mapping, the
+// limits of request bodies, and the compression of requests and responses, from configuration.
+func (m *APIManager) initSerialization() {
+	opts := serialization.ProtoJSONOptions{
+		EmitUnpopulated: serializationEmitUnpopulated.GetBool(),
+		UseEnumNumbers:  serializationEnumNumbers.GetBool(),
+		UseProtoNames:   serializationProtoNames.GetBool(),
+		DiscardUnknown:  serializationDiscardUnknown.GetBool(),
+	}
+
+	serialization.RegisterCodec(serialization.NewJSONCodec(opts))
+	serialization.RegisterCodec(serialization.NewYAMLCodec(opts))
+	serialization.RegisterCodec(serialization.NewMsgPackCodec(opts))
+	serialization.RegisterCodec(serialization.NewCBORCodec(opts))
+
+	serialization.SetDecodeLimits(serialization.DecodeLimits{
+		MaxBodySize:           int(serializationMaxBodySize.GetUint()),
+		MaxDepth:              int(serializationMaxDepth.GetUint()),
+		MaxYAMLAliases:        int(serializationMaxYAMLAliases.GetUint()),
+		DisallowUnknownFields: !serializationDiscardUnknown.GetBool(),
+	})
+
+	serialization.SetCompressionOptions(serialization.CompressionOptions{
+		MinSize:             int(compressionMinSize.GetUint()),
+		ContentTypes:        compressionContentTypes.GetStringSlice(),
+		GzipLevel:           compressionGzipLevel.GetInt(),
+		DeflateLevel:        compressionDeflateLevel.GetInt(),
+		BrotliLevel:         compressionBrotliLevel.GetInt(),
+		ZstdLevel:           compressionZstdLevel.GetInt(),
+		MaxDecompressedSize: int64(compressionMaxDecompressedSize.GetUint64()),
+	})
+}
@@ -19,11 +19,10 @@
 package mgr
 
 import (
+	"context"
 	"os"
 	"sync"
 	"time"
-
-	"k8s.io/klog/v2"
 )
 
 func (m *APIManager) initializeSubsystems(reg *SystemRegistrar) {
@@ -35,30 +34,30 @@ func (m *APIManager) initializeSubsystems(reg *SystemRegistrar) {
 	errChan := make(chan bool, len(m.systems))
 
 	for name, sys := range m.systems {
-		klog.V(3).Infof("initializing subsystem %s", name)
+		logger.Log(context.Background(), Verbosity(3), "initializing subsystem", "name", name)
 		go func(s Subsystem, wg *sync.WaitGroup, errChan chan<- bool) {
 			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
-					klog.Errorf("subsystem %s panicked whilst initializing: %v", s.Name(), r)
+					logger.Error("subsystem panicked whilst initializing", "name", s.Name(), "panic", r)
 				}
 			}()
 
 			for i := 1; i <= 3; i++ {
 				if e := s.Initialize(reg); e != nil {
-					klog.Errorf("unable to initialize subsystem %s (error: %s) %d times. Waiting 10 seconds to retry", s.Name(), e.Error(), i)
+					logger.Error("unable to initialize subsystem, waiting 10 seconds to retry", "name", s.Name(), "error", e, "attempt", i)
 					time.Sleep(time.Second * 10) // Wait for 10 seconds to try and reinitialize
 					continue
 				}
 
 				if e := m.registry.Register(s); e != nil {
-					klog.Errorf("unable to register subsystem %s with registry: %s", s.Name(), e)
+					logger.Error("unable to register subsystem with registry", "name", s.Name(), "error", e)
 				}
 
 				return
 			}
 
-			klog.Errorf("3 retries attempted to initialize subsystem %s, all failed", s.Name())
+			logger.Error("3 retries attempted to initialize subsystem, all failed", "name", s.Name())
 			errChan <- true
 		}(sys, wg, errChan)
 	}
@@ -75,18 +74,18 @@ func (m *APIManager) initializeSubsystems(reg *SystemRegistrar) {
 }
 
 func (m *APIManager) reloadSubsystems() {
-	klog.V(4).Infof("reload signal received, forwarding to %d subsystems", len(m.systems))
+	logger.Log(context.Background(), Verbosity(4), "reload signal received, forwarding to subsystems", "count", len(m.systems))
 
 	wg := new(sync.WaitGroup)
 	wg.Add(len(m.systems))
 
 	for name, sys := range m.systems {
-		klog.V(5).Infof("sending reload update for subsystem %s", name)
+		logger.Log(context.Background(), Verbosity(5), "sending reload update for subsystem", "name", name)
 		go func(sys Subsystem, wg *sync.WaitGroup) {
 			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
-					klog.Errorf("subsystem %s panicked whilst reloading: %v", sys.Name(), r)
+					logger.Error("subsystem panicked whilst reloading", "name", sys.Name(), "panic", r)
 				}
 			}()
 
@@ -95,22 +94,22 @@ func (m *APIManager) reloadSubsystems() {
 	}
 
 	wg.Wait()
-	klog.V(5).Info("reloading of subsystems complete")
+	logger.Log(context.Background(), Verbosity(5), "reloading of subsystems complete")
 }
 
 func (m *APIManager) shutdownSubsystems() {
-	klog.V(4).Infof("shutdown signal received, forwarding to %d subsystems", len(m.systems))
+	logger.Log(context.Background(), Verbosity(4), "shutdown signal received, forwarding to subsystems", "count", len(m.systems))
 
 	wg := new(sync.WaitGroup)
 	wg.Add(len(m.systems))
 
 	for name, sys := range m.systems {
-		klog.V(5).Infof("sending shutdown update for subsystem %s", name)
+		logger.Log(context.Background(), Verbosity(5), "sending shutdown update for subsystem", "name", name)
 		go func(sys Subsystem, wg *sync.WaitGroup) {
 			defer wg.Done()
 			defer func() {
 				if r := recover(); r != nil {
-					klog.Errorf("subsystem %s panicked whilst shutting down: %v", sys.Name(), r)
+					logger.Error("subsystem panicked whilst shutting down", "name", sys.Name(), "panic", r)
 				}
 			}()
 
@@ -119,11 +118,17 @@ func (m *APIManager) shutdownSubsystems() {
 	}
 
 	wg.Wait()
-	klog.V(5).Info("shutdown of subsystems complete")
+	logger.Log(context.Background(), Verbosity(5), "shutdown of subsystems complete")
 
 	if m.opts.EnableSysAPI {
 		if e := m.server.Shutdown(); e != nil {
-			klog.Errorf("unable to gracefully shutdown sysAPI: %v", e)
+			logger.Error("unable to gracefully shutdown sysAPI", "error", e)
+		}
+
+		if m.probeServer != nil {
+			if e := m.probeServer.Shutdown(); e != nil {
+				logger.Error("unable to gracefully shutdown sysAPI probes", "error", e)
+			}
 		}
 	}
 }
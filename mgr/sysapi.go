@@ -19,18 +19,28 @@
 package mgr
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/fasthttp/router"
+	"github.com/fire833/go-api-utils/buildinfo"
+	"github.com/fire833/go-api-utils/openapi"
 	"github.com/fire833/go-api-utils/serialization"
 	"github.com/go-openapi/spec"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/fasthttpadaptor"
-	"k8s.io/klog/v2"
 )
 
 type ConfigInfo struct {
@@ -41,6 +51,9 @@ type ConfigInfo struct {
 type SecretInfo struct {
 	Meta  *SecretValue `json:"meta" yaml:"meta" xml:"meta"`
 	Value interface{}  `json:"value" yaml:"value" xml:"value"`
+
+	// The version of the backing vault secret loaded into the process, if sourced from vault.
+	Version int `json:"version,omitempty" yaml:"version,omitempty" xml:"version,omitempty"`
 }
 
 var (
@@ -56,6 +69,42 @@ var (
 		uint16(8081),
 	)
 
+	sysAPISocketPath *ConfigValue = NewConfigValue(
+		"sysAPISocketPath",
+		"Specify the path of a unix domain socket to serve sysAPI on. If set, sysAPI is served on this socket instead of sysAPIListenAddress:sysAPIListenPort, which is useful for only allowing access from sidecars sharing a volume with the app process.",
+		"",
+	)
+
+	sysAPISocketMode *ConfigValue = NewConfigValue(
+		"sysAPISocketMode",
+		"Specify the file mode of the sysAPI unix domain socket, in octal.",
+		"0660",
+	)
+
+	sysAPISocketOwner *ConfigValue = NewConfigValue(
+		"sysAPISocketOwner",
+		"Specify the user name or ID that should own the sysAPI unix domain socket. If unset, the socket is owned by the app process user.",
+		"",
+	)
+
+	sysAPISocketGroup *ConfigValue = NewConfigValue(
+		"sysAPISocketGroup",
+		"Specify the group name or ID that should own the sysAPI unix domain socket. If unset, the socket is owned by the app process group.",
+		"",
+	)
+
+	sysAPIProbeListenAddress *ConfigValue = NewConfigValue(
+		"sysAPIProbeListenAddress",
+		"Specify the listening address of the sysAPI probe listener.",
+		"0.0.0.0",
+	)
+
+	sysAPIProbeListenPort *ConfigValue = NewConfigValue(
+		"sysAPIProbeListenPort",
+		"Specify the listening port of the sysAPI probe listener. If nonzero, /livez, /readyz and /metrics are additionally served without authentication on this port, so the primary sysAPI listener can be kept private.",
+		uint16(0),
+	)
+
 	sysAPIConcurrency *ConfigValue = NewConfigValue(
 		"sysAPIConcurrency",
 		"Specify the amount of concurrent connections to be allowed to the SysAPI webserver concurrently.",
@@ -86,6 +135,12 @@ var (
 		uint(120),
 	)
 
+	sysAPIDocsEnabled *ConfigValue = NewConfigValue(
+		"sysAPIDocsEnabled",
+		"Toggle whether sysAPI serves an embedded API documentation UI for its OpenAPI document at /docs. The UI itself is public, but loading the document requires read access.",
+		true,
+	)
+
 	sysAPIIdleTimeout *ConfigValue = NewConfigValue(
 		"sysAPIIdleTimeout",
 		"IdleTimeout is the maximum amount of time (in seconds) to wait for the next request when keep-alive is enabled.",
@@ -93,8 +148,66 @@ var (
 	)
 )
 
-func (m *APIManager) initSysAPI() {
-	ser := &fasthttp.Server{
+// newBuildInfo converts the build information of the process for serving over sysAPI.
+func newBuildInfo(info buildinfo.Info) *BuildInfo {
+	bi := &BuildInfo{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuildTime: info.BuildTime,
+		Os:        info.Os,
+		Arch:      info.Arch,
+		GoVersion: info.GoVersion,
+		Dirty:     info.Dirty,
+		Path:      info.Path,
+	}
+
+	for _, dep := range info.Deps {
+		bi.Dependencies = append(bi.Dependencies, &BuildDependency{
+			Path:    dep.Path,
+			Version: dep.Version,
+			Sum:     dep.Sum,
+			Replace: dep.Replace,
+		})
+	}
+
+	return bi
+}
+
+// openAPIDocument returns the OpenAPI 3.1 document for sysAPI, converted from the swagger spec so
+// it includes every handler registered with RegisterSysAPIHandler.
+func (m *APIManager) openAPIDocument() *openapi.Document {
+	m.m.RLock()
+	defer m.m.RUnlock()
+
+	return openapi.FromSwagger(m.spec)
+}
+
+// initSysAPIDocs registers the documentation UI with sysAPI and documents it within the
+// provided spec, if enabled.
+func (m *APIManager) initSysAPIDocs(s *spec.Swagger) {
+	if !sysAPIDocsEnabled.GetBool() {
+		return
+	}
+
+	docs := openapi.DocsHandler("/docs", "/openapi.json", m.registrar.AppName+" SysAPI")
+	m.handleSysAPI(fasthttp.MethodGet, "/docs", SysAPIRolePublic, docs)
+	m.handleSysAPI(fasthttp.MethodGet, "/docs/{FILE}", SysAPIRolePublic, docs)
+
+	s.Paths.Paths["/docs"] = spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getDocs").
+				WithTags("sys").
+				WithProduces("text/html").
+				WithDescription("Serve the embedded documentation UI for the sysAPI OpenAPI document. The token used for reading the document and trying out requests can be provided within the UI.").
+				RespondsWith(200, spec.NewResponse().
+					WithDescription("Returns the documentation UI.")),
+		},
+	}
+}
+
+// newSysAPIServer returns a new sysAPI webserver serving the provided handler.
+func newSysAPIServer(handler fasthttp.RequestHandler) *fasthttp.Server {
+	return &fasthttp.Server{
 		// overwrite the server name for a bit more obfuscation.
 		Name: "null",
 
@@ -119,14 +232,18 @@ func (m *APIManager) initSysAPI() {
 		WriteTimeout:    time.Duration(time.Second * time.Duration(sysAPIWriteTimeout.GetUint())),
 		IdleTimeout:     time.Duration(time.Second * time.Duration(sysAPIIdleTimeout.GetUint())),
 
-		Handler: m.router.Handler,
+		Handler: handler,
 	}
+}
+
+func (m *APIManager) initSysAPI() {
+	ser := newSysAPIServer(serialization.CompressionHandler(m.router.Handler))
 
 	// The primary spec object for sysAPI. Can have other stuff registered to it through RegisterSysAPIHandler().
 	spec := &spec.Swagger{
 		SwaggerProps: spec.SwaggerProps{
-			Consumes: []string{"application/json", "application/xml", "application/yaml", "application/API+Protobuf"},
-			Produces: []string{"application/json", "application/xml", "application/yaml", "application/API+Protobuf"},
+			Consumes: serialization.MediaTypes(),
+			Produces: serialization.MediaTypes(),
 			Swagger:  "2.0",
 			Info: &spec.Info{
 				InfoProps: spec.InfoProps{
@@ -155,6 +272,22 @@ func (m *APIManager) initSysAPI() {
 									WithDescription("Returns the current swagger specification file for sysAPI on the current app instance being queried. This will always be returned in JSON format.")),
 						},
 					},
+					"/openapi.json": {
+						PathItemProps: spec.PathItemProps{
+							Get: spec.NewOperation("getOpenAPIJSON").
+								WithTags("sys").
+								RespondsWith(200, spec.NewResponse().
+									WithDescription("Returns the OpenAPI 3.1 document for sysAPI on the current app instance being queried, in JSON format.")),
+						},
+					},
+					"/openapi.yaml": {
+						PathItemProps: spec.PathItemProps{
+							Get: spec.NewOperation("getOpenAPIYAML").
+								WithTags("sys").
+								RespondsWith(200, spec.NewResponse().
+									WithDescription("Returns the OpenAPI 3.1 document for sysAPI on the current app instance being queried, in YAML format.")),
+						},
+					},
 					"/readyz": {
 						PathItemProps: spec.PathItemProps{
 							Get: spec.NewOperation("getReady").
@@ -195,6 +328,93 @@ func (m *APIManager) initSysAPI() {
 									WithSchema(spec.ArrayProperty(spec.RefSchema("#/definitions/ConfigKeyValue")))),
 						},
 					},
+					"/secrets/vault": {
+						PathItemProps: spec.PathItemProps{
+							Get: spec.NewOperation("getVaultSecrets").
+								WithTags("sys", "vault").
+								WithDescription("Returns every vault KV secret backing secret values within the app process, the version currently loaded by the process, and all versions available within vault.").
+								RespondsWith(200, spec.NewResponse().
+									WithDescription("Returns the status of all vault secrets within the app process.").
+									WithSchema(spec.RefSchema("#/definitions/VaultSecretStatusList"))),
+						},
+					},
+					"/secrets/vault/refresh": {
+						PathItemProps: spec.PathItemProps{
+							Put: spec.NewOperation("refreshVaultSecrets").
+								WithTags("sys", "vault").
+								WithDescription("Force a reload of vault secrets from vault. If mountPath and path are provided, only that secret will be reloaded, otherwise all secrets are reloaded. Pinned secrets will reload their pinned version.").
+								AddParam(spec.QueryParam("mountPath").Typed("string", "").WithDescription("The mount path of the KV engine containing the secret.")).
+								AddParam(spec.QueryParam("path").Typed("string", "").WithDescription("The path of the secret within the KV engine.")).
+								RespondsWith(200, spec.NewResponse().
+									WithDescription("Returns the status of the refreshed secret, or of all secrets if none was specified.").
+									WithSchema(spec.RefSchema("#/definitions/VaultSecretStatusList"))).
+								RespondsWith(400, spec.NewResponse().
+									WithDescription("Returned if only one of mountPath and path were provided.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))).
+								RespondsWith(404, spec.NewResponse().
+									WithDescription("Returned if the secret isn't referenced by any secret values within the process.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))).
+								RespondsWith(500, spec.NewResponse().
+									WithDescription("Returned if the secret could not be reloaded from vault.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+						},
+					},
+					"/secrets/vault/pin": {
+						PathItemProps: spec.PathItemProps{
+							Put: spec.NewOperation("pinVaultSecret").
+								WithTags("sys", "vault").
+								WithDescription("Pin a vault secret to a specific version within the app process. The pinned version is loaded immediately, and will continue to be used until the secret is unpinned. This only affects the instance being queried.").
+								AddParam(spec.QueryParam("mountPath").Typed("string", "").AsRequired().WithDescription("The mount path of the KV engine containing the secret.")).
+								AddParam(spec.QueryParam("path").Typed("string", "").AsRequired().WithDescription("The path of the secret within the KV engine.")).
+								AddParam(spec.QueryParam("version").Typed("integer", "int64").AsRequired().WithDescription("The version of the secret to pin the process to.")).
+								RespondsWith(200, spec.NewResponse().
+									WithDescription("Returns the status of the pinned secret.").
+									WithSchema(spec.RefSchema("#/definitions/VaultSecretStatus"))).
+								RespondsWith(400, spec.NewResponse().
+									WithDescription("Returned if the parameters are invalid or the version could not be loaded.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))).
+								RespondsWith(404, spec.NewResponse().
+									WithDescription("Returned if the secret isn't referenced by any secret values within the process.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+							Delete: spec.NewOperation("unpinVaultSecret").
+								WithTags("sys", "vault").
+								WithDescription("Unpin a vault secret, and load the latest version of it into the app process.").
+								AddParam(spec.QueryParam("mountPath").Typed("string", "").AsRequired().WithDescription("The mount path of the KV engine containing the secret.")).
+								AddParam(spec.QueryParam("path").Typed("string", "").AsRequired().WithDescription("The path of the secret within the KV engine.")).
+								RespondsWith(200, spec.NewResponse().
+									WithDescription("Returns the status of the unpinned secret.").
+									WithSchema(spec.RefSchema("#/definitions/VaultSecretStatus"))).
+								RespondsWith(400, spec.NewResponse().
+									WithDescription("Returned if the parameters are invalid.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))).
+								RespondsWith(404, spec.NewResponse().
+									WithDescription("Returned if the secret isn't referenced by any secret values within the process.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))).
+								RespondsWith(500, spec.NewResponse().
+									WithDescription("Returned if the latest version of the secret could not be loaded from vault.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+						},
+					},
+					"/secrets/vault/rollback": {
+						PathItemProps: spec.PathItemProps{
+							Put: spec.NewOperation("rollbackVaultSecret").
+								WithTags("sys", "vault").
+								WithDescription("Write the data of a previous version of a vault secret back to vault as the latest version, and load it into the app process. Unlike pinning, this affects every consumer of the secret once they reload it.").
+								AddParam(spec.QueryParam("mountPath").Typed("string", "").AsRequired().WithDescription("The mount path of the KV engine containing the secret.")).
+								AddParam(spec.QueryParam("path").Typed("string", "").AsRequired().WithDescription("The path of the secret within the KV engine.")).
+								AddParam(spec.QueryParam("version").Typed("integer", "int64").AsRequired().WithDescription("The version of the secret to roll back to.")).
+								RespondsWith(200, spec.NewResponse().
+									WithDescription("Returns the status of the rolled back secret.").
+									WithSchema(spec.RefSchema("#/definitions/VaultSecretStatus"))).
+								RespondsWith(400, spec.NewResponse().
+									WithDescription("Returned if the parameters are invalid or the rollback failed.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))).
+								RespondsWith(404, spec.NewResponse().
+									WithDescription("Returned if the secret isn't referenced by any secret values within the process.").
+									WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))),
+						},
+					},
+					"/logging": loggingPathItem(),
 					"/status": {
 						PathItemProps: spec.PathItemProps{
 							Get: spec.NewOperation("getStatus").
@@ -222,6 +442,7 @@ func (m *APIManager) initSysAPI() {
 						PathItemProps: spec.PathItemProps{
 							Get: spec.NewOperation("getBuildInfo").
 								WithTags("sys").
+								WithDescription("Return the version, VCS revision, build time, Go version and module dependencies the process binary was built with.").
 								RespondsWith(200, spec.NewResponse().
 									WithDescription("Returns an object with the current build info.").
 									WithSchema(spec.RefSchema("#/definitions/BuildInfo"))),
@@ -229,20 +450,35 @@ func (m *APIManager) initSysAPI() {
 					},
 				},
 			},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"bearer": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						Type:        "apiKey",
+						Name:        fasthttp.HeaderAuthorization,
+						In:          "header",
+						Description: "A static sysAPI token, or a kubernetes token validated with a TokenReview, provided as 'Bearer <token>'. Callers may alternatively authenticate with a TLS client certificate.",
+					},
+				},
+			},
 			Definitions: spec.Definitions{
-				"SubsystemStatus":      *subsystemStatusSchema,
-				"BuildInfo":            *buildInfoSchema,
-				"OKResponse":           *serialization.OKResponseSchema,
-				"GenericErrorResponse": *serialization.GenericErrorResponseSchema,
-				"ConfigKeyValue":       *configKeySchema,
+				"ConfigKeyValue": *configKeySchema,
 			},
 		},
 	}
 
+	addProtoDefinitions(spec.Definitions, &serialization.OKResponse{}, &serialization.GenericErrorResponse{}, &SubsystemStatus{}, &BuildInfo{}, &VaultSecretStatusList{}, &LoggingSettings{})
+
+	buildInfo := spec.Definitions["BuildInfo"]
+	buildInfo.Example = buildInfoExample
+	spec.Definitions["BuildInfo"] = buildInfo
+
 	// Load intial collectors to the registry subsystem.
 	m.registry.Register(collectors.NewBuildInfoCollector())
+	m.registry.Register(buildinfo.NewCollector(m.registrar.AppName))
 	m.registry.Register(collectors.NewGoCollector())
 
+	m.initSysAPIAuth()
+
 	m.router.NotFound = func(ctx *fasthttp.RequestCtx) {
 		serialization.GenericNotFoundResponseHandler(ctx)
 	}
@@ -256,14 +492,31 @@ func (m *APIManager) initSysAPI() {
 	}
 
 	// Get HTTP handler for this registry, and register it with the sysapi http server.
-	m.router.GET("/metrics", fasthttpadaptor.NewFastHTTPHandler(
-		promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})))
-
-	m.router.GET("/readyz", func(ctx *fasthttp.RequestCtx) {
+	metrics := fasthttpadaptor.NewFastHTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	probe := func(ctx *fasthttp.RequestCtx) {
 		serialization.GenericOKResponseHandler(ctx)
-	})
+	}
+
+	m.handleSysAPI(fasthttp.MethodGet, "/metrics", SysAPIRoleReadOnly, metrics)
+	m.handleSysAPI(fasthttp.MethodGet, "/readyz", SysAPIRolePublic, probe)
+	m.handleSysAPI(fasthttp.MethodGet, "/livez", SysAPIRolePublic, probe)
+
+	// If split out, the probe routes are also served without authentication on their own listener,
+	// so they can be exposed to kubelets and scrapers without exposing the rest of sysAPI.
+	if sysAPIProbeListenPort.GetUint16() != 0 {
+		probes := router.New()
+		probes.NotFound = m.router.NotFound
+		probes.MethodNotAllowed = m.router.MethodNotAllowed
+		probes.PanicHandler = m.router.PanicHandler
+
+		probes.GET("/metrics", metrics)
+		probes.GET("/readyz", probe)
+		probes.GET("/livez", probe)
+
+		m.probeServer = newSysAPIServer(probes.Handler)
+	}
 
-	m.router.GET("/status", func(ctx *fasthttp.RequestCtx) {
+	m.handleSysAPI(fasthttp.MethodGet, "/status", SysAPIRoleReadOnly, func(ctx *fasthttp.RequestCtx) {
 		statuses := []*SubsystemStatus{}
 		for _, sys := range m.systems {
 			statuses = append(statuses, sys.Status())
@@ -272,7 +525,7 @@ func (m *APIManager) initSysAPI() {
 		serialization.MarshalBodyByAcceptHeader(ctx, &SubsystemStatusList{Items: statuses})
 	})
 
-	m.router.GET("/status/{SUBSYSTEM}", func(ctx *fasthttp.RequestCtx) {
+	m.handleSysAPI(fasthttp.MethodGet, "/status/{SUBSYSTEM}", SysAPIRoleReadOnly, func(ctx *fasthttp.RequestCtx) {
 		sub := ctx.UserValue("SUBSYSTEM").(string)
 		if val, ok := m.systems[sub]; ok {
 			serialization.MarshalBodyByAcceptHeader(ctx, val.Status())
@@ -282,11 +535,7 @@ func (m *APIManager) initSysAPI() {
 		serialization.BadRequestResponseHandler(ctx, "subsystem not found in process")
 	})
 
-	m.router.GET("/livez", func(ctx *fasthttp.RequestCtx) {
-		serialization.GenericOKResponseHandler(ctx)
-	})
-
-	m.router.GET("/swagger.json", func(ctx *fasthttp.RequestCtx) {
+	m.handleSysAPI(fasthttp.MethodGet, "/swagger.json", SysAPIRoleReadOnly, func(ctx *fasthttp.RequestCtx) {
 		data, e := json.MarshalIndent(m.spec, "", "   ")
 		if e != nil {
 			serialization.InternalErrorResponseHandler(ctx, e.Error())
@@ -297,7 +546,10 @@ func (m *APIManager) initSysAPI() {
 		ctx.Response.SetStatusCode(http.StatusOK)
 	})
 
-	m.router.GET("/configuration", func(ctx *fasthttp.RequestCtx) {
+	m.handleSysAPI(fasthttp.MethodGet, "/openapi.json", SysAPIRoleReadOnly, openapi.JSONHandler(m.openAPIDocument))
+	m.handleSysAPI(fasthttp.MethodGet, "/openapi.yaml", SysAPIRoleReadOnly, openapi.YAMLHandler(m.openAPIDocument))
+
+	m.handleSysAPI(fasthttp.MethodGet, "/configuration", SysAPIRoleReadOnly, func(ctx *fasthttp.RequestCtx) {
 		var values []ConfigInfo
 
 		for _, key := range m.ckeys {
@@ -317,13 +569,14 @@ func (m *APIManager) initSysAPI() {
 		ctx.Response.SetStatusCode(http.StatusOK)
 	})
 
-	m.router.GET("/secrets", func(ctx *fasthttp.RequestCtx) {
+	m.handleSysAPI(fasthttp.MethodGet, "/secrets", SysAPIRoleReadOnly, func(ctx *fasthttp.RequestCtx) {
 		var values []SecretInfo
 
 		for _, key := range m.skeys {
 			values = append(values, SecretInfo{
-				Meta:  key,
-				Value: "*****",
+				Meta:    key,
+				Value:   "*****",
+				Version: key.LoadedVersion(),
 			})
 		}
 
@@ -336,24 +589,210 @@ func (m *APIManager) initSysAPI() {
 		ctx.Response.SetStatusCode(http.StatusOK)
 	})
 
-	m.router.GET("/buildinfo", func(ctx *fasthttp.RequestCtx) {
-		bi := &BuildInfo{
-			// Version:   pkg.Version,
-			// Commit:    pkg.Commit,
-			// BuildTime: pkg.BuildTime,
-			// Os:        pkg.Os,
-			// Arch:      pkg.Arch,
-		}
+	m.handleSysAPI(fasthttp.MethodGet, "/secrets/vault", SysAPIRoleReadOnly, m.getVaultSecretsHandler)
+	m.handleSysAPI(fasthttp.MethodPut, "/secrets/vault/refresh", SysAPIRoleAdmin, m.refreshVaultSecretsHandler)
+	m.handleSysAPI(fasthttp.MethodPut, "/secrets/vault/pin", SysAPIRoleAdmin, m.pinVaultSecretHandler)
+	m.handleSysAPI(fasthttp.MethodDelete, "/secrets/vault/pin", SysAPIRoleAdmin, m.unpinVaultSecretHandler)
+	m.handleSysAPI(fasthttp.MethodPut, "/secrets/vault/rollback", SysAPIRoleAdmin, m.rollbackVaultSecretHandler)
+
+	m.handleSysAPI(fasthttp.MethodGet, "/logging", SysAPIRoleReadOnly, m.getLoggingHandler)
+	m.handleSysAPI(fasthttp.MethodPut, "/logging", SysAPIRoleAdmin, m.setLoggingHandler)
+	m.handleSysAPI(fasthttp.MethodDelete, "/logging", SysAPIRoleAdmin, m.revertLoggingHandler)
 
-		serialization.MarshalBodyByAcceptHeader(ctx, bi)
+	m.handleSysAPI(fasthttp.MethodGet, "/buildinfo", SysAPIRoleReadOnly, func(ctx *fasthttp.RequestCtx) {
+		serialization.MarshalBodyByAcceptHeader(ctx, newBuildInfo(buildinfo.Get()))
 	})
 
+	m.initSysAPIDocs(spec)
+	m.initSysAPIDiagnostics(spec)
+
+	for route, role := range m.sysAPIRoles {
+		method, path, _ := strings.Cut(route, " ")
+		if item, ok := spec.Paths.Paths[path]; ok {
+			if op := serialization.PathItemOperation(&item, method); op != nil {
+				documentSysAPIAuth(*op, role)
+			}
+		}
+	}
+
 	m.server = ser
 	m.spec = spec
 }
 
-func (m *APIManager) startSysAPI() {
-	bind := fmt.Sprintf("%s:%d", sysAPIListenAddress.GetString(), sysAPIListenPort.GetUint16())
-	klog.V(5).Infof("starting sysAPI on %s", bind)
-	m.server.ListenAndServe(bind)
+// startSysAPI binds all sysAPI listeners and serves sysAPI on them, only returning once
+// sysAPI is shut down or fails to serve.
+func (m *APIManager) startSysAPI() error {
+	ln, e := sysAPIListener()
+	if e != nil {
+		return e
+	}
+
+	conf, e := sysAPITLSConfig()
+	if e != nil {
+		ln.Close()
+		return fmt.Errorf("unable to configure TLS for sysAPI: %v", e)
+	}
+
+	if conf != nil {
+		cert, e := tls.LoadX509KeyPair(sysAPITLSCertFile.GetString(), sysAPITLSKeyFile.GetString())
+		if e != nil {
+			ln.Close()
+			return fmt.Errorf("unable to load sysAPI TLS certificate: %v", e)
+		}
+
+		conf.Certificates = []tls.Certificate{cert}
+		ln = tls.NewListener(ln, conf)
+	}
+
+	errs := make(chan error, 2)
+
+	if m.probeServer != nil {
+		bind := net.JoinHostPort(sysAPIProbeListenAddress.GetString(), strconv.Itoa(int(sysAPIProbeListenPort.GetUint16())))
+		pln, e := net.Listen("tcp4", bind)
+		if e != nil {
+			ln.Close()
+			return fmt.Errorf("unable to listen for sysAPI probes: %v", e)
+		}
+
+		logger.Log(context.Background(), Verbosity(5), "serving sysAPI probes", "address", bind)
+		go func() { errs <- m.probeServer.Serve(pln) }()
+	}
+
+	logger.Log(context.Background(), Verbosity(5), "serving sysAPI", "address", ln.Addr().String())
+	go func() { errs <- m.server.Serve(ln) }()
+
+	// If either listener fails, stop serving on the other rather than leaving it running alone.
+	if e := <-errs; e != nil {
+		m.server.Shutdown()
+		if m.probeServer != nil {
+			m.probeServer.Shutdown()
+		}
+
+		return e
+	}
+
+	return nil
+}
+
+// sysAPIListener returns the listener for sysAPI, either a unix socket if sysAPISocketPath
+// is set, or a TCP socket on sysAPIListenAddress:sysAPIListenPort.
+func sysAPIListener() (net.Listener, error) {
+	path := sysAPISocketPath.GetString()
+	if path == "" {
+		bind := net.JoinHostPort(sysAPIListenAddress.GetString(), strconv.Itoa(int(sysAPIListenPort.GetUint16())))
+		ln, e := net.Listen("tcp4", bind)
+		if e != nil {
+			return nil, fmt.Errorf("unable to listen for sysAPI: %v", e)
+		}
+
+		return ln, nil
+	}
+
+	mode, e := strconv.ParseUint(sysAPISocketMode.GetString(), 8, 32)
+	if e != nil {
+		return nil, fmt.Errorf("invalid sysAPI socket mode %s: %v", sysAPISocketMode.GetString(), e)
+	}
+
+	uid, gid, e := lookupOwner(sysAPISocketOwner.GetString(), sysAPISocketGroup.GetString())
+	if e != nil {
+		return nil, e
+	}
+
+	// Clean up the socket left behind by a previous instance, but never remove anything else.
+	if info, e := os.Lstat(path); e == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("sysAPI socket path %s exists and is not a socket", path)
+		}
+
+		if e := os.Remove(path); e != nil {
+			return nil, fmt.Errorf("unable to remove stale sysAPI socket: %v", e)
+		}
+	}
+
+	// Bind the socket inside a private directory and only move it into place once its mode
+	// and owner are set, so it is never reachable with the permissions of the process umask.
+	dir, e := os.MkdirTemp(filepath.Dir(path), ".sysapi")
+	if e != nil {
+		return nil, fmt.Errorf("unable to create sysAPI socket directory: %v", e)
+	}
+	defer os.RemoveAll(dir)
+
+	tmp := filepath.Join(dir, "sysapi.sock")
+	ln, e := net.Listen("unix", tmp)
+	if e != nil {
+		return nil, fmt.Errorf("unable to listen for sysAPI: %v", e)
+	}
+
+	// The socket is renamed, so unlink it at its final path ourselves.
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if e := os.Chmod(tmp, os.FileMode(mode)); e != nil {
+		ln.Close()
+		return nil, fmt.Errorf("unable to set mode of sysAPI socket: %v", e)
+	}
+
+	if uid != -1 || gid != -1 {
+		if e := os.Chown(tmp, uid, gid); e != nil {
+			ln.Close()
+			return nil, fmt.Errorf("unable to set owner of sysAPI socket: %v", e)
+		}
+	}
+
+	if e := os.Rename(tmp, path); e != nil {
+		ln.Close()
+		return nil, fmt.Errorf("unable to move sysAPI socket into place: %v", e)
+	}
+
+	return &unixSocketListener{Listener: ln, path: path}, nil
+}
+
+// unixSocketListener removes its socket from path once closed.
+type unixSocketListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unixSocketListener) Close() error {
+	e := l.Listener.Close()
+	if e == nil {
+		os.Remove(l.path)
+	}
+
+	return e
+}
+
+// lookupOwner resolves the provided user and group names or IDs, returning -1
+// for either if they are unset.
+func lookupOwner(owner, group string) (int, int, error) {
+	uid, gid := -1, -1
+
+	if owner != "" {
+		id := owner
+		if u, e := user.Lookup(owner); e == nil {
+			id = u.Uid
+		}
+
+		i, e := strconv.Atoi(id)
+		if e != nil {
+			return -1, -1, fmt.Errorf("unknown sysAPI socket owner %s", owner)
+		}
+
+		uid = i
+	}
+
+	if group != "" {
+		id := group
+		if g, e := user.LookupGroup(group); e == nil {
+			id = g.Gid
+		}
+
+		i, e := strconv.Atoi(id)
+		if e != nil {
+			return -1, -1, fmt.Errorf("unknown sysAPI socket group %s", group)
+		}
+
+		gid = i
+	}
+
+	return uid, gid, nil
 }
@@ -0,0 +1,384 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fire833/go-api-utils/openapi"
+	"github.com/fire833/go-api-utils/serialization"
+	"github.com/go-openapi/spec"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// freePort returns a TCP port that is free to listen on.
+func freePort(t *testing.T) uint16 {
+	t.Helper()
+
+	ln, e := net.Listen("tcp4", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer ln.Close()
+
+	return uint16(ln.Addr().(*net.TCPAddr).Port)
+}
+
+// serveSysAPI starts sysAPI in the background, returning a channel with the error it exits with.
+func serveSysAPI(t *testing.T, m *APIManager) chan error {
+	errs := make(chan error, 1)
+	go func() { errs <- m.startSysAPI() }()
+
+	t.Cleanup(func() {
+		m.server.Shutdown()
+		if m.probeServer != nil {
+			m.probeServer.Shutdown()
+		}
+	})
+
+	return errs
+}
+
+func getStatus(t *testing.T, client *fasthttp.Client, url string) int {
+	t.Helper()
+
+	var (
+		code int
+		e    error
+	)
+
+	// Give the listener a moment to come up.
+	for range 50 {
+		if code, _, e = client.Get(nil, url); e == nil {
+			return code
+		}
+
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	t.Fatalf("unable to GET %s: %v", url, e)
+	return 0
+}
+
+func TestStartSysAPI(t *testing.T) {
+	t.Run("unixSocket", func(t *testing.T) {
+		m := loadAuthApp(t)
+
+		dir := t.TempDir()
+		path := dir + "/sysapi.sock"
+		m.config.Set("sysAPISocketPath", path)
+		m.config.Set("sysAPISocketMode", "0600")
+		m.config.Set("sysAPISocketOwner", strconv.Itoa(os.Getuid()))
+		m.config.Set("sysAPISocketGroup", strconv.Itoa(os.Getgid()))
+
+		// Leave a stale socket behind, like a crashed instance would.
+		stale, e := net.Listen("unix", path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		stale.Close()
+
+		serveSysAPI(t, m)
+
+		client := &fasthttp.Client{Dial: func(string) (net.Conn, error) { return net.Dial("unix", path) }}
+		if code := getStatus(t, client, "http://sysapi/livez"); code != 200 {
+			t.Errorf("GET /livez = %d, want 200", code)
+		}
+
+		info, e := os.Stat(path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("socket mode = %o, want 600", info.Mode().Perm())
+		}
+
+		// Only the socket itself should be left, not the directory it was bound in.
+		if entries, e := os.ReadDir(dir); e != nil || len(entries) != 1 {
+			t.Errorf("socket directory = %v, want only sysapi.sock", entries)
+		}
+
+		m.server.Shutdown()
+		if _, e := os.Lstat(path); !os.IsNotExist(e) {
+			t.Errorf("socket not removed on shutdown: %v", e)
+		}
+	})
+
+	t.Run("notASocket", func(t *testing.T) {
+		m := loadAuthApp(t)
+
+		path := t.TempDir() + "/sysapi.sock"
+		if e := os.WriteFile(path, []byte("precious"), 0600); e != nil {
+			t.Fatal(e)
+		}
+
+		m.config.Set("sysAPISocketPath", path)
+		if e := m.startSysAPI(); e == nil {
+			t.Errorf("startSysAPI() wanted error for non-socket path")
+		}
+
+		if data, _ := os.ReadFile(path); string(data) != "precious" {
+			t.Errorf("startSysAPI() clobbered existing file")
+		}
+	})
+
+	t.Run("badSocketMode", func(t *testing.T) {
+		m := loadAuthApp(t)
+		m.config.Set("sysAPISocketPath", t.TempDir()+"/sysapi.sock")
+		m.config.Set("sysAPISocketMode", "rw-rw----")
+
+		if e := m.startSysAPI(); e == nil {
+			t.Errorf("startSysAPI() wanted error for invalid mode")
+		}
+	})
+
+	t.Run("listenError", func(t *testing.T) {
+		m := loadAuthApp(t)
+
+		ln, e := net.Listen("tcp4", "127.0.0.1:0")
+		if e != nil {
+			t.Fatal(e)
+		}
+		defer ln.Close()
+
+		m.config.Set("sysAPIListenAddress", "127.0.0.1")
+		m.config.Set("sysAPIListenPort", uint16(ln.Addr().(*net.TCPAddr).Port))
+
+		select {
+		case e := <-serveSysAPI(t, m):
+			if e == nil {
+				t.Errorf("startSysAPI() wanted error for port in use")
+			}
+		case <-time.After(time.Second * 5):
+			t.Errorf("startSysAPI() did not return error for port in use")
+		}
+	})
+}
+
+func TestSysAPIProbeListener(t *testing.T) {
+	mgr = nil
+	t.Cleanup(func() { mgr = nil })
+
+	// The probe listener is configured when sysAPI is initialized, so it needs
+	// to be set up before the app is loaded.
+	m := New(&APIManagerOpts{EnableSysAPI: true})
+	m.config.Set("sysAPIProbeListenPort", freePort(t))
+	m.Initialize(&SystemRegistrar{AppName: "foo"})
+
+	m.config.Set("sysAPIAuthEnabled", true)
+	m.config.Set("sysAPIListenAddress", "127.0.0.1")
+	m.config.Set("sysAPIListenPort", freePort(t))
+	m.config.Set("sysAPIProbeListenAddress", "127.0.0.1")
+
+	if m.probeServer == nil {
+		t.Fatalf("probe server not initialized")
+	}
+
+	serveSysAPI(t, m)
+
+	client := &fasthttp.Client{}
+	admin := fmt.Sprintf("http://127.0.0.1:%d", sysAPIListenPort.GetUint16())
+	probe := fmt.Sprintf("http://127.0.0.1:%d", sysAPIProbeListenPort.GetUint16())
+
+	tests := []struct {
+		name string
+		url  string
+		code int
+	}{
+		{"probeLive", probe + "/livez", 200},
+		{"probeReady", probe + "/readyz", 200},
+		{"probeMetrics", probe + "/metrics", 200},
+		{"probeNoConfiguration", probe + "/configuration", 404},
+		{"probeNoSecrets", probe + "/secrets", 404},
+		{"adminLive", admin + "/livez", 200},
+		{"adminMetricsRequiresAuth", admin + "/metrics", 401},
+		{"adminConfigurationRequiresAuth", admin + "/configuration", 401},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := getStatus(t, client, tt.url); code != tt.code {
+				t.Errorf("GET %s = %d, want %d", tt.url, code, tt.code)
+			}
+		})
+	}
+}
+
+func TestSysAPIBuildInfo(t *testing.T) {
+	m := loadAuthApp(t)
+
+	ctx := sysAPIRequest(m, fasthttp.MethodGet, "/buildinfo", "readtoken")
+	if ctx.Response.StatusCode() != 200 {
+		t.Fatalf("status = %d, want 200", ctx.Response.StatusCode())
+	}
+
+	bi := &BuildInfo{}
+	if e := protojson.Unmarshal(ctx.Response.Body(), bi); e != nil {
+		t.Fatal(e)
+	}
+
+	if bi.GoVersion != runtime.Version() || bi.Os != runtime.GOOS || bi.Arch != runtime.GOARCH {
+		t.Errorf("build info = %+v, want toolchain values of the test binary", bi)
+	}
+
+	metrics, e := m.registry.Gather()
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	found := false
+	for _, family := range metrics {
+		if family.GetName() == "foo_build_info" {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Errorf("foo_build_info not registered with the manager registry")
+	}
+}
+
+func TestSysAPIOpenAPI(t *testing.T) {
+	m := loadAuthApp(t)
+
+	if e := RegisterSysAPIHandler(fasthttp.MethodGet, "/custom", func(ctx *fasthttp.RequestCtx) {}, spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getCustom").RespondsWith(200, spec.NewResponse().WithDescription("Custom.")),
+		},
+	}); e != nil {
+		t.Fatal(e)
+	}
+
+	// Another method on the same path is merged with the existing path item.
+	if e := RegisterSysAPIEndpoint((&serialization.Route[*LoggingSettings, serialization.NoBody]{
+		Method:      fasthttp.MethodPut,
+		Path:        "/custom",
+		OperationID: "putCustom",
+		Handler:     func(ctx *fasthttp.RequestCtx) {},
+	}).Endpoint()); e != nil {
+		t.Fatal(e)
+	}
+
+	if e := RegisterSysAPIHandler(fasthttp.MethodGet, "/custom", func(ctx *fasthttp.RequestCtx) {}, spec.PathItem{
+		PathItemProps: spec.PathItemProps{
+			Get: spec.NewOperation("getCustomAgain").RespondsWith(200, spec.NewResponse().WithDescription("Custom.")),
+		},
+	}); e == nil {
+		t.Errorf("registering GET /custom twice succeeded")
+	}
+
+	// A failed registration leaves the caller's operation untouched.
+	again := spec.NewOperation("getCustomThird")
+	if e := RegisterSysAPIHandler(fasthttp.MethodGet, "/custom", func(ctx *fasthttp.RequestCtx) {}, spec.PathItem{
+		PathItemProps: spec.PathItemProps{Get: again},
+	}); e == nil {
+		t.Errorf("registering GET /custom three times succeeded")
+	}
+
+	if again.Extensions != nil || again.Security != nil || again.Responses != nil {
+		t.Errorf("operation = %+v, modified by failed registration", again)
+	}
+
+	// Path items without an operation for the method are still accepted, and documented as provided.
+	if e := RegisterSysAPIHandler(fasthttp.MethodGet, "/partial", func(ctx *fasthttp.RequestCtx) {}, spec.PathItem{}); e != nil {
+		t.Errorf("registering GET /partial with an empty path item error = %v", e)
+	}
+
+	if e := RegisterSysAPIHandler(fasthttp.MethodDelete, "/partial", func(ctx *fasthttp.RequestCtx) {}, spec.PathItem{
+		PathItemProps: spec.PathItemProps{Get: spec.NewOperation("getPartial")},
+	}); e != nil {
+		t.Errorf("registering DELETE /partial with a GET path item error = %v", e)
+	}
+
+	if get := m.spec.Paths.Paths["/partial"].Get; get == nil || get.ID != "getPartial" {
+		t.Errorf("/partial GET = %+v, want getPartial documented as provided", get)
+	}
+
+	if ctx := sysAPIRequest(m, fasthttp.MethodDelete, "/partial", "admintoken"); ctx.Response.StatusCode() != 200 {
+		t.Errorf("DELETE /partial status = %d, want 200", ctx.Response.StatusCode())
+	}
+
+	ctx := sysAPIRequest(m, fasthttp.MethodGet, "/openapi.json", "readtoken")
+	if ctx.Response.StatusCode() != 200 || string(ctx.Response.Header.ContentType()) != "application/json" {
+		t.Fatalf("GET /openapi.json = %d %s", ctx.Response.StatusCode(), ctx.Response.Header.ContentType())
+	}
+
+	doc := &openapi.Document{}
+	if e := json.Unmarshal(ctx.Response.Body(), doc); e != nil {
+		t.Fatal(e)
+	}
+
+	if doc.OpenAPI != openapi.Version {
+		t.Errorf("openapi = %s, want %s", doc.OpenAPI, openapi.Version)
+	}
+
+	for _, path := range []string{"/custom", "/buildinfo", "/openapi.yaml"} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("path %s not within OpenAPI document", path)
+		}
+	}
+
+	if custom := doc.Paths["/custom"]; custom.Get == nil || custom.Get.OperationID != "getCustom" || custom.Put == nil {
+		t.Errorf("/custom = %+v, want getCustom and putCustom operations", custom)
+	}
+
+	if role, _ := m.spec.Paths.Paths["/custom"].Put.Extensions.GetString("x-sysapi-role"); role != "admin" {
+		t.Errorf("putCustom x-sysapi-role = %s, want admin", role)
+	}
+
+	if _, ok := doc.Components.Schemas["BuildInfo"]; !ok {
+		t.Errorf("BuildInfo schema not within OpenAPI document")
+	}
+
+	if ref := doc.Paths["/buildinfo"].Get.Responses["200"].Content["application/json"].Schema.Ref; ref != "#/components/schemas/BuildInfo" {
+		t.Errorf("/buildinfo response schema = %s, want BuildInfo component", ref)
+	}
+
+	ctx = sysAPIRequest(m, fasthttp.MethodGet, "/openapi.yaml", "readtoken")
+	if ctx.Response.StatusCode() != 200 || !strings.HasPrefix(string(ctx.Response.Body()), "openapi: 3.1.0") {
+		t.Errorf("GET /openapi.yaml = %d:\n%.100s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+}
+
+func TestSysAPIDocs(t *testing.T) {
+	m := loadAuthApp(t)
+
+	ctx := sysAPIRequest(m, fasthttp.MethodGet, "/docs", "")
+	if ctx.Response.StatusCode() != 200 || !strings.Contains(string(ctx.Response.Body()), `data-spec="/openapi.json"`) {
+		t.Fatalf("GET /docs = %d:\n%s", ctx.Response.StatusCode(), ctx.Response.Body())
+	}
+
+	ctx = sysAPIRequest(m, fasthttp.MethodGet, "/docs/docs.js", "")
+	if ctx.Response.StatusCode() != 200 {
+		t.Errorf("GET /docs/docs.js = %d", ctx.Response.StatusCode())
+	}
+
+	if _, ok := m.spec.Paths.Paths["/docs"]; !ok {
+		t.Errorf("/docs not documented within sysAPI spec")
+	}
+}
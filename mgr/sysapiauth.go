@@ -0,0 +1,666 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fire833/go-api-utils/serialization"
+	"github.com/go-openapi/spec"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/valyala/fasthttp"
+)
+
+// SysAPIRole is the level of access required to call a sysAPI route, or granted to a
+// caller of the sysAPI. Roles are ordered, so a caller is allowed to call a route if
+// their role is greater than or equal to the role of the route.
+type SysAPIRole uint8
+
+const (
+	// Routes with the public role can be called without any credentials, even when
+	// sysAPI authentication is enabled. This should only be used for probes.
+	SysAPIRolePublic SysAPIRole = iota
+
+	// Routes with the read-only role can only introspect the process.
+	SysAPIRoleReadOnly
+
+	// Routes with the admin role can mutate the state of the process.
+	SysAPIRoleAdmin
+)
+
+func (r SysAPIRole) String() string {
+	switch r {
+	case SysAPIRolePublic:
+		return "public"
+	case SysAPIRoleReadOnly:
+		return "readonly"
+	case SysAPIRoleAdmin:
+		return "admin"
+	default:
+		return fmt.Sprintf("unknown(%d)", r)
+	}
+}
+
+// defaultSysAPIRole returns the role required for a route registered with the provided
+// method when no role is explicitly provided. Safe methods only require read-only access.
+func defaultSysAPIRole(method string) SysAPIRole {
+	switch method {
+	case fasthttp.MethodGet, fasthttp.MethodHead, fasthttp.MethodOptions:
+		return SysAPIRoleReadOnly
+	default:
+		return SysAPIRoleAdmin
+	}
+}
+
+// SysAPIIdentity is the authenticated caller of a sysAPI request.
+type SysAPIIdentity struct {
+	// The name of the caller, such as a certificate common name or kubernetes username.
+	Name string
+
+	// The groups the caller is a member of, if any.
+	Groups []string
+
+	// The role granted to the caller.
+	Role SysAPIRole
+
+	// The name of the authenticator that authenticated the caller.
+	Authenticator string
+}
+
+// SysAPIAuthenticator authenticates callers of the sysAPI. Additional authenticators
+// can be provided by subsystems with RegisterSysAPIAuthenticator().
+type SysAPIAuthenticator interface {
+	// Return the name of this authenticator for referencing.
+	Name() string
+
+	// Authenticate the request. If the request does not contain credentials this authenticator
+	// understands, it should return nil for both the identity and error so the next authenticator
+	// can be tried. If the request contains invalid credentials, an error should be returned, or
+	// an error wrapping ErrSysAPIAuthUnavailable if the credentials couldn't be checked.
+	Authenticate(ctx *fasthttp.RequestCtx) (*SysAPIIdentity, error)
+}
+
+// ErrSysAPIAuthUnavailable is wrapped by errors returned by authenticators that were unable to check
+// the credentials of a request, such as when a remote endpoint can't be reached, in which case the
+// request is responded to with 503 rather than 401.
+var ErrSysAPIAuthUnavailable error = errors.New("sysAPI authentication is unavailable")
+
+var (
+	sysAPIAuthEnabled *ConfigValue = NewConfigValue(
+		"sysAPIAuthEnabled",
+		"Toggle whether callers of sysAPI need to authenticate. When enabled, every route other than the liveness and readiness probes requires either read-only or admin access.",
+		false,
+	)
+
+	sysAPITLSCertFile *ConfigValue = NewConfigValue(
+		"sysAPITLSCertFile",
+		"Specify the path to a PEM encoded certificate for serving sysAPI over TLS. If unset, sysAPI is served over plain HTTP.",
+		"",
+	)
+
+	sysAPITLSKeyFile *ConfigValue = NewConfigValue(
+		"sysAPITLSKeyFile",
+		"Specify the path to the PEM encoded private key for sysAPITLSCertFile.",
+		"",
+	)
+
+	sysAPITLSClientCAFile *ConfigValue = NewConfigValue(
+		"sysAPITLSClientCAFile",
+		"Specify the path to a PEM encoded CA bundle used to verify sysAPI client certificates. If set, callers can authenticate with a client certificate, and their certificate common name and organizations are used as their identity and groups.",
+		"",
+	)
+
+	sysAPIAdminIdentities *ConfigValue = NewConfigValue(
+		"sysAPIAdminIdentities",
+		"Specify the names of client certificate or kubernetes identities that are granted admin access to sysAPI.",
+		[]string{},
+	)
+
+	sysAPIReadOnlyIdentities *ConfigValue = NewConfigValue(
+		"sysAPIReadOnlyIdentities",
+		"Specify the names of client certificate or kubernetes identities that are granted read-only access to sysAPI.",
+		[]string{},
+	)
+
+	sysAPIAdminGroups *ConfigValue = NewConfigValue(
+		"sysAPIAdminGroups",
+		"Specify the client certificate organizations or kubernetes groups that are granted admin access to sysAPI.",
+		[]string{},
+	)
+
+	sysAPIReadOnlyGroups *ConfigValue = NewConfigValue(
+		"sysAPIReadOnlyGroups",
+		"Specify the client certificate organizations or kubernetes groups that are granted read-only access to sysAPI.",
+		[]string{},
+	)
+
+	sysAPITokenReviewURL *ConfigValue = NewConfigValue(
+		"sysAPITokenReviewURL",
+		"Specify the URL of a kubernetes TokenReview endpoint (ie https://kubernetes.default.svc/apis/authentication.k8s.io/v1/tokenreviews) used to validate bearer tokens that aren't static sysAPI tokens. If unset, TokenReview authentication is disabled.",
+		"",
+	)
+
+	sysAPITokenReviewTokenFile *ConfigValue = NewConfigValue(
+		"sysAPITokenReviewTokenFile",
+		"Specify the path to the bearer token the process uses to submit TokenReviews. If the file doesn't exist, reviews are submitted without credentials.",
+		"/var/run/secrets/kubernetes.io/serviceaccount/token",
+	)
+
+	sysAPITokenReviewCAFile *ConfigValue = NewConfigValue(
+		"sysAPITokenReviewCAFile",
+		"Specify the path to the CA bundle used to verify the TokenReview endpoint. If the file doesn't exist, the system roots are used.",
+		"/var/run/secrets/kubernetes.io/serviceaccount/ca.crt",
+	)
+
+	sysAPITokenReviewAudiences *ConfigValue = NewConfigValue(
+		"sysAPITokenReviewAudiences",
+		"Specify the audiences that bearer tokens validated with TokenReviews must be issued for.",
+		[]string{},
+	)
+
+	sysAPITokenReviewCacheTTL *ConfigValue = NewConfigValue(
+		"sysAPITokenReviewCacheTTL",
+		"Specify the number of seconds the result of a TokenReview is cached for, so repeated requests with the same token don't each call the TokenReview endpoint. Set to 0 to disable caching.",
+		uint(10),
+	)
+
+	sysAPIAdminTokens *SecretValue = NewSecretValue(
+		"sysAPIAdminTokens",
+		"Specify static bearer tokens that are granted admin access to sysAPI.",
+		[]string{},
+	)
+
+	sysAPIReadOnlyTokens *SecretValue = NewSecretValue(
+		"sysAPIReadOnlyTokens",
+		"Specify static bearer tokens that are granted read-only access to sysAPI.",
+		[]string{},
+	)
+)
+
+// The user value key the authenticated SysAPIIdentity is stored under within the request context.
+const sysAPIIdentityKey string = "sysAPIIdentity"
+
+// SysAPIIdentityFromContext returns the authenticated caller of a sysAPI request, or nil
+// if the request was not authenticated, such as when sysAPI authentication is disabled.
+func SysAPIIdentityFromContext(ctx *fasthttp.RequestCtx) *SysAPIIdentity {
+	if id, ok := ctx.UserValue(sysAPIIdentityKey).(*SysAPIIdentity); ok {
+		return id
+	}
+
+	return nil
+}
+
+// handleSysAPI registers the handler with the sysAPI router, requiring the provided role to call it.
+func (m *APIManager) handleSysAPI(method, path string, role SysAPIRole, handler fasthttp.RequestHandler) {
+	m.router.Handle(method, path, m.authorizeSysAPI(role, handler))
+	m.sysAPIRoles[method+" "+path] = role
+}
+
+// authorizeSysAPI wraps the handler so that only callers that have been granted at least
+// the provided role are able to call it.
+func (m *APIManager) authorizeSysAPI(role SysAPIRole, handler fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		if role == SysAPIRolePublic || !sysAPIAuthEnabled.GetBool() {
+			handler(ctx)
+			return
+		}
+
+		id, e := m.authenticateSysAPI(ctx)
+		if errors.Is(e, ErrSysAPIAuthUnavailable) {
+			// The cause may include internal endpoints and addresses, so is only logged.
+			logger.Error("unable to authenticate sysAPI request", "method", string(ctx.Method()), "path", string(ctx.Path()), "error", e)
+			m.sysAPIDenials.WithLabelValues("unavailable", role.String()).Inc()
+			serialization.ServiceUnavailableResponseHandler(ctx, "authentication is temporarily unavailable", 0)
+			return
+		}
+
+		if e != nil {
+			logger.Log(ctx, Verbosity(4), "denied unauthenticated sysAPI request", "method", string(ctx.Method()), "path", string(ctx.Path()), "error", e)
+			m.sysAPIDenials.WithLabelValues("unauthenticated", role.String()).Inc()
+			ctx.Response.Header.Set(fasthttp.HeaderWWWAuthenticate, fmt.Sprintf("Bearer realm=%q", m.registrar.AppName+" sysAPI"))
+			serialization.UnauthorizedResponseHandler(ctx, "the request could not be authenticated")
+			return
+		}
+
+		if id.Role < role {
+			logger.Log(ctx, Verbosity(4), "denied sysAPI request", "method", string(ctx.Method()), "path", string(ctx.Path()), "identity", id.Name, "required", role.String(), "role", id.Role.String())
+			m.sysAPIDenials.WithLabelValues("forbidden", role.String()).Inc()
+			serialization.ForbiddenResponseHandler(ctx, fmt.Sprintf("%s access is required for this operation", role))
+			return
+		}
+
+		ctx.SetUserValue(sysAPIIdentityKey, id)
+		handler(ctx)
+	}
+}
+
+// authenticateSysAPI tries each authenticator in order, returning the first identity found.
+func (m *APIManager) authenticateSysAPI(ctx *fasthttp.RequestCtx) (*SysAPIIdentity, error) {
+	m.m.RLock()
+	authenticators := m.sysAPIAuthenticators
+	m.m.RUnlock()
+
+	for _, a := range authenticators {
+		id, e := a.Authenticate(ctx)
+		if e != nil {
+			return nil, fmt.Errorf("%s: %w", a.Name(), e)
+		}
+
+		if id != nil {
+			id.Authenticator = a.Name()
+			return id, nil
+		}
+	}
+
+	if _, ok := bearerToken(ctx); ok {
+		return nil, errors.New("invalid bearer token")
+	}
+
+	return nil, errors.New("no credentials provided")
+}
+
+// sysAPIRoleFor returns the role granted to the provided identity name and groups.
+func sysAPIRoleFor(name string, groups []string) SysAPIRole {
+	granted := func(names, grps *ConfigValue) bool {
+		if slices.Contains(names.GetStringSlice(), name) {
+			return true
+		}
+
+		for _, g := range groups {
+			if slices.Contains(grps.GetStringSlice(), g) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch {
+	case granted(sysAPIAdminIdentities, sysAPIAdminGroups):
+		return SysAPIRoleAdmin
+	case granted(sysAPIReadOnlyIdentities, sysAPIReadOnlyGroups):
+		return SysAPIRoleReadOnly
+	default:
+		return SysAPIRolePublic
+	}
+}
+
+// bearerToken returns the bearer token from the Authorization header of the request.
+func bearerToken(ctx *fasthttp.RequestCtx) (string, bool) {
+	header := ctx.Request.Header.Peek(fasthttp.HeaderAuthorization)
+	if len(header) < 7 || !strings.EqualFold(string(header[:7]), "bearer ") {
+		return "", false
+	}
+
+	token := string(bytes.TrimSpace(header[7:]))
+	return token, token != ""
+}
+
+// certAuthenticator authenticates callers with verified TLS client certificates.
+type certAuthenticator struct{}
+
+func (certAuthenticator) Name() string { return "x509" }
+
+func (certAuthenticator) Authenticate(ctx *fasthttp.RequestCtx) (*SysAPIIdentity, error) {
+	state := ctx.TLSConnectionState()
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil, nil
+	}
+
+	// The server only requests certificates if given, so unverified chains should never
+	// make it here, but don't trust a certificate unless it was verified.
+	if len(state.VerifiedChains) == 0 {
+		return nil, errors.New("client certificate was not verified")
+	}
+
+	// Certificates that aren't granted a role may have been issued for something else entirely,
+	// so let the bearer token authenticators have a crack at the request instead.
+	cert := state.VerifiedChains[0][0]
+	role := sysAPIRoleFor(cert.Subject.CommonName, cert.Subject.Organization)
+	if role == SysAPIRolePublic {
+		return nil, nil
+	}
+
+	return &SysAPIIdentity{
+		Name:   cert.Subject.CommonName,
+		Groups: cert.Subject.Organization,
+		Role:   role,
+	}, nil
+}
+
+// staticTokenAuthenticator authenticates callers with the static bearer tokens configured
+// within the process secrets.
+type staticTokenAuthenticator struct{}
+
+func (staticTokenAuthenticator) Name() string { return "token" }
+
+func (staticTokenAuthenticator) Authenticate(ctx *fasthttp.RequestCtx) (*SysAPIIdentity, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	matches := func(tokens []string) bool {
+		found := 0
+		for _, t := range tokens {
+			// Don't short circuit, so the time taken doesn't leak which token matched.
+			found |= subtle.ConstantTimeCompare([]byte(t), []byte(token))
+		}
+
+		return found == 1
+	}
+
+	switch {
+	case matches(sysAPIAdminTokens.GetStringSlice()):
+		return &SysAPIIdentity{Name: "static-admin-token", Role: SysAPIRoleAdmin}, nil
+	case matches(sysAPIReadOnlyTokens.GetStringSlice()):
+		return &SysAPIIdentity{Name: "static-readonly-token", Role: SysAPIRoleReadOnly}, nil
+	default:
+		// Let TokenReviews have a crack at the token.
+		return nil, nil
+	}
+}
+
+// tokenReview is the subset of the kubernetes authentication.k8s.io/v1 TokenReview
+// object needed for validating tokens.
+type tokenReview struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Spec       struct {
+		Token     string   `json:"token"`
+		Audiences []string `json:"audiences,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Authenticated bool `json:"authenticated"`
+		User          struct {
+			Username string   `json:"username"`
+			Groups   []string `json:"groups"`
+		} `json:"user"`
+		Error string `json:"error"`
+	} `json:"status"`
+}
+
+// tokenReviewAuthenticator authenticates callers by submitting their bearer tokens to
+// a kubernetes TokenReview endpoint.
+type tokenReviewAuthenticator struct {
+	client *http.Client
+
+	m sync.Mutex
+	// Recently authenticated tokens, keyed by the hash of the token, and ordered oldest first.
+	// Tokens that weren't authenticated aren't cached, so requests with random tokens can't evict
+	// valid ones.
+	cache map[[sha256.Size]byte]*list.Element
+	order *list.List
+}
+
+// tokenReviewResult is the user of an authenticated token. Roles are resolved from the user on
+// every request, so changes to the configured identities and groups apply to cached results.
+type tokenReviewResult struct {
+	key      [sha256.Size]byte
+	username string
+	groups   []string
+	expires  time.Time
+}
+
+// The maximum number of cached reviews, after which the oldest reviews are evicted.
+const tokenReviewCacheSize int = 1024
+
+func newTokenReviewAuthenticator() *tokenReviewAuthenticator {
+	conf := &tls.Config{}
+	if data, e := os.ReadFile(sysAPITokenReviewCAFile.GetString()); e == nil {
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(data)
+		conf.RootCAs = pool
+	}
+
+	return &tokenReviewAuthenticator{
+		client: &http.Client{
+			Timeout:   time.Second * 10,
+			Transport: &http.Transport{TLSClientConfig: conf},
+		},
+		cache: make(map[[sha256.Size]byte]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (*tokenReviewAuthenticator) Name() string { return "tokenreview" }
+
+func (t *tokenReviewAuthenticator) Authenticate(ctx *fasthttp.RequestCtx) (*SysAPIIdentity, error) {
+	url := sysAPITokenReviewURL.GetString()
+	token, ok := bearerToken(ctx)
+	if url == "" || !ok {
+		return nil, nil
+	}
+
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+
+	result := t.load(key, now)
+	if result == nil {
+		var e error
+		if result, e = t.review(url, token); e != nil {
+			return nil, e
+		}
+
+		if ttl := time.Second * time.Duration(sysAPITokenReviewCacheTTL.GetUint()); ttl > 0 {
+			result.key = key
+			result.expires = now.Add(ttl)
+			t.store(result)
+		}
+	}
+
+	return &SysAPIIdentity{
+		Name:   result.username,
+		Groups: result.groups,
+		Role:   sysAPIRoleFor(result.username, result.groups),
+	}, nil
+}
+
+// load returns the cached review of a token, or nil if it isn't cached or has expired.
+func (t *tokenReviewAuthenticator) load(key [sha256.Size]byte, now time.Time) *tokenReviewResult {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	el, ok := t.cache[key]
+	if !ok {
+		return nil
+	}
+
+	result := el.Value.(*tokenReviewResult)
+	if now.After(result.expires) {
+		t.order.Remove(el)
+		delete(t.cache, key)
+		return nil
+	}
+
+	return result
+}
+
+// store caches the review of an authenticated token, evicting the oldest reviews once the cache is full.
+func (t *tokenReviewAuthenticator) store(result *tokenReviewResult) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if el, ok := t.cache[result.key]; ok {
+		t.order.Remove(el)
+	}
+
+	t.cache[result.key] = t.order.PushBack(result)
+
+	for t.order.Len() > tokenReviewCacheSize {
+		oldest := t.order.Remove(t.order.Front()).(*tokenReviewResult)
+		delete(t.cache, oldest.key)
+	}
+}
+
+// review submits the token for review, returning an error if the token wasn't authenticated, or
+// an error wrapping ErrSysAPIAuthUnavailable if it couldn't be reviewed.
+func (t *tokenReviewAuthenticator) review(url, token string) (*tokenReviewResult, error) {
+	review := &tokenReview{APIVersion: "authentication.k8s.io/v1", Kind: "TokenReview"}
+	review.Spec.Token = token
+	review.Spec.Audiences = sysAPITokenReviewAudiences.GetStringSlice()
+
+	body, e := json.Marshal(review)
+	if e != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSysAPIAuthUnavailable, e)
+	}
+
+	req, e := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if e != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSysAPIAuthUnavailable, e)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if reviewer, e := os.ReadFile(sysAPITokenReviewTokenFile.GetString()); e == nil {
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(reviewer)))
+	}
+
+	resp, e := t.client.Do(req)
+	if e != nil {
+		return nil, fmt.Errorf("%w: unable to submit token review: %v", ErrSysAPIAuthUnavailable, e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%w: token review returned status %d", ErrSysAPIAuthUnavailable, resp.StatusCode)
+	}
+
+	reviewed := &tokenReview{}
+	if e := json.NewDecoder(resp.Body).Decode(reviewed); e != nil {
+		return nil, fmt.Errorf("%w: unable to decode token review: %v", ErrSysAPIAuthUnavailable, e)
+	}
+
+	if !reviewed.Status.Authenticated {
+		if reviewed.Status.Error != "" {
+			return nil, errors.New(reviewed.Status.Error)
+		}
+
+		return nil, errors.New("token was not authenticated")
+	}
+
+	return &tokenReviewResult{
+		username: reviewed.Status.User.Username,
+		groups:   reviewed.Status.User.Groups,
+	}, nil
+}
+
+// RegisterSysAPIAuthenticator registers an additional authenticator for sysAPI callers.
+// Authenticators are tried in the order they are registered, after the client certificate,
+// static token, and TokenReview authenticators provided by the manager.
+func RegisterSysAPIAuthenticator(a SysAPIAuthenticator) error {
+	m := mgr
+	if m == nil {
+		return errors.New("global APIManager not initialized")
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	for _, existing := range m.sysAPIAuthenticators {
+		if existing.Name() == a.Name() {
+			return fmt.Errorf("authenticator %s already registered with SysAPI server", a.Name())
+		}
+	}
+
+	m.sysAPIAuthenticators = append(m.sysAPIAuthenticators, a)
+	return nil
+}
+
+// initSysAPIAuth sets up the default authenticators and metrics for sysAPI authentication.
+func (m *APIManager) initSysAPIAuth() {
+	m.sysAPIAuthenticators = append([]SysAPIAuthenticator{
+		certAuthenticator{},
+		staticTokenAuthenticator{},
+		newTokenReviewAuthenticator(),
+	}, m.sysAPIAuthenticators...)
+
+	m.sysAPIDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.registrar.AppName,
+		Subsystem: "sysapi",
+		Name:      "auth_denials_total",
+		Help:      "Metrics on the total number of sysAPI requests denied, by reason and the role required by the route.",
+	}, []string{"reason", "role"})
+
+	m.registry.Register(m.sysAPIDenials)
+}
+
+// sysAPITLSConfig returns the TLS configuration for sysAPI, or nil if TLS is not configured.
+func sysAPITLSConfig() (*tls.Config, error) {
+	if sysAPITLSCertFile.GetString() == "" {
+		return nil, nil
+	}
+
+	conf := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if ca := sysAPITLSClientCAFile.GetString(); ca != "" {
+		data, e := os.ReadFile(ca)
+		if e != nil {
+			return nil, fmt.Errorf("unable to read client CA file: %v", e)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("no certificates found within client CA file")
+		}
+
+		conf.ClientCAs = pool
+		conf.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return conf, nil
+}
+
+// documentSysAPIAuth updates the operation to reflect the authentication required to call it.
+func documentSysAPIAuth(op *spec.Operation, role SysAPIRole) {
+	if op == nil {
+		return
+	}
+
+	op.AddExtension("x-sysapi-role", role.String())
+
+	if role == SysAPIRolePublic {
+		op.Security = []map[string][]string{}
+		return
+	}
+
+	op.SecuredWith("bearer")
+	op.RespondsWith(401, spec.NewResponse().
+		WithDescription("Returned if the caller could not be authenticated.").
+		WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse")))
+	op.RespondsWith(403, spec.NewResponse().
+		WithDescription(fmt.Sprintf("Returned if the caller has not been granted %s access.", role)).
+		WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse")))
+	op.RespondsWith(503, spec.NewResponse().
+		WithDescription("Returned if the credentials of the caller could not be checked, such as when the TokenReview endpoint is unreachable.").
+		WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse")))
+}
@@ -0,0 +1,450 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fire833/go-api-utils/fake/faketokenreview"
+	"github.com/go-openapi/spec"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func loadAuthApp(t *testing.T) *APIManager {
+	m := loadSimpleApp(true)
+	t.Cleanup(func() { mgr = nil })
+
+	m.config.Set("sysAPIAuthEnabled", true)
+	m.secrets.Set("sysAPIAdminTokens", []string{"admintoken"})
+	m.secrets.Set("sysAPIReadOnlyTokens", []string{"readtoken"})
+	return m
+}
+
+func sysAPIRequest(m *APIManager, method, path, token string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(method)
+	ctx.Request.SetRequestURI(path)
+	if token != "" {
+		ctx.Request.Header.Set(fasthttp.HeaderAuthorization, "Bearer "+token)
+	}
+
+	m.router.Handler(ctx)
+	return ctx
+}
+
+func TestSysAPIAuthStaticTokens(t *testing.T) {
+	m := loadAuthApp(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+	}{
+		{"probeAnonymous", fasthttp.MethodGet, "/livez", "", 200},
+		{"readyAnonymous", fasthttp.MethodGet, "/readyz", "", 200},
+		{"statusAnonymous", fasthttp.MethodGet, "/status", "", 401},
+		{"statusBadToken", fasthttp.MethodGet, "/status", "nope", 401},
+		{"statusReadOnly", fasthttp.MethodGet, "/status", "readtoken", 200},
+		{"statusAdmin", fasthttp.MethodGet, "/status", "admintoken", 200},
+		{"refreshReadOnly", fasthttp.MethodPut, "/secrets/vault/refresh", "readtoken", 403},
+		{"refreshAdmin", fasthttp.MethodPut, "/secrets/vault/refresh", "admintoken", 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := sysAPIRequest(m, tt.method, tt.path, tt.token)
+			if ctx.Response.StatusCode() != tt.code {
+				t.Errorf("status = %d, want %d: %s", ctx.Response.StatusCode(), tt.code, ctx.Response.Body())
+			}
+
+			if tt.code == 401 && len(ctx.Response.Header.Peek(fasthttp.HeaderWWWAuthenticate)) == 0 {
+				t.Errorf("missing WWW-Authenticate header on 401 response")
+			}
+		})
+	}
+
+	t.Run("denialMetrics", func(t *testing.T) {
+		if got := testutil.ToFloat64(m.sysAPIDenials.WithLabelValues("unauthenticated", "readonly")); got != 2 {
+			t.Errorf("unauthenticated denials = %v, want 2", got)
+		}
+		if got := testutil.ToFloat64(m.sysAPIDenials.WithLabelValues("forbidden", "admin")); got != 1 {
+			t.Errorf("forbidden denials = %v, want 1", got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		m.config.Set("sysAPIAuthEnabled", false)
+		defer m.config.Set("sysAPIAuthEnabled", true)
+
+		if ctx := sysAPIRequest(m, fasthttp.MethodGet, "/status", ""); ctx.Response.StatusCode() != 200 {
+			t.Errorf("status = %d with auth disabled, want 200", ctx.Response.StatusCode())
+		}
+	})
+}
+
+func TestSysAPIAuthTokenReview(t *testing.T) {
+	m := loadAuthApp(t)
+
+	s := faketokenreview.New()
+	defer s.Close()
+
+	s.RequireReviewerToken("reviewer")
+	s.AddToken("operator", &faketokenreview.User{Username: "system:serviceaccount:ops:operator"})
+	s.AddToken("viewer", &faketokenreview.User{Username: "jane", Groups: []string{"viewers"}})
+	s.AddToken("stranger", &faketokenreview.User{Username: "bob"})
+	s.AddToken("wrongaudience", &faketokenreview.User{Username: "jane", Groups: []string{"viewers"}, Audiences: []string{"other"}})
+	s.AddToken("uncached", &faketokenreview.User{Username: "jane", Groups: []string{"viewers"}})
+
+	tokenFile := t.TempDir() + "/token"
+	if e := os.WriteFile(tokenFile, []byte("reviewer\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+
+	m.config.Set("sysAPITokenReviewURL", s.URL())
+	m.config.Set("sysAPITokenReviewTokenFile", tokenFile)
+	m.config.Set("sysAPITokenReviewAudiences", []string{"foo"})
+	m.config.Set("sysAPIAdminIdentities", []string{"system:serviceaccount:ops:operator"})
+	m.config.Set("sysAPIReadOnlyGroups", []string{"viewers"})
+
+	tests := []struct {
+		name   string
+		method string
+		token  string
+		code   int
+	}{
+		{"adminIdentity", fasthttp.MethodPut, "operator", 200},
+		{"readOnlyGroup", fasthttp.MethodGet, "viewer", 200},
+		{"readOnlyGroupMutate", fasthttp.MethodPut, "viewer", 403},
+		{"noRole", fasthttp.MethodGet, "stranger", 403},
+		{"invalid", fasthttp.MethodGet, "unknown", 401},
+		{"wrongAudience", fasthttp.MethodGet, "wrongaudience", 401},
+		{"staticStillWorks", fasthttp.MethodPut, "admintoken", 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := "/status"
+			if tt.method == fasthttp.MethodPut {
+				path = "/secrets/vault/refresh"
+			}
+
+			ctx := sysAPIRequest(m, tt.method, path, tt.token)
+			if ctx.Response.StatusCode() != tt.code {
+				t.Errorf("status = %d, want %d: %s", ctx.Response.StatusCode(), tt.code, ctx.Response.Body())
+			}
+		})
+	}
+
+	if s.Reviews() != 5 {
+		t.Errorf("Reviews() = %d, want 5 as static tokens and cached reviews shouldn't be reviewed", s.Reviews())
+	}
+
+	t.Run("cacheDisabled", func(t *testing.T) {
+		m.config.Set("sysAPITokenReviewCacheTTL", uint(0))
+		defer m.config.Set("sysAPITokenReviewCacheTTL", uint(10))
+
+		before := s.Reviews()
+		for i := 0; i < 2; i++ {
+			if ctx := sysAPIRequest(m, fasthttp.MethodGet, "/status", "uncached"); ctx.Response.StatusCode() != 200 {
+				t.Errorf("status = %d, want 200", ctx.Response.StatusCode())
+			}
+		}
+
+		if s.Reviews()-before != 2 {
+			t.Errorf("Reviews() = %d, want 2 with caching disabled", s.Reviews()-before)
+		}
+	})
+
+	t.Run("rejectedNotCached", func(t *testing.T) {
+		before := s.Reviews()
+		for i := 0; i < 2; i++ {
+			if ctx := sysAPIRequest(m, fasthttp.MethodGet, "/status", "unknown"); ctx.Response.StatusCode() != 401 {
+				t.Errorf("status = %d, want 401", ctx.Response.StatusCode())
+			}
+		}
+
+		if s.Reviews()-before != 2 {
+			t.Errorf("Reviews() = %d, want 2 as rejected tokens aren't cached", s.Reviews()-before)
+		}
+	})
+
+	// Review failures are responded to with 503, without the cause that may include internal addresses.
+	t.Run("reviewerUnauthorized", func(t *testing.T) {
+		m.config.Set("sysAPITokenReviewTokenFile", t.TempDir()+"/missing")
+		defer m.config.Set("sysAPITokenReviewTokenFile", tokenFile)
+
+		ctx := sysAPIRequest(m, fasthttp.MethodGet, "/status", "unknown")
+		if ctx.Response.StatusCode() != 503 || strings.Contains(string(ctx.Response.Body()), "status 401") {
+			t.Errorf("status = %d: %s, want 503 without the cause", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+	})
+
+	t.Run("reviewerUnreachable", func(t *testing.T) {
+		m.config.Set("sysAPITokenReviewURL", "http://127.0.0.1:1/review")
+		defer m.config.Set("sysAPITokenReviewURL", s.URL())
+
+		ctx := sysAPIRequest(m, fasthttp.MethodGet, "/status", "unknown")
+		if ctx.Response.StatusCode() != 503 || strings.Contains(string(ctx.Response.Body()), "127.0.0.1") {
+			t.Errorf("status = %d: %s, want 503 without the cause", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+
+		if got := testutil.ToFloat64(m.sysAPIDenials.WithLabelValues("unavailable", "readonly")); got != 2 {
+			t.Errorf("unavailable denials = %v, want 2", got)
+		}
+	})
+}
+
+func TestTokenReviewCache(t *testing.T) {
+	a := newTokenReviewAuthenticator()
+	now := time.Now()
+
+	key := func(i int) [sha256.Size]byte { return sha256.Sum256([]byte(strconv.Itoa(i))) }
+	for i := 0; i < tokenReviewCacheSize+10; i++ {
+		a.store(&tokenReviewResult{key: key(i), username: strconv.Itoa(i), expires: now.Add(time.Minute)})
+	}
+
+	if len(a.cache) != tokenReviewCacheSize || a.order.Len() != tokenReviewCacheSize {
+		t.Errorf("cache size = %d, %d, want %d", len(a.cache), a.order.Len(), tokenReviewCacheSize)
+	}
+
+	// The oldest reviews are evicted first, while newer reviews are kept.
+	if a.load(key(9), now) != nil {
+		t.Errorf("load() of an evicted review = non-nil")
+	}
+
+	if result := a.load(key(10), now); result == nil || result.username != "10" {
+		t.Errorf("load() = %v, want the review of 10", result)
+	}
+
+	if a.load(key(tokenReviewCacheSize+9), now.Add(time.Hour)) != nil || len(a.cache) != tokenReviewCacheSize-1 {
+		t.Errorf("load() of an expired review = non-nil, or it wasn't removed")
+	}
+}
+
+type headerAuthenticator struct{}
+
+func (headerAuthenticator) Name() string { return "header" }
+
+func (headerAuthenticator) Authenticate(ctx *fasthttp.RequestCtx) (*SysAPIIdentity, error) {
+	switch string(ctx.Request.Header.Peek("X-Test-User")) {
+	case "":
+		return nil, nil
+	case "root":
+		return &SysAPIIdentity{Name: "root", Role: SysAPIRoleAdmin}, nil
+	default:
+		return nil, errors.New("unknown user")
+	}
+}
+
+func TestRegisterSysAPIAuthenticator(t *testing.T) {
+	m := loadAuthApp(t)
+
+	if e := RegisterSysAPIAuthenticator(headerAuthenticator{}); e != nil {
+		t.Fatalf("RegisterSysAPIAuthenticator() error = %v", e)
+	}
+	if e := RegisterSysAPIAuthenticator(headerAuthenticator{}); e == nil {
+		t.Errorf("RegisterSysAPIAuthenticator() wanted error for duplicate authenticator")
+	}
+
+	var seen *SysAPIIdentity
+	e := RegisterSysAPIHandler(fasthttp.MethodPost, "/whoami", func(ctx *fasthttp.RequestCtx) {
+		seen = SysAPIIdentityFromContext(ctx)
+	}, spec.PathItem{PathItemProps: spec.PathItemProps{Post: spec.NewOperation("whoami")}})
+	if e != nil {
+		t.Fatalf("RegisterSysAPIHandler() error = %v", e)
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	ctx.Request.SetRequestURI("/whoami")
+	ctx.Request.Header.Set("X-Test-User", "root")
+	m.router.Handler(ctx)
+
+	if ctx.Response.StatusCode() != 200 || seen == nil || seen.Name != "root" || seen.Authenticator != "header" {
+		t.Errorf("status = %d, identity = %+v, want 200 and root from header authenticator", ctx.Response.StatusCode(), seen)
+	}
+
+	ctx.Request.Header.Set("X-Test-User", "mallory")
+	ctx.Response.Reset()
+	m.router.Handler(ctx)
+
+	if ctx.Response.StatusCode() != 401 {
+		t.Errorf("status = %d, want 401", ctx.Response.StatusCode())
+	}
+
+	op := m.spec.Paths.Paths["/whoami"].Post
+	if role, _ := op.Extensions.GetString("x-sysapi-role"); role != "admin" {
+		t.Errorf("x-sysapi-role = %s, want admin", role)
+	}
+	if _, ok := op.Responses.StatusCodeResponses[403]; !ok {
+		t.Errorf("operation missing 403 response")
+	}
+}
+
+func TestSysAPIAuthClientCertificates(t *testing.T) {
+	m := loadAuthApp(t)
+
+	ca, caKey := newTestCert(t, "ca", nil, nil, nil)
+	server, serverKey := newTestCert(t, "localhost", nil, ca, caKey)
+	admin, adminKey := newTestCert(t, "admin", []string{"ops"}, ca, caKey)
+	nobody, nobodyKey := newTestCert(t, "nobody", nil, ca, caKey)
+	rogueCA, rogueKey := newTestCert(t, "ca", nil, nil, nil)
+	rogue, rogueCertKey := newTestCert(t, "admin", []string{"ops"}, rogueCA, rogueKey)
+
+	dir := t.TempDir()
+	if e := os.WriteFile(dir+"/ca.pem", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600); e != nil {
+		t.Fatal(e)
+	}
+
+	m.config.Set("sysAPITLSCertFile", dir+"/server.pem")
+	m.config.Set("sysAPITLSClientCAFile", dir+"/ca.pem")
+	m.config.Set("sysAPIAdminGroups", []string{"ops"})
+
+	conf, e := sysAPITLSConfig()
+	if e != nil {
+		t.Fatalf("sysAPITLSConfig() error = %v", e)
+	}
+
+	conf.Certificates = []tls.Certificate{{Certificate: [][]byte{server.Raw}, PrivateKey: serverKey}}
+	m.server.TLSConfig = conf
+
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+	go m.server.Serve(tls.NewListener(ln, conf))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	tests := []struct {
+		name  string
+		cert  *x509.Certificate
+		key   *ecdsa.PrivateKey
+		token string
+		code  int
+	}{
+		{"admin", admin, adminKey, "", 200},
+		{"noRole", nobody, nobodyKey, "", 401},
+		{"noRoleWithToken", nobody, nobodyKey, "admintoken", 200},
+		{"noRoleWithReadOnlyToken", nobody, nobodyKey, "readtoken", 403},
+		{"noCert", nil, nil, "", 401},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientConf := &tls.Config{RootCAs: pool, ServerName: "localhost"}
+			if tt.cert != nil {
+				clientConf.Certificates = []tls.Certificate{{Certificate: [][]byte{tt.cert.Raw}, PrivateKey: tt.key}}
+			}
+
+			client := &fasthttp.Client{
+				TLSConfig: clientConf,
+				Dial:      func(string) (net.Conn, error) { return ln.Dial() },
+			}
+
+			req := fasthttp.AcquireRequest()
+			resp := fasthttp.AcquireResponse()
+			defer fasthttp.ReleaseRequest(req)
+			defer fasthttp.ReleaseResponse(resp)
+
+			req.SetRequestURI("https://localhost/secrets/vault/refresh")
+			req.Header.SetMethod(fasthttp.MethodPut)
+			if tt.token != "" {
+				req.Header.Set(fasthttp.HeaderAuthorization, "Bearer "+tt.token)
+			}
+
+			if e := client.Do(req, resp); e != nil {
+				t.Fatalf("Do() error = %v", e)
+			}
+
+			if resp.StatusCode() != tt.code {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode(), tt.code, resp.Body())
+			}
+		})
+	}
+
+	t.Run("untrustedCert", func(t *testing.T) {
+		client := &fasthttp.Client{
+			TLSConfig: &tls.Config{
+				RootCAs:      pool,
+				ServerName:   "localhost",
+				Certificates: []tls.Certificate{{Certificate: [][]byte{rogue.Raw}, PrivateKey: rogueCertKey}},
+			},
+			Dial: func(string) (net.Conn, error) { return ln.Dial() },
+		}
+
+		code, _, e := client.Get(nil, "https://localhost/status")
+		if e == nil && code == 200 {
+			t.Errorf("request with untrusted certificate succeeded")
+		}
+	})
+}
+
+// newTestCert returns a new certificate signed by parent, or a self-signed CA if parent is nil.
+func newTestCert(t *testing.T, cn string, orgs []string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn, Organization: orgs},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{cn},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, key
+	}
+
+	der, e := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	cert, e := x509.ParseCertificate(der)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	return cert, key
+}
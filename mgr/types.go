@@ -68,6 +68,10 @@ type APIManager struct {
 	vault         *vault.Client
 	secretRenewer *vault.LifetimeWatcher
 
+	// Map of all KV v2 secrets loaded into the process, keyed by <mountpath>/<path>.
+	vaultSecrets map[string]*vaultSecret
+	vaultLock    sync.RWMutex
+
 	// Config contains non-secret key/value data for configuring the process.
 	config *viper.Viper
 
@@ -89,9 +93,24 @@ type APIManager struct {
 	// The long term goal is develop a
 	server *fasthttp.Server
 
+	// probeServer serves the sysAPI probe routes on their own listener, if enabled.
+	probeServer *fasthttp.Server
+
 	// router contains the routing logic and handlers for all sysAPI operations and REST calls.
 	router *router.Router
 
+	// Authenticators for callers of sysAPI, tried in order on every request to a non-public route.
+	sysAPIAuthenticators []SysAPIAuthenticator
+
+	// Map of "<method> <path>" for every sysAPI route to the role required to call it.
+	sysAPIRoles map[string]SysAPIRole
+
+	// Counter of all sysAPI requests denied by authentication or authorization.
+	sysAPIDenials *prometheus.CounterVec
+
+	// Temporary log verbosity changes made through sysAPI.
+	logging *logState
+
 	// spec contains the swagger 2.0 docs for the sysAPI, this allows for programmatic access and
 	// automated documentation for how the sysAPI is structured and different endpoints available to it.
 	spec *spec.Swagger
@@ -110,6 +129,11 @@ type APIManagerOpts struct {
 
 	// Toggle whether secrets will be retrieved by vault within this application.
 	EnableVault bool
+
+	// Optionally provide a preconfigured vault client to be used instead of one built from
+	// the vault configuration keys. This is mostly useful for pointing the manager at an
+	// in-process vault instance (see the fake/fakevault package) within tests.
+	VaultClient *vault.Client
 }
 
 // Subsystem is a component of app that is bootstrapped by the manager upon process startup.
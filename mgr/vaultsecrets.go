@@ -0,0 +1,446 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fire833/go-api-utils/serialization"
+	"github.com/hashicorp/vault/api"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// vaultSecret is the copy of a KV v2 secret that is currently loaded into the process.
+// All SecretValues that reference the same <mountpath>/<path> share a single vaultSecret,
+// so they are always read from the same version of the secret.
+type vaultSecret struct {
+	mountpath string
+	path      string
+
+	// All keys within this secret that have been requested by SecretValues.
+	keys map[string]bool
+
+	data    map[string]interface{}
+	version int
+	loaded  time.Time
+
+	// If nonzero, the version of the secret that should be loaded instead of the latest.
+	pinned int
+}
+
+func vaultSecretID(mountpath, path string) string { return mountpath + "/" + path }
+
+// getVaultSecret returns the loaded data for the secret at <mountpath>/<path>, loading it
+// from vault first if it has not been loaded yet. Once loaded, secrets are only reloaded
+// from vault upon a call to refreshVaultSecrets(), which happens on process reload or
+// through the sysAPI.
+func (m *APIManager) getVaultSecret(mountpath, path, key string) (map[string]interface{}, error) {
+	pinned := 0
+
+	m.vaultLock.Lock()
+	if s, ok := m.vaultSecrets[vaultSecretID(mountpath, path)]; ok {
+		s.keys[key] = true
+		if s.data != nil {
+			data := s.data
+			m.vaultLock.Unlock()
+			return data, nil
+		}
+
+		pinned = s.pinned
+	}
+	m.vaultLock.Unlock()
+
+	// Retrieve the secret without holding vaultLock, so a slow vault doesn't block
+	// every other secret within the process.
+	data, version, e := m.fetchVaultSecret(mountpath, path, pinned)
+	if e != nil {
+		return nil, e
+	}
+
+	m.vaultLock.Lock()
+	defer m.vaultLock.Unlock()
+
+	s := m.trackVaultSecret(mountpath, path)
+	s.keys[key] = true
+
+	// Another caller may have loaded the secret in the meantime, in which case their copy is kept.
+	if s.data == nil {
+		m.storeVaultSecret(s, pinned, data, version)
+	}
+
+	if s.data == nil {
+		return data, nil
+	}
+
+	return s.data, nil
+}
+
+// trackVaultSecret returns the vaultSecret for <mountpath>/<path>, creating one if it
+// doesn't exist already. Callers must hold vaultLock.
+func (m *APIManager) trackVaultSecret(mountpath, path string) *vaultSecret {
+	id := vaultSecretID(mountpath, path)
+
+	s, ok := m.vaultSecrets[id]
+	if !ok {
+		s = &vaultSecret{
+			mountpath: mountpath,
+			path:      path,
+			keys:      make(map[string]bool),
+		}
+		m.vaultSecrets[id] = s
+	}
+
+	return s
+}
+
+// vaultSecretReferenced returns whether the secret at <mountpath>/<path> backs any SecretValue
+// registered with the manager. Only these secrets can be managed through the sysAPI.
+func (m *APIManager) vaultSecretReferenced(mountpath, path string) bool {
+	for _, key := range m.skeys {
+		if key.vault && key.secretmountpath == mountpath && key.secretpath == path {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fetchVaultSecret retrieves the provided version of the secret from vault, or the latest
+// version if version is 0. Callers must not hold vaultLock.
+func (m *APIManager) fetchVaultSecret(mountpath, path string, version int) (map[string]interface{}, int, error) {
+	if m.vault == nil {
+		return nil, 0, errors.New("vault not initialized, cannot retrieve secrets")
+	}
+
+	var (
+		secret *api.KVSecret
+		e      error
+	)
+
+	if version != 0 {
+		secret, e = m.vault.KVv2(mountpath).GetVersion(context.Background(), path, version)
+	} else {
+		secret, e = m.vault.KVv2(mountpath).Get(context.Background(), path)
+	}
+
+	if e != nil {
+		return nil, 0, e
+	}
+
+	if secret.Data == nil {
+		return nil, 0, fmt.Errorf("secret %s/%s has been deleted", mountpath, path)
+	}
+
+	if secret.VersionMetadata != nil {
+		version = secret.VersionMetadata.Version
+	}
+
+	return secret.Data, version, nil
+}
+
+// storeVaultSecret swaps data retrieved from vault into the secret. If the secret has been
+// pinned to another version since the data was retrieved for the pinned version, the data is
+// stale and is discarded. Callers must hold vaultLock.
+func (m *APIManager) storeVaultSecret(s *vaultSecret, pinned int, data map[string]interface{}, version int) bool {
+	if s.pinned != pinned {
+		return false
+	}
+
+	s.data = data
+	s.version = version
+	s.loaded = time.Now()
+
+	logger.Log(context.Background(), Verbosity(4), "loaded vault secret", "mountPath", s.mountpath, "path", s.path, "version", s.version)
+	return true
+}
+
+// refreshVaultSecret reloads the latest or pinned version of the secret from vault, loading it
+// for the first time if it hasn't been loaded yet.
+func (m *APIManager) refreshVaultSecret(mountpath, path string) error {
+	m.vaultLock.RLock()
+	pinned := 0
+	if s, ok := m.vaultSecrets[vaultSecretID(mountpath, path)]; ok {
+		pinned = s.pinned
+	}
+	m.vaultLock.RUnlock()
+
+	data, version, e := m.fetchVaultSecret(mountpath, path, pinned)
+	if e != nil {
+		return e
+	}
+
+	m.vaultLock.Lock()
+	defer m.vaultLock.Unlock()
+
+	m.storeVaultSecret(m.trackVaultSecret(mountpath, path), pinned, data, version)
+	return nil
+}
+
+// refreshVaultSecrets reloads all secrets that have been loaded into the process from vault.
+func (m *APIManager) refreshVaultSecrets() {
+	m.vaultLock.RLock()
+	secrets := []*vaultSecret{}
+	for _, s := range m.vaultSecrets {
+		secrets = append(secrets, s)
+	}
+	m.vaultLock.RUnlock()
+
+	for _, s := range secrets {
+		if e := m.refreshVaultSecret(s.mountpath, s.path); e != nil {
+			logger.Error("unable to refresh vault secret", "secret", vaultSecretID(s.mountpath, s.path), "error", e)
+		}
+	}
+}
+
+// pinVaultSecret pins the secret at <mountpath>/<path> to the provided version, and loads
+// that version into the process. A version of 0 unpins the secret and loads the latest version.
+// If the version can't be loaded, the secret is left as it was.
+func (m *APIManager) pinVaultSecret(mountpath, path string, version int) error {
+	data, loaded, e := m.fetchVaultSecret(mountpath, path, version)
+	if e != nil {
+		return e
+	}
+
+	m.vaultLock.Lock()
+	defer m.vaultLock.Unlock()
+
+	s := m.trackVaultSecret(mountpath, path)
+	s.pinned = version
+	m.storeVaultSecret(s, version, data, loaded)
+	return nil
+}
+
+// rollbackVaultSecret writes the data of the provided version back to vault as the
+// latest version of the secret, unpins the secret, and loads the new latest version.
+func (m *APIManager) rollbackVaultSecret(mountpath, path string, version int) error {
+	if m.vault == nil {
+		return errors.New("vault not initialized, cannot roll back secrets")
+	}
+
+	if _, e := m.vault.KVv2(mountpath).Rollback(context.Background(), path, version); e != nil {
+		return e
+	}
+
+	return m.pinVaultSecret(mountpath, path, 0)
+}
+
+// vaultSecretStatus returns the status of the secret at <mountpath>/<path>, including
+// all versions available within vault.
+func (m *APIManager) vaultSecretStatus(mountpath, path string) *VaultSecretStatus {
+	m.vaultLock.RLock()
+	status := &VaultSecretStatus{
+		MountPath: mountpath,
+		Path:      path,
+		Keys:      []string{},
+		Versions:  []*VaultSecretVersion{},
+	}
+
+	if s, ok := m.vaultSecrets[vaultSecretID(mountpath, path)]; ok {
+		for key := range s.keys {
+			status.Keys = append(status.Keys, key)
+		}
+
+		status.LoadedVersion = int64(s.version)
+		status.PinnedVersion = int64(s.pinned)
+		if !s.loaded.IsZero() {
+			status.LoadedTime = timestamppb.New(s.loaded)
+		}
+	}
+	m.vaultLock.RUnlock()
+
+	sort.Strings(status.Keys)
+
+	if m.vault == nil {
+		return status
+	}
+
+	versions, e := m.vault.KVv2(mountpath).GetVersionsAsList(context.Background(), path)
+	if e != nil {
+		logger.Warn("unable to list versions of vault secret", "mountPath", mountpath, "path", path, "error", e)
+		return status
+	}
+
+	for _, v := range versions {
+		version := &VaultSecretVersion{
+			Version:     int64(v.Version),
+			CreatedTime: timestamppb.New(v.CreatedTime),
+			Destroyed:   v.Destroyed,
+		}
+
+		if !v.DeletionTime.IsZero() {
+			version.DeletionTime = timestamppb.New(v.DeletionTime)
+		}
+
+		status.Versions = append(status.Versions, version)
+	}
+
+	return status
+}
+
+// vaultSecretStatuses returns the status of every vault secret either registered by
+// subsystems or loaded into the process.
+func (m *APIManager) vaultSecretStatuses() *VaultSecretStatusList {
+	m.vaultLock.Lock()
+	for _, key := range m.skeys {
+		if key.vault {
+			m.trackVaultSecret(key.secretmountpath, key.secretpath).keys[key.key] = true
+		}
+	}
+
+	secrets := []*vaultSecret{}
+	for _, s := range m.vaultSecrets {
+		secrets = append(secrets, s)
+	}
+	m.vaultLock.Unlock()
+
+	sort.Slice(secrets, func(i, j int) bool {
+		return vaultSecretID(secrets[i].mountpath, secrets[i].path) < vaultSecretID(secrets[j].mountpath, secrets[j].path)
+	})
+
+	list := &VaultSecretStatusList{Items: []*VaultSecretStatus{}}
+	for _, s := range secrets {
+		list.AddItem(m.vaultSecretStatus(s.mountpath, s.path))
+	}
+
+	return list
+}
+
+// Return the status of all vault secrets backing SecretValues within the process.
+func (m *APIManager) getVaultSecretsHandler(ctx *fasthttp.RequestCtx) {
+	serialization.MarshalBodyByAcceptHeader(ctx, m.vaultSecretStatuses())
+}
+
+// Reload one or all vault secrets within the process from vault.
+func (m *APIManager) refreshVaultSecretsHandler(ctx *fasthttp.RequestCtx) {
+	mountpath := string(ctx.QueryArgs().Peek("mountPath"))
+	path := string(ctx.QueryArgs().Peek("path"))
+
+	if mountpath == "" && path == "" {
+		m.refreshVaultSecrets()
+		serialization.MarshalBodyByAcceptHeader(ctx, m.vaultSecretStatuses())
+		return
+	}
+
+	if mountpath == "" || path == "" {
+		serialization.BadRequestResponseHandler(ctx, "both mountPath and path must be provided to refresh a single secret")
+		return
+	}
+
+	if !m.vaultSecretReferenced(mountpath, path) {
+		serialization.NotFoundResponseHandler(ctx, "secret is not referenced by any secret values within the process")
+		return
+	}
+
+	// Secrets that haven't been read yet are loaded by the refresh.
+	if e := m.refreshVaultSecret(mountpath, path); e != nil {
+		serialization.InternalErrorResponseHandler(ctx, fmt.Sprintf("unable to refresh secret: %v", e))
+		return
+	}
+
+	serialization.MarshalBodyByAcceptHeader(ctx, &VaultSecretStatusList{
+		Items: []*VaultSecretStatus{m.vaultSecretStatus(mountpath, path)},
+	})
+}
+
+// Pin a vault secret to a specific version within the process.
+func (m *APIManager) pinVaultSecretHandler(ctx *fasthttp.RequestCtx) {
+	mountpath, path, version, ok := vaultSecretVersionParams(ctx)
+	if !ok {
+		return
+	}
+
+	if !m.vaultSecretReferenced(mountpath, path) {
+		serialization.NotFoundResponseHandler(ctx, "secret is not referenced by any secret values within the process")
+		return
+	}
+
+	if e := m.pinVaultSecret(mountpath, path, version); e != nil {
+		serialization.BadRequestResponseHandler(ctx, fmt.Sprintf("unable to pin secret to version %d: %v", version, e))
+		return
+	}
+
+	serialization.MarshalBodyByAcceptHeader(ctx, m.vaultSecretStatus(mountpath, path))
+}
+
+// Unpin a vault secret, so the latest version is loaded into the process.
+func (m *APIManager) unpinVaultSecretHandler(ctx *fasthttp.RequestCtx) {
+	mountpath := string(ctx.QueryArgs().Peek("mountPath"))
+	path := string(ctx.QueryArgs().Peek("path"))
+
+	if mountpath == "" || path == "" {
+		serialization.BadRequestResponseHandler(ctx, "both mountPath and path must be provided")
+		return
+	}
+
+	if !m.vaultSecretReferenced(mountpath, path) {
+		serialization.NotFoundResponseHandler(ctx, "secret is not referenced by any secret values within the process")
+		return
+	}
+
+	if e := m.pinVaultSecret(mountpath, path, 0); e != nil {
+		serialization.InternalErrorResponseHandler(ctx, fmt.Sprintf("unable to load latest version of secret: %v", e))
+		return
+	}
+
+	serialization.MarshalBodyByAcceptHeader(ctx, m.vaultSecretStatus(mountpath, path))
+}
+
+// Write a previous version of a vault secret back to vault as the latest version.
+func (m *APIManager) rollbackVaultSecretHandler(ctx *fasthttp.RequestCtx) {
+	mountpath, path, version, ok := vaultSecretVersionParams(ctx)
+	if !ok {
+		return
+	}
+
+	if !m.vaultSecretReferenced(mountpath, path) {
+		serialization.NotFoundResponseHandler(ctx, "secret is not referenced by any secret values within the process")
+		return
+	}
+
+	if e := m.rollbackVaultSecret(mountpath, path, version); e != nil {
+		serialization.BadRequestResponseHandler(ctx, fmt.Sprintf("unable to roll back secret to version %d: %v", version, e))
+		return
+	}
+
+	serialization.MarshalBodyByAcceptHeader(ctx, m.vaultSecretStatus(mountpath, path))
+}
+
+func vaultSecretVersionParams(ctx *fasthttp.RequestCtx) (string, string, int, bool) {
+	mountpath := string(ctx.QueryArgs().Peek("mountPath"))
+	path := string(ctx.QueryArgs().Peek("path"))
+
+	if mountpath == "" || path == "" {
+		serialization.BadRequestResponseHandler(ctx, "both mountPath and path must be provided")
+		return "", "", 0, false
+	}
+
+	version, e := strconv.Atoi(string(ctx.QueryArgs().Peek("version")))
+	if e != nil || version < 1 {
+		serialization.BadRequestResponseHandler(ctx, "version must be a positive integer")
+		return "", "", 0, false
+	}
+
+	return mountpath, path, version, true
+}
@@ -0,0 +1,323 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package mgr
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestVaultSecretVersions(t *testing.T) {
+	m, v := loadVaultApp(t)
+	t.Setenv("VAULT_TOKEN", v.RootToken())
+	m.initVault()
+
+	v.PutSecret("kv", "app/api", map[string]interface{}{"key": "v1"})
+	v.PutSecret("kv", "app/api", map[string]interface{}{"key": "v2"})
+
+	key := NewSecretVaultValue("key", "desc", "default", "kv", "app/api")
+
+	t.Run("cached", func(t *testing.T) {
+		if got := key.GetString(); got != "v2" {
+			t.Fatalf("GetString() = %s, want v2", got)
+		}
+
+		v.PutSecret("kv", "app/api", map[string]interface{}{"key": "v3"})
+
+		if got := key.GetString(); got != "v2" {
+			t.Errorf("GetString() = %s after write, want cached v2", got)
+		}
+		if got := key.LoadedVersion(); got != 2 {
+			t.Errorf("LoadedVersion() = %d, want 2", got)
+		}
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		m.refreshVaultSecrets()
+
+		if got := key.GetString(); got != "v3" {
+			t.Errorf("GetString() = %s after refresh, want v3", got)
+		}
+	})
+
+	t.Run("pin", func(t *testing.T) {
+		if e := m.pinVaultSecret("kv", "app/api", 1); e != nil {
+			t.Fatalf("pinVaultSecret() error = %v", e)
+		}
+		if got := key.GetString(); got != "v1" {
+			t.Errorf("GetString() = %s after pin, want v1", got)
+		}
+
+		m.refreshVaultSecrets()
+		if got := key.GetString(); got != "v1" {
+			t.Errorf("GetString() = %s after refreshing pinned secret, want v1", got)
+		}
+
+		if e := m.pinVaultSecret("kv", "app/api", 10); e == nil {
+			t.Errorf("pinVaultSecret() wanted error for nonexistent version")
+		}
+		if got := key.GetString(); got != "v1" {
+			t.Errorf("GetString() = %s after failed pin, want v1", got)
+		}
+	})
+
+	t.Run("unpin", func(t *testing.T) {
+		if e := m.pinVaultSecret("kv", "app/api", 0); e != nil {
+			t.Fatalf("pinVaultSecret() error = %v", e)
+		}
+		if got := key.GetString(); got != "v3" {
+			t.Errorf("GetString() = %s after unpin, want v3", got)
+		}
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		if e := m.rollbackVaultSecret("kv", "app/api", 1); e != nil {
+			t.Fatalf("rollbackVaultSecret() error = %v", e)
+		}
+		if got := key.GetString(); got != "v1" {
+			t.Errorf("GetString() = %s after rollback, want v1", got)
+		}
+		if got := key.LoadedVersion(); got != 4 {
+			t.Errorf("LoadedVersion() = %d after rollback, want 4", got)
+		}
+	})
+
+	t.Run("status", func(t *testing.T) {
+		status := m.vaultSecretStatus("kv", "app/api")
+		if len(status.Versions) != 4 {
+			t.Errorf("vaultSecretStatus() versions = %d, want 4", len(status.Versions))
+		}
+		if status.LoadedVersion != 4 || status.PinnedVersion != 0 {
+			t.Errorf("vaultSecretStatus() loaded = %d, pinned = %d, want 4, 0", status.LoadedVersion, status.PinnedVersion)
+		}
+		if len(status.Keys) != 1 || status.Keys[0] != "key" {
+			t.Errorf("vaultSecretStatus() keys = %v, want [key]", status.Keys)
+		}
+	})
+}
+
+func TestVaultSecretHandlers(t *testing.T) {
+	m, v := loadVaultApp(t)
+	t.Setenv("VAULT_TOKEN", v.RootToken())
+	m.initVault()
+
+	v.PutSecret("kv", "app/api", map[string]interface{}{"key": "v1"})
+	v.PutSecret("kv", "app/api", map[string]interface{}{"key": "v2"})
+	v.PutSecret("kv", "app/registered", map[string]interface{}{"key": "v1"})
+	v.PutSecret("kv", "app/unloaded", map[string]interface{}{"key": "v1"})
+
+	key := NewSecretVaultValue("key", "desc", "default", "kv", "app/api")
+	m.skeys = append(m.skeys, NewSecretVaultValue("key", "desc", "default", "kv", "app/registered"), key,
+		NewSecretVaultValue("key", "desc", "default", "kv", "app/unloaded"))
+	key.Get()
+
+	tests := []struct {
+		name    string
+		handler fasthttp.RequestHandler
+		query   string
+		code    int
+
+		// If set, returns the body the handler should have responded with.
+		want func() proto.Message
+		// If set, the path, loaded and pinned versions of the secret within the body.
+		status *VaultSecretStatus
+	}{
+		{
+			// Referenced secrets are loaded by refreshes even if they haven't been read yet.
+			name:    "refreshUnloaded",
+			handler: m.refreshVaultSecretsHandler,
+			query:   "mountPath=kv&path=app/unloaded",
+			code:    200,
+			want: func() proto.Message {
+				return &VaultSecretStatusList{Items: []*VaultSecretStatus{m.vaultSecretStatus("kv", "app/unloaded")}}
+			},
+			status: &VaultSecretStatus{Path: "app/unloaded", LoadedVersion: 1},
+		},
+		{
+			name:    "list",
+			handler: m.getVaultSecretsHandler,
+			code:    200,
+			want:    func() proto.Message { return m.vaultSecretStatuses() },
+			status:  &VaultSecretStatus{Path: "app/registered"},
+		},
+		{
+			name:    "pin",
+			handler: m.pinVaultSecretHandler,
+			query:   "mountPath=kv&path=app/api&version=1",
+			code:    200,
+			want:    func() proto.Message { return m.vaultSecretStatus("kv", "app/api") },
+			status:  &VaultSecretStatus{Path: "app/api", LoadedVersion: 1, PinnedVersion: 1},
+		},
+		{
+			name:    "pinMissingVersion",
+			handler: m.pinVaultSecretHandler,
+			query:   "mountPath=kv&path=app/api",
+			code:    400,
+		},
+		{
+			name:    "pinBadVersion",
+			handler: m.pinVaultSecretHandler,
+			query:   "mountPath=kv&path=app/api&version=12",
+			code:    400,
+		},
+		{
+			name:    "pinUnreferenced",
+			handler: m.pinVaultSecretHandler,
+			query:   "mountPath=kv&path=app/other&version=1",
+			code:    404,
+		},
+		{
+			name:    "refreshOne",
+			handler: m.refreshVaultSecretsHandler,
+			query:   "mountPath=kv&path=app/api",
+			code:    200,
+			want: func() proto.Message {
+				return &VaultSecretStatusList{Items: []*VaultSecretStatus{m.vaultSecretStatus("kv", "app/api")}}
+			},
+			status: &VaultSecretStatus{Path: "app/api", LoadedVersion: 1, PinnedVersion: 1},
+		},
+		{
+			name:    "refreshUnknown",
+			handler: m.refreshVaultSecretsHandler,
+			query:   "mountPath=kv&path=app/none",
+			code:    404,
+		},
+		{
+			name:    "refreshPartial",
+			handler: m.refreshVaultSecretsHandler,
+			query:   "mountPath=kv",
+			code:    400,
+		},
+		{
+			name:    "unpin",
+			handler: m.unpinVaultSecretHandler,
+			query:   "mountPath=kv&path=app/api",
+			code:    200,
+			want:    func() proto.Message { return m.vaultSecretStatus("kv", "app/api") },
+			status:  &VaultSecretStatus{Path: "app/api", LoadedVersion: 2},
+		},
+		{
+			name:    "unpinUnreferenced",
+			handler: m.unpinVaultSecretHandler,
+			query:   "mountPath=kv&path=app/other",
+			code:    404,
+		},
+		{
+			name:    "rollbackUnreferenced",
+			handler: m.rollbackVaultSecretHandler,
+			query:   "mountPath=kv&path=app/other&version=1",
+			code:    404,
+		},
+		{
+			name:    "rollback",
+			handler: m.rollbackVaultSecretHandler,
+			query:   "mountPath=kv&path=app/api&version=1",
+			code:    200,
+			want:    func() proto.Message { return m.vaultSecretStatus("kv", "app/api") },
+			status:  &VaultSecretStatus{Path: "app/api", LoadedVersion: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.SetRequestURI("/secrets/vault?" + tt.query)
+
+			tt.handler(ctx)
+
+			if ctx.Response.StatusCode() != tt.code {
+				t.Errorf("status = %d, want %d: %s", ctx.Response.StatusCode(), tt.code, ctx.Response.Body())
+			}
+
+			if tt.want == nil {
+				return
+			}
+
+			want := tt.want()
+			got := want.ProtoReflect().New().Interface()
+			if e := protojson.Unmarshal(ctx.Response.Body(), got); e != nil {
+				t.Fatalf("unable to decode body %s: %v", ctx.Response.Body(), e)
+			}
+
+			if !proto.Equal(got, want) {
+				t.Errorf("body = %v, want %v", got, want)
+			}
+
+			statuses := []*VaultSecretStatus{}
+			switch got := got.(type) {
+			case *VaultSecretStatus:
+				statuses = append(statuses, got)
+			case *VaultSecretStatusList:
+				statuses = got.Items
+			}
+
+			for _, status := range statuses {
+				if status.Path == tt.status.Path {
+					if status.LoadedVersion != tt.status.LoadedVersion || status.PinnedVersion != tt.status.PinnedVersion {
+						t.Errorf("%s loaded = %d, pinned = %d, want %d, %d", status.Path, status.LoadedVersion, status.PinnedVersion,
+							tt.status.LoadedVersion, tt.status.PinnedVersion)
+					}
+
+					return
+				}
+			}
+
+			t.Errorf("body = %v, want it to contain %s", got, tt.status.Path)
+		})
+	}
+}
+
+func TestVaultSecretLoadFailure(t *testing.T) {
+	m, v := loadVaultApp(t)
+	t.Setenv("VAULT_TOKEN", v.RootToken())
+	m.initVault()
+
+	if _, e := m.getVaultSecret("kv", "app/missing", "key"); e == nil {
+		t.Errorf("getVaultSecret() wanted error for missing secret")
+	}
+
+	if e := m.pinVaultSecret("kv", "app/missing", 1); e == nil {
+		t.Errorf("pinVaultSecret() wanted error for missing secret")
+	}
+
+	if _, ok := m.vaultSecrets[vaultSecretID("kv", "app/missing")]; ok {
+		t.Errorf("missing secret is tracked after failing to load")
+	}
+}
+
+func TestSecretValueMarshal(t *testing.T) {
+	data, e := json.Marshal(NewSecretVaultValue("key", "desc", "hunter2", "kv", "app/api"))
+	if e != nil {
+		t.Fatalf("MarshalJSON() error = %v", e)
+	}
+
+	for _, want := range []string{`"source":"vault"`, `"mountPath":"kv"`, `"path":"app/api"`, `"typeOf":"String"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("MarshalJSON() = %s, want it to contain %s", data, want)
+		}
+	}
+
+	if strings.Contains(string(data), "hunter2") {
+		t.Errorf("MarshalJSON() = %s, leaked default secret value", data)
+	}
+}
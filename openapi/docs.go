@@ -0,0 +1,94 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package openapi
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// The documentation UI is bundled into the binary, so it can be served without any access to
+// external CDNs. Any assets in the ui directory are served alongside the index page.
+//
+// The UI is Swagger UI, with swagger-ui-bundle.js and swagger-ui.css copied unmodified from a
+// release of the swagger-ui-dist package, see ui/LICENSE.swagger-ui for the version. To update it,
+// copy both files from a newer release and update the version noted there.
+//
+//go:embed ui
+var uiAssets embed.FS
+
+var docsIndex = template.Must(template.ParseFS(uiAssets, "ui/index.html"))
+
+// Only allow the UI to load its own assets and talk to the origin serving it.
+const docsContentSecurityPolicy string = "default-src 'none'; script-src 'self'; style-src 'self'; img-src 'self' data:; connect-src 'self'; base-uri 'none'; form-action 'none'; frame-ancestors 'none'"
+
+// DocsHandler returns a handler serving the embedded API documentation UI under base, rendering
+// the OpenAPI document served at specURL. The index page is served at base itself, and the UI
+// assets at base/<file>, so the handler should be registered for both, for example with
+// router.GET(base, h) and router.GET(base+"/{FILE}", h).
+func DocsHandler(base, specURL, title string) fasthttp.RequestHandler {
+	base = strings.TrimSuffix(base, "/")
+
+	index := &bytes.Buffer{}
+	if e := docsIndex.Execute(index, struct {
+		Base    string
+		SpecURL string
+		Title   string
+	}{base, specURL, title}); e != nil {
+		panic("unable to render documentation index: " + e.Error())
+	}
+
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.Header.Set("Content-Security-Policy", docsContentSecurityPolicy)
+		ctx.Response.Header.Set("X-Content-Type-Options", "nosniff")
+
+		name := strings.TrimPrefix(strings.TrimPrefix(string(ctx.Path()), base), "/")
+		if name == "" || name == "index.html" {
+			ctx.Response.Header.SetContentType("text/html; charset=utf-8")
+			ctx.Response.Header.Set("Cache-Control", "no-cache")
+			ctx.Response.SetBody(index.Bytes())
+			ctx.Response.SetStatusCode(http.StatusOK)
+			return
+		}
+
+		data, e := fs.ReadFile(uiAssets, path.Join("ui", path.Clean("/"+name)))
+		if e != nil {
+			ctx.Error("not found", http.StatusNotFound)
+			return
+		}
+
+		contentType := mime.TypeByExtension(path.Ext(name))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		ctx.Response.Header.SetContentType(contentType)
+		ctx.Response.Header.Set("Cache-Control", "public, max-age=3600")
+		ctx.Response.SetBody(data)
+		ctx.Response.SetStatusCode(http.StatusOK)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestDocsHandler(t *testing.T) {
+	h := DocsHandler("/api/docs/", "/api/openapi.json", `<script>alert("x")</script>`)
+
+	tests := []struct {
+		path        string
+		code        int
+		contentType string
+		contains    string
+	}{
+		{"/api/docs", 200, "text/html", `data-spec="/api/openapi.json"`},
+		{"/api/docs/", 200, "text/html", `src="/api/docs/docs.js"`},
+		{"/api/docs/docs.js", 200, "javascript", "data-spec"},
+		{"/api/docs/docs.css", 200, "text/css", "#auth"},
+		{"/api/docs/swagger-ui-bundle.js", 200, "javascript", "SwaggerUIBundle"},
+		{"/api/docs/swagger-ui.css", 200, "text/css", ".swagger-ui"},
+		{"/api/docs/missing.js", 404, "", ""},
+		{"/api/docs/../docs.go", 404, "", ""},
+	}
+
+	for _, tt := range tests {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI(tt.path)
+		h(ctx)
+
+		if ctx.Response.StatusCode() != tt.code {
+			t.Errorf("GET %s = %d, want %d", tt.path, ctx.Response.StatusCode(), tt.code)
+		}
+
+		if tt.code != 200 {
+			continue
+		}
+
+		if !strings.Contains(string(ctx.Response.Header.ContentType()), tt.contentType) {
+			t.Errorf("GET %s content type = %s, want %s", tt.path, ctx.Response.Header.ContentType(), tt.contentType)
+		}
+
+		if !strings.Contains(string(ctx.Response.Body()), tt.contains) {
+			t.Errorf("GET %s body doesn't contain %q", tt.path, tt.contains)
+		}
+
+		if len(ctx.Response.Header.Peek("Content-Security-Policy")) == 0 {
+			t.Errorf("GET %s is missing a content security policy", tt.path)
+		}
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/docs")
+	h(ctx)
+	if strings.Contains(string(ctx.Response.Body()), "<script>alert") {
+		t.Errorf("title is not escaped within index:\n%s", ctx.Response.Body())
+	}
+}
@@ -0,0 +1,348 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Package openapi is a model of OpenAPI 3.1 documents, which can be built up from registered
+// routes and schemas, or converted from the Swagger 2.0 objects used throughout the rest of the
+// project, and served as JSON or YAML.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The OpenAPI version of generated documents.
+const Version string = "3.1.0"
+
+// Prefix of references to schemas within a document.
+const SchemaRefPrefix string = "#/components/schemas/"
+
+// Document is the root object of an OpenAPI 3.1 document.
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []*Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem  `json:"paths"`
+	Components *Components           `json:"components,omitempty"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+	Tags       []*Tag                `json:"tags,omitempty"`
+}
+
+// Info is metadata about the API described by a document.
+type Info struct {
+	Title       string `json:"title"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a server hosting the API described by a document.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag adds metadata to a tag used by operations.
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Components holds the reusable objects of a document.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityRequirement maps security scheme names to the scopes required of them.
+type SecurityRequirement map[string][]string
+
+// SecurityScheme describes a way of authenticating with the API.
+type SecurityScheme struct {
+	Type         string      `json:"type"`
+	Description  string      `json:"description,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	In           string      `json:"in,omitempty"`
+	Scheme       string      `json:"scheme,omitempty"`
+	BearerFormat string      `json:"bearerFormat,omitempty"`
+	Flows        *OAuthFlows `json:"flows,omitempty"`
+}
+
+// OAuthFlows configures the supported OAuth flows of an oauth2 security scheme.
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow configures a single OAuth flow.
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// PathItem describes the operations available on a single path.
+type PathItem struct {
+	Summary     string       `json:"summary,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Get         *Operation   `json:"get,omitempty"`
+	Put         *Operation   `json:"put,omitempty"`
+	Post        *Operation   `json:"post,omitempty"`
+	Delete      *Operation   `json:"delete,omitempty"`
+	Options     *Operation   `json:"options,omitempty"`
+	Head        *Operation   `json:"head,omitempty"`
+	Patch       *Operation   `json:"patch,omitempty"`
+	Trace       *Operation   `json:"trace,omitempty"`
+	Parameters  []*Parameter `json:"parameters,omitempty"`
+}
+
+// Operation returns a pointer to the operation slot of the path item for method, or nil
+// if method isn't a method supported by OpenAPI.
+func (p *PathItem) Operation(method string) **Operation {
+	switch strings.ToUpper(method) {
+	case "GET":
+		return &p.Get
+	case "PUT":
+		return &p.Put
+	case "POST":
+		return &p.Post
+	case "DELETE":
+		return &p.Delete
+	case "OPTIONS":
+		return &p.Options
+	case "HEAD":
+		return &p.Head
+	case "PATCH":
+		return &p.Patch
+	case "TRACE":
+		return &p.Trace
+	default:
+		return nil
+	}
+}
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses,omitempty"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+
+	// Specification extensions, keys must begin with "x-".
+	Extensions map[string]any `json:"-"`
+}
+
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	return marshalWithExtensions((*operation)(o), o.Extensions)
+}
+
+// Parameter describes a single operation parameter, outside of the request body.
+type Parameter struct {
+	Ref         string  `json:"$ref,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	In          string  `json:"in,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Deprecated  bool    `json:"deprecated,omitempty"`
+	Style       string  `json:"style,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes the body of a request.
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+	Required    bool                  `json:"required,omitempty"`
+}
+
+// Response describes a single response of an operation.
+type Response struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header describes a response header.
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// MediaType describes the content of a request or response body in a single media type.
+type MediaType struct {
+	Schema  *Schema `json:"schema,omitempty"`
+	Example any     `json:"example,omitempty"`
+}
+
+// Content returns a content map serving schema with each of the provided media types.
+func Content(schema *Schema, mediaTypes ...string) map[string]*MediaType {
+	content := make(map[string]*MediaType, len(mediaTypes))
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = &MediaType{Schema: schema}
+	}
+
+	return content
+}
+
+// New returns an empty document with the provided info.
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+	}
+}
+
+// AddOperation adds an operation for method on path, returning an error if there is already an
+// operation registered for method on that path, or method isn't supported by OpenAPI.
+func (d *Document) AddOperation(method, path string, op *Operation) error {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+	}
+
+	slot := item.Operation(method)
+	if slot == nil {
+		return fmt.Errorf("method %s is not supported by OpenAPI", method)
+	}
+
+	if *slot != nil {
+		return fmt.Errorf("operation %s %s is already registered", method, path)
+	}
+
+	*slot = op
+	d.Paths[path] = item
+	return nil
+}
+
+// AddSchema adds a named schema to the document components, returning an error if a different
+// schema is already registered under that name.
+func (d *Document) AddSchema(name string, schema *Schema) error {
+	if d.Components == nil {
+		d.Components = &Components{}
+	}
+
+	if d.Components.Schemas == nil {
+		d.Components.Schemas = make(map[string]*Schema)
+	}
+
+	if existing, ok := d.Components.Schemas[name]; ok && !schemasEqual(existing, schema) {
+		return fmt.Errorf("schema %s is already registered", name)
+	}
+
+	d.Components.Schemas[name] = schema
+	return nil
+}
+
+func schemasEqual(a, b *Schema) bool {
+	if a == b {
+		return true
+	}
+
+	ab, e1 := json.Marshal(a)
+	bb, e2 := json.Marshal(b)
+	return e1 == nil && e2 == nil && bytes.Equal(ab, bb)
+}
+
+// JSON returns the document encoded as indented JSON.
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "   ")
+}
+
+// YAML returns the document encoded as YAML. The document is encoded from its JSON form, so
+// both encodings are always equivalent.
+func (d *Document) YAML() ([]byte, error) {
+	data, e := json.Marshal(d)
+	if e != nil {
+		return nil, e
+	}
+
+	node := &yaml.Node{}
+	if e := yaml.Unmarshal(data, node); e != nil {
+		return nil, e
+	}
+
+	blockStyle(node)
+	return yaml.Marshal(node)
+}
+
+// blockStyle clears the flow and quoting styles nodes decoded from JSON are given, so they are
+// encoded as regular YAML. Scalars keep their tags, so strings such as response codes are still
+// quoted wherever they would otherwise be decoded as another type.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// marshalWithExtensions encodes v, which must encode to a JSON object, with the extensions
+// added to it as additional keys.
+func marshalWithExtensions(v any, extensions map[string]any) ([]byte, error) {
+	data, e := json.Marshal(v)
+	if e != nil || len(extensions) == 0 {
+		return data, e
+	}
+
+	keys := make([]string, 0, len(extensions))
+	for key := range extensions {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(data[:len(data)-1])
+	for i, key := range keys {
+		value, e := json.Marshal(extensions[key])
+		if e != nil {
+			return nil, e
+		}
+
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,135 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestAddOperation(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1.0.0"})
+
+	if e := doc.AddOperation("GET", "/foo", &Operation{OperationID: "getFoo"}); e != nil {
+		t.Fatal(e)
+	}
+
+	if e := doc.AddOperation("POST", "/foo", &Operation{OperationID: "createFoo"}); e != nil {
+		t.Fatal(e)
+	}
+
+	if doc.Paths["/foo"].Get.OperationID != "getFoo" || doc.Paths["/foo"].Post.OperationID != "createFoo" {
+		t.Errorf("path item = %+v, want both operations", doc.Paths["/foo"])
+	}
+
+	if e := doc.AddOperation("GET", "/foo", &Operation{OperationID: "other"}); e == nil {
+		t.Errorf("AddOperation() wanted error for duplicate operation")
+	}
+
+	if e := doc.AddOperation("CONNECT", "/bar", &Operation{}); e == nil {
+		t.Errorf("AddOperation() wanted error for unsupported method")
+	}
+
+	if _, ok := doc.Paths["/bar"]; ok {
+		t.Errorf("AddOperation() added path for unsupported method")
+	}
+}
+
+func TestAddSchema(t *testing.T) {
+	doc := New(Info{})
+
+	if e := doc.AddSchema("Foo", TypedSchema("string", "")); e != nil {
+		t.Fatal(e)
+	}
+
+	if e := doc.AddSchema("Foo", TypedSchema("string", "")); e != nil {
+		t.Errorf("AddSchema() = %v, want no error for identical schema", e)
+	}
+
+	if e := doc.AddSchema("Foo", TypedSchema("integer", "int32")); e == nil {
+		t.Errorf("AddSchema() wanted error for conflicting schema")
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1.0.0"})
+	doc.AddOperation("GET", "/foo", &Operation{
+		OperationID: "getFoo",
+		Responses: map[string]*Response{
+			"200": {Description: "OK", Content: Content(RefSchema("Foo"), "application/json")},
+		},
+		Extensions: map[string]any{"x-role": "admin"},
+	})
+	doc.AddSchema("Foo", &Schema{
+		Type: SchemaType{"object"},
+		Properties: map[string]*Schema{
+			"name": {Type: SchemaType{"string", "null"}},
+		},
+		Extensions: map[string]any{"x-internal": true},
+	})
+
+	data, e := doc.JSON()
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	for _, want := range []string{`"openapi": "3.1.0"`, `"x-role": "admin"`, `"x-internal": true`, `"$ref": "#/components/schemas/Foo"`, `"string",`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("document does not contain %s:\n%s", want, data)
+		}
+	}
+
+	t.Run("yamlEquivalent", func(t *testing.T) {
+		y, e := doc.YAML()
+		if e != nil {
+			t.Fatal(e)
+		}
+
+		if strings.Contains(string(y), "{") || !strings.Contains(string(y), `"200":`) {
+			t.Errorf("yaml document uses flow style or unquoted response codes:\n%s", y)
+		}
+
+		var fromJSON, fromYAML any
+		if e := json.Unmarshal(data, &fromJSON); e != nil {
+			t.Fatal(e)
+		}
+		if e := yaml.Unmarshal(y, &fromYAML); e != nil {
+			t.Fatal(e)
+		}
+
+		if !reflect.DeepEqual(fromJSON, fromYAML) {
+			t.Errorf("yaml document differs from json document:\n%s", y)
+		}
+	})
+
+	t.Run("schemaTypeRoundTrip", func(t *testing.T) {
+		for _, typ := range []SchemaType{{"string"}, {"string", "null"}} {
+			data, _ := json.Marshal(typ)
+
+			var out SchemaType
+			if e := json.Unmarshal(data, &out); e != nil || !reflect.DeepEqual(out, typ) {
+				t.Errorf("round trip of %v = %v (%v)", typ, out, e)
+			}
+		}
+	})
+}
@@ -0,0 +1,50 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/valyala/fasthttp"
+)
+
+// JSONHandler returns a handler serving the document returned by doc as JSON. doc is called on
+// every request, so the served document reflects routes registered after the handler is created.
+func JSONHandler(doc func() *Document) fasthttp.RequestHandler {
+	return serve(doc, (*Document).JSON, "application/json")
+}
+
+// YAMLHandler returns a handler serving the document returned by doc as YAML.
+func YAMLHandler(doc func() *Document) fasthttp.RequestHandler {
+	return serve(doc, (*Document).YAML, "application/yaml")
+}
+
+func serve(doc func() *Document, encode func(*Document) ([]byte, error), contentType string) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		data, e := encode(doc())
+		if e != nil {
+			ctx.Error("unable to encode OpenAPI document: "+e.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		ctx.Response.Header.SetContentType(contentType)
+		ctx.Response.SetBody(data)
+		ctx.Response.SetStatusCode(http.StatusOK)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package openapi
+
+import "encoding/json"
+
+// Schema is an OpenAPI 3.1 schema object, which is a JSON Schema 2020-12 schema. Only the
+// keywords used by this project are modeled.
+type Schema struct {
+	Ref         string     `json:"$ref,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Type        SchemaType `json:"type,omitempty"`
+	Format      string     `json:"format,omitempty"`
+
+	Enum     []any `json:"enum,omitempty"`
+	Const    any   `json:"const,omitempty"`
+	Default  any   `json:"default,omitempty"`
+	Examples []any `json:"examples,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	MinProperties        *int64             `json:"minProperties,omitempty"`
+	MaxProperties        *int64             `json:"maxProperties,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *int64  `json:"minItems,omitempty"`
+	MaxItems    *int64  `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+
+	MinLength        *int64 `json:"minLength,omitempty"`
+	MaxLength        *int64 `json:"maxLength,omitempty"`
+	Pattern          string `json:"pattern,omitempty"`
+	ContentEncoding  string `json:"contentEncoding,omitempty"`
+	ContentMediaType string `json:"contentMediaType,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+
+	ReadOnly   bool `json:"readOnly,omitempty"`
+	WriteOnly  bool `json:"writeOnly,omitempty"`
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Specification extensions, keys must begin with "x-".
+	Extensions map[string]any `json:"-"`
+}
+
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	return marshalWithExtensions((*schema)(s), s.Extensions)
+}
+
+// SchemaType is the set of JSON types a schema allows. It is encoded as a single string when
+// there is only one type, and as an array otherwise, such as ["string", "null"] for a nullable string.
+type SchemaType []string
+
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+func (t *SchemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*t = SchemaType{single}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// RefSchema returns a schema referencing the named schema within the document components.
+func RefSchema(name string) *Schema {
+	return &Schema{Ref: SchemaRefPrefix + name}
+}
+
+// TypedSchema returns a schema of a single type and format.
+func TypedSchema(typ, format string) *Schema {
+	return &Schema{Type: SchemaType{typ}, Format: format}
+}
+
+// ArraySchema returns an array schema of items.
+func ArraySchema(items *Schema) *Schema {
+	return &Schema{Type: SchemaType{"array"}, Items: items}
+}
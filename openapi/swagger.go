@@ -0,0 +1,512 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Media type assumed for bodies of Swagger 2.0 operations that don't declare any.
+const defaultMediaType string = "application/json"
+
+// FromSwagger converts a Swagger 2.0 document into an OpenAPI 3.1 document.
+func FromSwagger(s *spec.Swagger) *Document {
+	doc := New(Info{})
+
+	if s.Info != nil {
+		doc.Info = Info{
+			Title:       s.Info.Title,
+			Description: s.Info.Description,
+			Version:     s.Info.Version,
+		}
+	}
+
+	if url := serverURL(s); url != "" {
+		doc.Servers = []*Server{{URL: url}}
+	}
+
+	if s.Paths != nil {
+		for path, item := range s.Paths.Paths {
+			doc.Paths[path] = FromPathItem(item, s.Consumes, s.Produces)
+		}
+	}
+
+	for name, schema := range s.Definitions {
+		doc.AddSchema(name, FromSchema(&schema))
+	}
+
+	if len(s.Responses) > 0 || len(s.Parameters) > 0 || len(s.SecurityDefinitions) > 0 {
+		if doc.Components == nil {
+			doc.Components = &Components{}
+		}
+
+		for name, resp := range s.Responses {
+			if doc.Components.Responses == nil {
+				doc.Components.Responses = make(map[string]*Response)
+			}
+
+			doc.Components.Responses[name] = fromResponse(&resp, s.Produces)
+		}
+
+		for name, param := range s.Parameters {
+			if param.In == "body" || param.In == "formData" {
+				// Bodies can't be reused as parameters in OpenAPI 3.
+				continue
+			}
+
+			if doc.Components.Parameters == nil {
+				doc.Components.Parameters = make(map[string]*Parameter)
+			}
+
+			doc.Components.Parameters[name] = fromParameter(&param)
+		}
+
+		for name, scheme := range s.SecurityDefinitions {
+			if doc.Components.SecuritySchemes == nil {
+				doc.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+			}
+
+			doc.Components.SecuritySchemes[name] = fromSecurityScheme(scheme)
+		}
+	}
+
+	doc.Security = fromSecurity(s.Security)
+
+	for _, tag := range s.Tags {
+		doc.Tags = append(doc.Tags, &Tag{Name: tag.Name, Description: tag.Description})
+	}
+
+	return doc
+}
+
+func serverURL(s *spec.Swagger) string {
+	basePath := strings.TrimSuffix(s.BasePath, "/")
+
+	if s.Host == "" {
+		return basePath
+	}
+
+	scheme := "https"
+	if len(s.Schemes) > 0 {
+		scheme = s.Schemes[0]
+	}
+
+	return scheme + "://" + s.Host + basePath
+}
+
+// FromPathItem converts a Swagger 2.0 path item into an OpenAPI 3.1 path item. The consumes
+// and produces media types are the document defaults, used for operations that don't
+// declare their own.
+func FromPathItem(item spec.PathItem, consumes, produces []string) *PathItem {
+	p := &PathItem{}
+
+	for method, op := range map[string]*spec.Operation{
+		http.MethodGet:     item.Get,
+		http.MethodPut:     item.Put,
+		http.MethodPost:    item.Post,
+		http.MethodDelete:  item.Delete,
+		http.MethodOptions: item.Options,
+		http.MethodHead:    item.Head,
+		http.MethodPatch:   item.Patch,
+	} {
+		if op != nil {
+			*p.Operation(method) = FromOperation(op, consumes, produces)
+		}
+	}
+
+	for _, param := range item.Parameters {
+		if param.In != "body" && param.In != "formData" {
+			p.Parameters = append(p.Parameters, fromParameter(&param))
+		}
+	}
+
+	return p
+}
+
+// FromOperation converts a Swagger 2.0 operation into an OpenAPI 3.1 operation. Body and form
+// parameters are converted into a request body.
+func FromOperation(op *spec.Operation, consumes, produces []string) *Operation {
+	if len(op.Consumes) > 0 {
+		consumes = op.Consumes
+	}
+
+	if len(op.Produces) > 0 {
+		produces = op.Produces
+	}
+
+	if len(consumes) == 0 {
+		consumes = []string{defaultMediaType}
+	}
+
+	if len(produces) == 0 {
+		produces = []string{defaultMediaType}
+	}
+
+	o := &Operation{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Deprecated:  op.Deprecated,
+		Security:    fromSecurity(op.Security),
+	}
+
+	var form *Schema
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "body":
+			o.RequestBody = &RequestBody{
+				Description: param.Description,
+				Required:    param.Required,
+				Content:     Content(FromSchema(param.Schema), consumes...),
+			}
+		case "formData":
+			if form == nil {
+				form = &Schema{Type: SchemaType{"object"}, Properties: map[string]*Schema{}}
+			}
+
+			prop := fromSimpleSchema(param.SimpleSchema, param.CommonValidations)
+			prop.Description = param.Description
+			form.Properties[param.Name] = prop
+
+			if param.Required {
+				form.Required = append(form.Required, param.Name)
+			}
+		default:
+			o.Parameters = append(o.Parameters, fromParameter(&param))
+		}
+	}
+
+	if form != nil {
+		mediaType := "application/x-www-form-urlencoded"
+		for _, prop := range form.Properties {
+			if prop.ContentMediaType != "" {
+				mediaType = "multipart/form-data"
+			}
+		}
+
+		o.RequestBody = &RequestBody{Content: Content(form, mediaType)}
+	}
+
+	if op.Responses != nil {
+		o.Responses = make(map[string]*Response)
+
+		if op.Responses.Default != nil {
+			o.Responses["default"] = fromResponse(op.Responses.Default, produces)
+		}
+
+		for code, resp := range op.Responses.StatusCodeResponses {
+			r := fromResponse(&resp, produces)
+			if r.Description == "" && r.Ref == "" {
+				r.Description = http.StatusText(code)
+			}
+
+			o.Responses[strconv.Itoa(code)] = r
+		}
+	}
+
+	if len(op.Extensions) > 0 {
+		o.Extensions = make(map[string]any, len(op.Extensions))
+		for key, value := range op.Extensions {
+			o.Extensions[key] = value
+		}
+	}
+
+	return o
+}
+
+func fromResponse(resp *spec.Response, produces []string) *Response {
+	if ref := resp.Ref.String(); ref != "" {
+		return &Response{Ref: strings.Replace(ref, "#/responses/", "#/components/responses/", 1)}
+	}
+
+	r := &Response{Description: resp.Description}
+
+	if resp.Schema != nil {
+		r.Content = Content(FromSchema(resp.Schema), produces...)
+	}
+
+	for name, header := range resp.Headers {
+		if r.Headers == nil {
+			r.Headers = make(map[string]*Header)
+		}
+
+		r.Headers[name] = &Header{
+			Description: header.Description,
+			Schema:      fromSimpleSchema(header.SimpleSchema, header.CommonValidations),
+		}
+	}
+
+	return r
+}
+
+func fromParameter(param *spec.Parameter) *Parameter {
+	if ref := param.Ref.String(); ref != "" {
+		return &Parameter{Ref: strings.Replace(ref, "#/parameters/", "#/components/parameters/", 1)}
+	}
+
+	p := &Parameter{
+		Name:        param.Name,
+		In:          param.In,
+		Description: param.Description,
+		Required:    param.Required || param.In == "path",
+		Schema:      fromSimpleSchema(param.SimpleSchema, param.CommonValidations),
+	}
+
+	if param.Type == "array" {
+		explode := false
+
+		switch param.CollectionFormat {
+		case "multi":
+			explode = true
+		case "ssv":
+			p.Style = "spaceDelimited"
+		case "pipes":
+			p.Style = "pipeDelimited"
+		}
+
+		if param.In == "query" {
+			p.Explode = &explode
+		}
+	}
+
+	return p
+}
+
+func fromSecurityScheme(scheme *spec.SecurityScheme) *SecurityScheme {
+	s := &SecurityScheme{
+		Type:        scheme.Type,
+		Description: scheme.Description,
+		Name:        scheme.Name,
+		In:          scheme.In,
+	}
+
+	switch scheme.Type {
+	case "basic":
+		s.Type = "http"
+		s.Scheme = "basic"
+	case "oauth2":
+		flow := &OAuthFlow{
+			AuthorizationURL: scheme.AuthorizationURL,
+			TokenURL:         scheme.TokenURL,
+			Scopes:           scheme.Scopes,
+		}
+
+		if flow.Scopes == nil {
+			flow.Scopes = map[string]string{}
+		}
+
+		s.Flows = &OAuthFlows{}
+		switch scheme.Flow {
+		case "implicit":
+			s.Flows.Implicit = flow
+		case "password":
+			s.Flows.Password = flow
+		case "application":
+			s.Flows.ClientCredentials = flow
+		case "accessCode":
+			s.Flows.AuthorizationCode = flow
+		}
+	}
+
+	return s
+}
+
+func fromSecurity(security []map[string][]string) []SecurityRequirement {
+	if security == nil {
+		return nil
+	}
+
+	requirements := make([]SecurityRequirement, 0, len(security))
+	for _, requirement := range security {
+		r := make(SecurityRequirement, len(requirement))
+		for name, scopes := range requirement {
+			// Scopes must always be encoded as an array, even if empty.
+			if scopes == nil {
+				scopes = []string{}
+			}
+
+			r[name] = scopes
+		}
+
+		requirements = append(requirements, r)
+	}
+
+	return requirements
+}
+
+// FromSchema converts a Swagger 2.0 schema into an OpenAPI 3.1 schema, rewriting references
+// to definitions into references to component schemas.
+func FromSchema(s *spec.Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+
+	if ref := s.Ref.String(); ref != "" {
+		return &Schema{Ref: strings.Replace(ref, "#/definitions/", SchemaRefPrefix, 1), Description: s.Description}
+	}
+
+	out := &Schema{
+		Title:       s.Title,
+		Description: s.Description,
+		Format:      s.Format,
+		Enum:        s.Enum,
+		Default:     s.Default,
+		Required:    s.Required,
+		Pattern:     s.Pattern,
+		MinLength:   s.MinLength,
+		MaxLength:   s.MaxLength,
+		MinItems:    s.MinItems,
+		MaxItems:    s.MaxItems,
+		UniqueItems: s.UniqueItems,
+		MultipleOf:  s.MultipleOf,
+		ReadOnly:    s.ReadOnly,
+	}
+
+	if len(s.Type) > 0 {
+		out.Type = SchemaType(s.Type)
+	}
+
+	if nullable, ok := s.Extensions.GetBool("x-nullable"); ok && nullable && len(out.Type) == 1 {
+		out.Type = append(out.Type, "null")
+	}
+
+	if s.Type.Contains("file") {
+		out.Type = SchemaType{"string"}
+		out.ContentMediaType = "application/octet-stream"
+	}
+
+	if s.Example != nil {
+		out.Examples = []any{s.Example}
+	}
+
+	out.Minimum, out.ExclusiveMinimum = bound(s.Minimum, s.ExclusiveMinimum)
+	out.Maximum, out.ExclusiveMaximum = bound(s.Maximum, s.ExclusiveMaximum)
+
+	if s.MinProperties != nil {
+		out.MinProperties = s.MinProperties
+	}
+
+	if s.MaxProperties != nil {
+		out.MaxProperties = s.MaxProperties
+	}
+
+	for name, prop := range s.Properties {
+		if out.Properties == nil {
+			out.Properties = make(map[string]*Schema, len(s.Properties))
+		}
+
+		out.Properties[name] = FromSchema(&prop)
+	}
+
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		out.AdditionalProperties = FromSchema(s.AdditionalProperties.Schema)
+	}
+
+	if s.Items != nil {
+		if s.Items.Schema != nil {
+			out.Items = FromSchema(s.Items.Schema)
+		} else if len(s.Items.Schemas) > 0 {
+			out.Items = FromSchema(&s.Items.Schemas[0])
+		}
+	}
+
+	out.AllOf = fromSchemas(s.AllOf)
+	out.AnyOf = fromSchemas(s.AnyOf)
+	out.OneOf = fromSchemas(s.OneOf)
+	out.Not = FromSchema(s.Not)
+
+	for key, value := range s.Extensions {
+		if key == "x-nullable" {
+			continue
+		}
+
+		if out.Extensions == nil {
+			out.Extensions = make(map[string]any)
+		}
+
+		out.Extensions[key] = value
+	}
+
+	return out
+}
+
+func fromSchemas(schemas []spec.Schema) []*Schema {
+	if len(schemas) == 0 {
+		return nil
+	}
+
+	out := make([]*Schema, 0, len(schemas))
+	for i := range schemas {
+		out = append(out, FromSchema(&schemas[i]))
+	}
+
+	return out
+}
+
+// fromSimpleSchema converts the schema of a Swagger 2.0 parameter, header or items object.
+func fromSimpleSchema(simple spec.SimpleSchema, v spec.CommonValidations) *Schema {
+	out := &Schema{
+		Format:      simple.Format,
+		Default:     simple.Default,
+		Enum:        v.Enum,
+		Pattern:     v.Pattern,
+		MinLength:   v.MinLength,
+		MaxLength:   v.MaxLength,
+		MinItems:    v.MinItems,
+		MaxItems:    v.MaxItems,
+		UniqueItems: v.UniqueItems,
+		MultipleOf:  v.MultipleOf,
+	}
+
+	if simple.Type != "" {
+		out.Type = SchemaType{simple.Type}
+	}
+
+	if simple.Type == "file" {
+		out.Type = SchemaType{"string"}
+		out.ContentMediaType = "application/octet-stream"
+	}
+
+	if simple.Example != nil {
+		out.Examples = []any{simple.Example}
+	}
+
+	out.Minimum, out.ExclusiveMinimum = bound(v.Minimum, v.ExclusiveMinimum)
+	out.Maximum, out.ExclusiveMaximum = bound(v.Maximum, v.ExclusiveMaximum)
+
+	if simple.Items != nil {
+		out.Items = fromSimpleSchema(simple.Items.SimpleSchema, simple.Items.CommonValidations)
+	}
+
+	return out
+}
+
+// bound converts a Swagger 2.0 boolean exclusive bound into the numeric exclusive bound of JSON Schema.
+func bound(value *float64, exclusive bool) (*float64, *float64) {
+	if exclusive {
+		return nil, value
+	}
+
+	return value, nil
+}
@@ -0,0 +1,179 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+)
+
+func testSwagger() *spec.Swagger {
+	get := spec.NewOperation("getWidget").
+		WithTags("widgets").
+		AddParam(spec.PathParam("ID").Typed("string", "")).
+		AddParam(spec.QueryParam("limit").Typed("integer", "int32").WithMinimum(1, false).WithMaximum(100, true)).
+		AddParam(spec.QueryParam("tags").CollectionOf(spec.NewItems().Typed("string", ""), "multi")).
+		RespondsWith(200, spec.NewResponse().WithDescription("The widget.").WithSchema(spec.RefSchema("#/definitions/Widget"))).
+		RespondsWith(404, spec.NewResponse())
+	get.AddExtension("x-sysapi-role", "readonly")
+	get.SecuredWith("bearer")
+
+	put := spec.NewOperation("putWidget").
+		WithConsumes("application/yaml").
+		AddParam(spec.BodyParam("body", spec.RefSchema("#/definitions/Widget")).AsRequired()).
+		RespondsWith(200, spec.NewResponse().WithDescription("The widget."))
+
+	upload := spec.NewOperation("uploadWidget").
+		AddParam(spec.FileParam("file").AsRequired()).
+		AddParam(spec.FormDataParam("name").Typed("string", ""))
+
+	return &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Swagger:  "2.0",
+			Info:     &spec.Info{InfoProps: spec.InfoProps{Title: "Widgets", Version: "1.0.0"}},
+			Host:     "example.com",
+			BasePath: "/api",
+			Schemes:  []string{"http"},
+			Produces: []string{"application/json", "application/xml"},
+			Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+				"/widgets/{ID}":  {PathItemProps: spec.PathItemProps{Get: get, Put: put}},
+				"/widgets/files": {PathItemProps: spec.PathItemProps{Post: upload}},
+			}},
+			Definitions: spec.Definitions{
+				"Widget": *spec.StringProperty().WithTitle("Widget").WithMinLength(1),
+				"Gadget": {
+					SchemaProps: spec.SchemaProps{
+						Type:       []string{"object"},
+						Properties: spec.SchemaProperties{"widgets": *spec.ArrayProperty(spec.RefSchema("#/definitions/Widget"))},
+						Minimum:    nil,
+					},
+					VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{"x-nullable": true}},
+				},
+			},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"bearer": spec.APIKeyAuth("Authorization", "header"),
+				"basic":  spec.BasicAuth(),
+			},
+		},
+	}
+}
+
+func TestFromSwagger(t *testing.T) {
+	doc := FromSwagger(testSwagger())
+
+	if doc.OpenAPI != Version || doc.Info.Title != "Widgets" {
+		t.Errorf("document header = %s %+v", doc.OpenAPI, doc.Info)
+	}
+
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "http://example.com/api" {
+		t.Errorf("servers = %+v, want http://example.com/api", doc.Servers)
+	}
+
+	get := doc.Paths["/widgets/{ID}"].Get
+	if get == nil {
+		t.Fatalf("GET /widgets/{ID} not converted")
+	}
+
+	t.Run("parameters", func(t *testing.T) {
+		if len(get.Parameters) != 3 {
+			t.Fatalf("parameters = %d, want 3", len(get.Parameters))
+		}
+
+		id, limit, tags := get.Parameters[0], get.Parameters[1], get.Parameters[2]
+		if id.In != "path" || !id.Required || id.Schema.Type[0] != "string" {
+			t.Errorf("path parameter = %+v", id)
+		}
+		if *limit.Schema.Minimum != 1 || limit.Schema.Maximum != nil || *limit.Schema.ExclusiveMaximum != 100 {
+			t.Errorf("limit bounds = %+v, want minimum 1 and exclusive maximum 100", limit.Schema)
+		}
+		if tags.Schema.Type[0] != "array" || tags.Schema.Items.Type[0] != "string" || tags.Explode == nil || !*tags.Explode {
+			t.Errorf("tags parameter = %+v, want exploded string array", tags)
+		}
+	})
+
+	t.Run("responses", func(t *testing.T) {
+		ok := get.Responses["200"]
+		if ok.Description != "The widget." || len(ok.Content) != 2 || ok.Content["application/xml"].Schema.Ref != "#/components/schemas/Widget" {
+			t.Errorf("200 response = %+v, want widget in json and xml", ok)
+		}
+
+		if get.Responses["404"].Description != "Not Found" {
+			t.Errorf("404 description = %q, want default status text", get.Responses["404"].Description)
+		}
+	})
+
+	t.Run("extensionsAndSecurity", func(t *testing.T) {
+		if get.Extensions["x-sysapi-role"] != "readonly" {
+			t.Errorf("extensions = %v", get.Extensions)
+		}
+		if len(get.Security) != 1 || get.Security[0]["bearer"] == nil || len(get.Security[0]["bearer"]) != 0 {
+			t.Errorf("security = %v", get.Security)
+		}
+
+		schemes := doc.Components.SecuritySchemes
+		if schemes["bearer"].Type != "apiKey" || schemes["bearer"].In != "header" || schemes["basic"].Type != "http" || schemes["basic"].Scheme != "basic" {
+			t.Errorf("security schemes = %+v %+v", schemes["bearer"], schemes["basic"])
+		}
+	})
+
+	t.Run("requestBody", func(t *testing.T) {
+		body := doc.Paths["/widgets/{ID}"].Put.RequestBody
+		if body == nil || !body.Required || body.Content["application/yaml"] == nil || len(body.Content) != 1 {
+			t.Errorf("request body = %+v, want required yaml body", body)
+		}
+
+		form := doc.Paths["/widgets/files"].Post.RequestBody
+		if form == nil || form.Content["multipart/form-data"] == nil {
+			t.Fatalf("form body = %+v, want multipart body", form)
+		}
+
+		schema := form.Content["multipart/form-data"].Schema
+		if schema.Properties["file"].ContentMediaType != "application/octet-stream" || len(schema.Required) != 1 || schema.Required[0] != "file" {
+			t.Errorf("form schema = %+v", schema)
+		}
+	})
+
+	t.Run("schemas", func(t *testing.T) {
+		widget := doc.Components.Schemas["Widget"]
+		if widget.Type[0] != "string" || *widget.MinLength != 1 {
+			t.Errorf("widget = %+v", widget)
+		}
+
+		gadget := doc.Components.Schemas["Gadget"]
+		if len(gadget.Type) != 2 || gadget.Type[1] != "null" {
+			t.Errorf("gadget type = %v, want nullable object", gadget.Type)
+		}
+		if gadget.Properties["widgets"].Items.Ref != "#/components/schemas/Widget" {
+			t.Errorf("gadget widgets = %+v", gadget.Properties["widgets"])
+		}
+	})
+}
+
+func TestFromPathItemDefaults(t *testing.T) {
+	item := FromPathItem(spec.PathItem{PathItemProps: spec.PathItemProps{
+		Post: spec.NewOperation("create").
+			AddParam(spec.BodyParam("body", spec.StringProperty())).
+			RespondsWith(201, spec.NewResponse().WithSchema(spec.StringProperty())),
+	}}, nil, nil)
+
+	if item.Post.RequestBody.Content[defaultMediaType] == nil || item.Post.Responses["201"].Content[defaultMediaType] == nil {
+		t.Errorf("operation = %+v, want application/json bodies by default", item.Post)
+	}
+}
@@ -0,0 +1,44 @@
+// Starts Swagger UI on the document read from the data-spec attribute of the body. This lives in
+// its own file rather than inline, so the page can be served with a content security policy that
+// only allows scripts from the origin.
+(function () {
+  "use strict";
+
+  var TOKEN_KEY = "openapi-docs-token";
+  var specURL = document.body.getAttribute("data-spec");
+
+  // Add the bearer token to every request, including the one for the document itself, since it
+  // may require authentication as well. The token only lives as long as the browser tab.
+  function authorize(req) {
+    var token = sessionStorage.getItem(TOKEN_KEY);
+    if (token) {
+      req.headers.Authorization = "Bearer " + token;
+    }
+    return req;
+  }
+
+  function load() {
+    window.ui = SwaggerUIBundle({
+      url: specURL,
+      dom_id: "#swagger-ui",
+      deepLinking: true,
+      validatorUrl: null,
+      requestInterceptor: authorize,
+      presets: [SwaggerUIBundle.presets.apis],
+      layout: "BaseLayout",
+    });
+  }
+
+  document.getElementById("auth").addEventListener("submit", function (event) {
+    event.preventDefault();
+    var token = document.getElementById("token").value;
+    if (token) {
+      sessionStorage.setItem(TOKEN_KEY, token);
+    } else {
+      sessionStorage.removeItem(TOKEN_KEY);
+    }
+    load();
+  });
+
+  load();
+})();
@@ -0,0 +1,40 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package pagination
+
+import (
+	_ "embed"
+
+	"github.com/fire833/go-api-utils/serialization"
+)
+
+//go:generate protoc --go_out=. pagination.proto
+// go:generate protoc-go-inject-tag -input pagination.pb.go
+//go:generate protoc --include_source_info --descriptor_set_out=pagination.binpb pagination.proto
+
+// Source info of pagination.proto, so schemas generated from its messages are documented.
+//
+//go:embed pagination.binpb
+var paginationSourceInfo []byte
+
+func init() {
+	if e := serialization.RegisterProtoSourceInfo(paginationSourceInfo); e != nil {
+		panic(e)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Package pagination implements cursor based pagination of list operations: the limit and page_token
+// parameters of list requests, opaque signed page tokens encoding the sort keys of the last item of
+// a page, the ListMeta returned with each page, and Link headers to the following pages. Adapters
+// translating cursors into queries are within the gormsql (keyset queries) and elastic (search_after)
+// subsystems.
+package pagination
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fire833/go-api-utils/object"
+	"github.com/fire833/go-api-utils/serialization"
+	"github.com/valyala/fasthttp"
+)
+
+// Options configures the size of pages and the tokens issued for them.
+type Options struct {
+	// The key page tokens are signed with, which must be shared by every replica of a service for
+	// tokens to be valid across them. If empty, a random key is used, so tokens are only valid within
+	// the process that issued them.
+	Key []byte
+
+	// The size of pages when the limit of a request is 0, and the maximum size of pages, to which
+	// larger limits are reduced.
+	DefaultLimit int
+	MaxLimit     int
+
+	// How long page tokens are valid for after they are issued. 0 for no limit.
+	TokenTTL time.Duration
+}
+
+// DefaultOptions returns the options used until SetOptions is called.
+func DefaultOptions() Options {
+	return Options{
+		DefaultLimit: 50,
+		MaxLimit:     1000,
+		TokenTTL:     24 * time.Hour,
+	}
+}
+
+type resolvedOptions struct {
+	Options
+
+	// The key tokens are signed with, which is the random process key if no key is set.
+	key []byte
+}
+
+var (
+	options    atomic.Pointer[resolvedOptions]
+	processKey []byte = randomKey()
+)
+
+func init() {
+	SetOptions(DefaultOptions())
+}
+
+// SetOptions replaces the options of pagination.
+func SetOptions(opts Options) {
+	key := opts.Key
+	if len(key) == 0 {
+		key = processKey
+	}
+
+	options.Store(&resolvedOptions{Options: opts, key: key})
+}
+
+// Params are the pagination parameters of list requests, which are bound with ParamsFrom and can be
+// documented with serialization.NewParameters(pagination.Params{}). Request messages with limit and
+// page_token fields are bound by serialization.BindParams instead.
+type Params struct {
+	Limit     uint32 `query:"limit" description:"The maximum number of items to return. The server may return fewer items, or reduce larger limits."`
+	PageToken string `query:"page_token" description:"The next page token returned with the previous page, to retrieve the following page."`
+}
+
+// ParamsFrom binds the pagination parameters of the request, see serialization.Bind.
+func ParamsFrom(ctx *fasthttp.RequestCtx) (Params, error) {
+	p := Params{}
+	return p, serialization.Bind(ctx, &p)
+}
+
+// Size returns the number of items to return within the page, which is the limit of the request
+// reduced to the maximum limit, or the default limit if the request has no limit.
+func (p Params) Size() int {
+	opts := options.Load()
+
+	switch {
+	case p.Limit == 0:
+		return opts.DefaultLimit
+	case opts.MaxLimit > 0 && int64(p.Limit) > int64(opts.MaxLimit):
+		return opts.MaxLimit
+	default:
+		return int(p.Limit)
+	}
+}
+
+// Cursor returns the cursor of the page token of the request, or nil for the first page, see ParseToken.
+func (p Params) Cursor(scope string) (*Cursor, error) {
+	if p.PageToken == "" {
+		return nil, nil
+	}
+
+	return ParseToken(scope, p.PageToken)
+}
+
+// Order is a key a list is sorted by, such as a column or field name, along with its direction.
+// Lists should be sorted by a unique key last (ie the primary key) so that the sort order of items
+// is total, otherwise items with the same sort keys may be skipped or repeated across pages.
+type Order struct {
+	Key  string
+	Desc bool
+}
+
+// List is implemented by list messages with the ListMeta of the page they contain, ie:
+//
+//	message WidgetList {
+//	  repeated Widget items = 1;
+//	  pagination.ListMeta meta = 2;
+//	}
+type List interface {
+	object.ObjectList
+
+	GetMeta() *ListMeta
+}
+
+// Page trims items, which were queried with a limit of one more than size from the cursor of the
+// request, to the page of size items, returning the ListMeta of the page. If there were more items,
+// the meta has a token for the next page, whose cursor is the sort keys of the last item within the
+// page as returned by keys.
+func Page[T any](items []T, size int, scope string, keys func(T) []any) ([]T, *ListMeta, error) {
+	meta := &ListMeta{}
+	if len(items) <= size {
+		return items, meta, nil
+	}
+
+	items = items[:size]
+	if size == 0 {
+		return items, meta, nil
+	}
+
+	next, e := NewToken(scope, Cursor{Keys: keys(items[size-1])})
+	if e != nil {
+		return nil, nil, e
+	}
+
+	meta.NextPageToken = next
+	return items, meta, nil
+}
+
+// SetLinkHeader sets the Link header (RFC 8288) of the response to a link to the first page of the
+// list and, if there is one, to the next page. Links are relative to the request, with its page_token
+// query argument replaced.
+func SetLinkHeader(ctx *fasthttp.RequestCtx, meta *ListMeta) {
+	links := []string{`<` + pageLink(ctx, "") + `>; rel="first"`}
+	if next := meta.GetNextPageToken(); next != "" {
+		links = append(links, `<`+pageLink(ctx, next)+`>; rel="next"`)
+	}
+
+	ctx.Response.Header.Set("Link", strings.Join(links, ", "))
+}
+
+func pageLink(ctx *fasthttp.RequestCtx, pageToken string) string {
+	uri := &fasthttp.URI{}
+	ctx.URI().CopyTo(uri)
+
+	args := uri.QueryArgs()
+	args.Del("page_token")
+	if pageToken != "" {
+		args.Set("page_token", pageToken)
+	}
+
+	return string(uri.RequestURI())
+}
@@ -0,0 +1,163 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.5
+// source: pagination.proto
+
+package pagination
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ListMeta
+//
+// ListMeta describes a page of a list returned by a list operation, and how to retrieve the next page.
+//
+// swagger:model ListMeta
+type ListMeta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The token to pass as the page_token parameter to retrieve the next page, which is empty on the last page.
+	//
+	// @gotags: yaml:"next_page_token,omitempty" xml:"next_page_token,omitempty" bson:"next_page_token,omitempty"
+	NextPageToken string `protobuf:"bytes,1,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty" yaml:"next_page_token,omitempty" xml:"next_page_token,omitempty" bson:"next_page_token,omitempty"`
+	// An estimate of the total number of items within the list, if known.
+	//
+	// @gotags: yaml:"total_estimate,omitempty" xml:"total_estimate,omitempty" bson:"total_estimate,omitempty"
+	TotalEstimate *int64 `protobuf:"varint,2,opt,name=total_estimate,json=totalEstimate,proto3,oneof" json:"total_estimate,omitempty" yaml:"total_estimate,omitempty" xml:"total_estimate,omitempty" bson:"total_estimate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMeta) Reset() {
+	*x = ListMeta{}
+	mi := &file_pagination_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMeta) ProtoMessage() {}
+
+func (x *ListMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_pagination_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMeta.ProtoReflect.Descriptor instead.
+func (*ListMeta) Descriptor() ([]byte, []int) {
+	return file_pagination_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListMeta) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListMeta) GetTotalEstimate() int64 {
+	if x != nil && x.TotalEstimate != nil {
+		return *x.TotalEstimate
+	}
+	return 0
+}
+
+var File_pagination_proto protoreflect.FileDescriptor
+
+const file_pagination_proto_rawDesc = "" +
+	"\n" +
+	"\x10pagination.proto\x12\n" +
+	"pagination\"q\n" +
+	"\bListMeta\x12&\n" +
+	"\x0fnext_page_token\x18\x01 \x01(\tR\rnextPageToken\x12*\n" +
+	"\x0etotal_estimate\x18\x02 \x01(\x03H\x00R\rtotalEstimate\x88\x01\x01B\x11\n" +
+	"\x0f_total_estimateB\x0fZ\r../paginationb\x06proto3"
+
+var (
+	file_pagination_proto_rawDescOnce sync.Once
+	file_pagination_proto_rawDescData []byte
+)
+
+func file_pagination_proto_rawDescGZIP() []byte {
+	file_pagination_proto_rawDescOnce.Do(func() {
+		file_pagination_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pagination_proto_rawDesc), len(file_pagination_proto_rawDesc)))
+	})
+	return file_pagination_proto_rawDescData
+}
+
+var file_pagination_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_pagination_proto_goTypes = []any{
+	(*ListMeta)(nil), // 0: pagination.ListMeta
+}
+var file_pagination_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_pagination_proto_init() }
+func file_pagination_proto_init() {
+	if File_pagination_proto != nil {
+		return
+	}
+	file_pagination_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pagination_proto_rawDesc), len(file_pagination_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_pagination_proto_goTypes,
+		DependencyIndexes: file_pagination_proto_depIdxs,
+		MessageInfos:      file_pagination_proto_msgTypes,
+	}.Build()
+	File_pagination_proto = out.File
+	file_pagination_proto_goTypes = nil
+	file_pagination_proto_depIdxs = nil
+}
@@ -0,0 +1,104 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package pagination
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/valyala/fasthttp"
+)
+
+func TestParams(t *testing.T) {
+	tests := []struct {
+		query string
+		size  int
+	}{
+		{"", 50},
+		{"limit=10", 10},
+		{"limit=5000", 1000},
+	}
+
+	for _, tt := range tests {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI("/widgets?" + tt.query)
+
+		p, e := ParamsFrom(ctx)
+		if e != nil {
+			t.Fatal(e)
+		}
+
+		if size := p.Size(); size != tt.size {
+			t.Errorf("Size(%s) = %d, want %d", tt.query, size, tt.size)
+		}
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/widgets?limit=-1")
+	if _, e := ParamsFrom(ctx); apierror.StatusOf(e) != http.StatusBadRequest {
+		t.Errorf("ParamsFrom() = %v, want 400", e)
+	}
+}
+
+func TestPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	keys := func(i int) []any { return []any{int64(i)} }
+
+	page, meta, e := Page(items, 3, "/numbers", keys)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if !reflect.DeepEqual(page, []int{1, 2, 3}) || meta.GetNextPageToken() == "" {
+		t.Fatalf("Page() = %v, %v", page, meta)
+	}
+
+	cursor, e := Params{PageToken: meta.GetNextPageToken()}.Cursor("/numbers")
+	if e != nil || !reflect.DeepEqual(cursor.Keys, []any{int64(3)}) {
+		t.Errorf("Cursor() = %v, %v, want the keys of 3", cursor, e)
+	}
+
+	page, meta, _ = Page(items[3:], 3, "/numbers", keys)
+	if !reflect.DeepEqual(page, []int{4, 5}) || meta.GetNextPageToken() != "" {
+		t.Errorf("last Page() = %v, %v", page, meta)
+	}
+
+	if cursor, e := (Params{}).Cursor("/numbers"); cursor != nil || e != nil {
+		t.Errorf("Cursor() of the first page = %v, %v", cursor, e)
+	}
+}
+
+func TestSetLinkHeader(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/widgets?color=blue&page_token=old&limit=2")
+
+	SetLinkHeader(ctx, &ListMeta{NextPageToken: "next"})
+
+	want := `</widgets?color=blue&limit=2>; rel="first", </widgets?color=blue&limit=2&page_token=next>; rel="next"`
+	if link := string(ctx.Response.Header.Peek("Link")); link != want {
+		t.Errorf("Link = %s, want %s", link, want)
+	}
+
+	SetLinkHeader(ctx, &ListMeta{})
+	if link := string(ctx.Response.Header.Peek("Link")); link != `</widgets?color=blue&limit=2>; rel="first"` {
+		t.Errorf("Link of the last page = %s", link)
+	}
+}
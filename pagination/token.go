@@ -0,0 +1,160 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package pagination
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fire833/go-api-utils/apierror"
+)
+
+// Length of the signature appended to page tokens, a truncated HMAC-SHA256 of the token.
+const tokenSignatureSize int = 16
+
+// ErrInvalidToken is wrapped by errors returned for page tokens that weren't issued by NewToken
+// with the same key, have expired, or were issued for a different list.
+var ErrInvalidToken = errors.New("invalid page token")
+
+func init() {
+	// Types of sort keys that gob doesn't know of, along with the basic types it does.
+	gob.Register(time.Time{})
+	gob.Register(json.Number(""))
+}
+
+// Cursor is a position within a list, as the values of the keys the list is sorted by of the last
+// item before the position, which is used to query the items after it (ie with keyset pagination
+// or search_after).
+type Cursor struct {
+	// The values of the sort keys, in the order the list is sorted by them.
+	Keys []any
+}
+
+// token is the signed content of a page token.
+type token struct {
+	// A hash of the scope the token was issued for, see NewToken.
+	Scope []byte
+
+	// When the token was issued, in unix seconds.
+	Issued int64
+
+	Keys []any
+}
+
+// NewToken returns an opaque page token encoding the cursor, signed with the key set with SetOptions
+// so clients can't forge or tamper with it. scope identifies the list the token is valid for, such
+// as the path and filters of the list, so tokens can't be passed to a different list or used after
+// the filters of the list are changed. Keys are normalized to the basic types understood by drivers
+// (ie int64, float64, string, bool, []byte and time.Time), calling driver.Valuer if implemented.
+func NewToken(scope string, cursor Cursor) (string, error) {
+	opts := options.Load()
+
+	keys := make([]any, 0, len(cursor.Keys))
+	for _, key := range cursor.Keys {
+		if valuer, ok := key.(driver.Valuer); ok {
+			value, e := valuer.Value()
+			if e != nil {
+				return "", fmt.Errorf("unable to encode sort key: %w", e)
+			}
+
+			key = value
+		}
+
+		keys = append(keys, key)
+	}
+
+	buf := &bytes.Buffer{}
+	if e := gob.NewEncoder(buf).Encode(&token{Scope: scopeHash(scope), Issued: time.Now().Unix(), Keys: keys}); e != nil {
+		return "", fmt.Errorf("unable to encode page token: %w", e)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()) + "." + base64.RawURLEncoding.EncodeToString(sign(opts.key, buf.Bytes())), nil
+}
+
+// ParseToken returns the cursor encoded within a page token returned by NewToken for the same scope.
+// An *apierror.Error with status 400 wrapping ErrInvalidToken is returned if the token is invalid.
+func ParseToken(scope, pageToken string) (*Cursor, error) {
+	opts := options.Load()
+
+	invalid := func(reason string) error {
+		return apierror.BadRequest(ErrInvalidToken.Error()+": "+reason).WithField("page_token", reason).WithCause(ErrInvalidToken)
+	}
+
+	content, signature, ok := bytes.Cut([]byte(pageToken), []byte("."))
+	if !ok {
+		return nil, invalid("token is malformed")
+	}
+
+	data, e1 := base64.RawURLEncoding.DecodeString(string(content))
+	mac, e2 := base64.RawURLEncoding.DecodeString(string(signature))
+	if e1 != nil || e2 != nil {
+		return nil, invalid("token is malformed")
+	}
+
+	if !hmac.Equal(mac, sign(opts.key, data)) {
+		return nil, invalid("token signature doesn't match")
+	}
+
+	t := &token{}
+	if e := gob.NewDecoder(bytes.NewReader(data)).Decode(t); e != nil {
+		return nil, invalid("token is malformed")
+	}
+
+	if !hmac.Equal(t.Scope, scopeHash(scope)) {
+		return nil, invalid("token was issued for a different list")
+	}
+
+	if opts.TokenTTL > 0 && time.Since(time.Unix(t.Issued, 0)) > opts.TokenTTL {
+		return nil, invalid("token has expired")
+	}
+
+	return &Cursor{Keys: t.Keys}, nil
+}
+
+func sign(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)[:tokenSignatureSize]
+}
+
+// scopeHash returns a short hash of the scope, so scopes aren't revealed to clients within tokens.
+func scopeHash(scope string) []byte {
+	sum := sha256.Sum256([]byte(scope))
+	return sum[:8]
+}
+
+// randomKey returns a key to sign tokens with when no key is configured, so tokens are only valid
+// within the process.
+func randomKey() []byte {
+	key := make([]byte, 32)
+	if _, e := rand.Read(key); e != nil {
+		panic(e)
+	}
+
+	return key
+}
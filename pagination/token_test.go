@@ -0,0 +1,92 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package pagination
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fire833/go-api-utils/apierror"
+)
+
+func TestTokenRoundTrip(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	token, e := NewToken("/widgets", Cursor{Keys: []any{int64(42), "name", created, 1.5, true, nil, sql.NullString{String: "valuer", Valid: true}}})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	cursor, e := ParseToken("/widgets", token)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if want := []any{int64(42), "name", created, 1.5, true, nil, "valuer"}; !reflect.DeepEqual(cursor.Keys, want) {
+		t.Errorf("keys = %#v, want %#v", cursor.Keys, want)
+	}
+}
+
+func TestParseTokenInvalid(t *testing.T) {
+	defer SetOptions(DefaultOptions())
+
+	token, e := NewToken("/widgets", Cursor{Keys: []any{int64(1)}})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	content, signature, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name  string
+		scope string
+		token string
+	}{
+		{"malformed", "/widgets", "not a token"},
+		{"tampered", "/widgets", content + "A." + signature},
+		{"different scope", "/widgets?color=blue", token},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, e := ParseToken(tt.scope, tt.token)
+			if apierror.StatusOf(e) != http.StatusBadRequest || !errors.Is(e, ErrInvalidToken) {
+				t.Errorf("ParseToken() = %v, want invalid token", e)
+			}
+		})
+	}
+
+	SetOptions(Options{Key: []byte("another key")})
+	if _, e := ParseToken("/widgets", token); !errors.Is(e, ErrInvalidToken) {
+		t.Errorf("ParseToken() with a different key = %v, want invalid token", e)
+	}
+
+	SetOptions(Options{Key: []byte("key"), TokenTTL: time.Nanosecond})
+	token, _ = NewToken("/widgets", Cursor{})
+	time.Sleep(time.Second)
+
+	if _, e := ParseToken("/widgets", token); !errors.Is(e, ErrInvalidToken) {
+		t.Errorf("ParseToken() of an expired token = %v, want invalid token", e)
+	}
+}
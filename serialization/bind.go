@@ -0,0 +1,740 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/object"
+	"github.com/go-openapi/spec"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Bind sets the fields of dst from the path parameters set by the router, the query arguments and
+// the headers of the request. dst is either a message, which is bound with BindParams, or a pointer
+// to a struct whose fields are bound according to their tags, ie:
+//
+//	type ListParams struct {
+//		IDs     []int64       `query:"id"`
+//		Limit   uint32        `query:"limit" default:"20" description:"The maximum number of objects to return."`
+//		Order   string        `query:"order" enum:"asc,desc" default:"asc"`
+//		Since   *time.Time    `query:"since"`
+//		Timeout time.Duration `header:"X-Timeout" default:"30s"`
+//		Name    string        `path:"name"`
+//		Token   string        `header:"X-Token,required"`
+//	}
+//
+// Integers are parsed with range checking of their bit size, durations with time.ParseDuration,
+// protobuf enums by their value names or numbers, and times (as RFC 3339) and any other type
+// implementing encoding.TextUnmarshaler with UnmarshalText. Slices are set from every argument of
+// the same name, each of which may also be a comma separated list (ie ?id=1&id=2,3), and pointers
+// are only set if the parameter is present. Parameters that aren't present are set from their
+// default if they have one, and are otherwise left untouched, unless they are marked as required.
+//
+// Every parameter that can't be bound is reported together within an *apierror.Error with status
+// 400, with a violation for each parameter. NewParameters documents the parameters of the struct.
+func Bind(ctx *fasthttp.RequestCtx, dst any) error {
+	if msg, ok := dst.(object.Object); ok {
+		return BindParams(ctx, msg)
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unable to bind parameters to %T, which isn't a pointer to a struct", dst)
+	}
+
+	fields, e := bindFieldsOf(rv.Elem().Type())
+	if e != nil {
+		return e
+	}
+
+	var violations []apierror.FieldViolation
+	for _, f := range fields {
+		values, ok := f.values(ctx)
+		if !ok {
+			switch {
+			case f.hasDefault:
+				values = []string{f.def}
+			case f.required:
+				violations = append(violations, apierror.FieldViolation{Field: f.name, Description: "is required"})
+				continue
+			default:
+				continue
+			}
+		}
+
+		if e := f.set(rv.Elem().FieldByIndex(f.index), values); e != nil {
+			violations = append(violations, apierror.FieldViolation{Field: f.name, Description: e.Error()})
+		}
+	}
+
+	if len(violations) > 0 {
+		return apierror.BadRequest("invalid request parameters").WithDetails(violations...)
+	}
+
+	return nil
+}
+
+// NewParameters returns the spec parameters of a struct bound with Bind, or pointer to one, in the
+// order of its fields. Parameters are typed from their fields, and documented with the description,
+// default and enum tags of the fields. It panics if the struct can't be bound, as it is called when
+// registering routes.
+func NewParameters(params any) []*spec.Parameter {
+	t := reflect.TypeOf(params)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	fields, e := bindFieldsOf(t)
+	if e != nil {
+		panic(e)
+	}
+
+	result := make([]*spec.Parameter, 0, len(fields))
+	for _, f := range fields {
+		var p *spec.Parameter
+		switch f.in {
+		case "path":
+			p = spec.PathParam(f.name)
+		case "header":
+			p = spec.HeaderParam(f.name)
+		default:
+			p = spec.QueryParam(f.name)
+		}
+
+		p.WithDescription(f.description)
+		if f.required {
+			p.AsRequired()
+		}
+
+		typ, format, enum := bindParamType(f.elem)
+		for _, value := range f.enum {
+			enum = append(enum, value)
+		}
+
+		if f.repeated {
+			items := spec.NewItems().Typed(typ, format).WithEnum(enum...)
+			if isUnsigned(f.elem) {
+				items.WithMinimum(0, false)
+			}
+
+			collection := "csv"
+			if f.in == "query" {
+				collection = "multi"
+			}
+
+			p.CollectionOf(items, collection)
+		} else {
+			p.Typed(typ, format).WithEnum(enum...)
+			if isUnsigned(f.elem) {
+				p.WithMinimum(0, false)
+			}
+		}
+
+		if f.hasDefault {
+			p.WithDefault(bindParamDefault(typ, f.def, f.repeated))
+		}
+
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// bindField is a field of a struct bound to a parameter.
+type bindField struct {
+	index []int
+
+	// Where the parameter is, one of path, query or header, and its name.
+	in   string
+	name string
+
+	required    bool
+	hasDefault  bool
+	def         string
+	enum        []string
+	description string
+
+	// Whether the field is a slice, and the type of its elements, or of the field otherwise.
+	repeated bool
+	elem     reflect.Type
+}
+
+var bindFieldsCache sync.Map // reflect.Type -> []*bindField
+
+// bindFieldsOf returns the fields of the struct bound to parameters, returning an error if any of their tags are invalid.
+func bindFieldsOf(t reflect.Type) ([]*bindField, error) {
+	if fields, ok := bindFieldsCache.Load(t); ok {
+		return fields.([]*bindField), nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unable to bind parameters to %s, which isn't a struct", t)
+	}
+
+	fields := []*bindField{}
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+
+		f := &bindField{index: sf.Index, elem: sf.Type}
+		for _, in := range []string{"path", "query", "header"} {
+			if tag, ok := sf.Tag.Lookup(in); ok {
+				name, opts, _ := strings.Cut(tag, ",")
+				f.in, f.name, f.required = in, name, opts == "required" || in == "path"
+				break
+			}
+		}
+
+		if f.in == "" || f.name == "-" {
+			continue
+		}
+
+		if f.name == "" {
+			f.name = sf.Name
+		}
+
+		f.def, f.hasDefault = sf.Tag.Lookup("default")
+		f.description = sf.Tag.Get("description")
+		if enum := sf.Tag.Get("enum"); enum != "" {
+			f.enum = strings.Split(enum, ",")
+		}
+
+		switch {
+		case sf.Type.Kind() == reflect.Slice:
+			f.repeated, f.elem = true, sf.Type.Elem()
+		case sf.Type.Kind() == reflect.Pointer:
+			f.elem = sf.Type.Elem()
+		}
+
+		if bindParamDescription(f.elem) == "" {
+			return nil, fmt.Errorf("unable to bind parameter %s to field %s of type %s", f.name, sf.Name, sf.Type)
+		}
+
+		if f.hasDefault {
+			if e := f.set(reflect.New(sf.Type).Elem(), []string{f.def}); e != nil {
+				return nil, fmt.Errorf("invalid default of parameter %s: %w", f.name, e)
+			}
+		}
+
+		fields = append(fields, f)
+	}
+
+	bindFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+// values returns the values of the parameter within the request, or false if it isn't present.
+func (f *bindField) values(ctx *fasthttp.RequestCtx) ([]string, bool) {
+	var raw [][]byte
+
+	switch f.in {
+	case "path":
+		value, ok := ctx.UserValue(f.name).(string)
+		return []string{value}, ok
+	case "query":
+		raw = ctx.QueryArgs().PeekMulti(f.name)
+	case "header":
+		raw = ctx.Request.Header.PeekAll(f.name)
+	}
+
+	if len(raw) == 0 {
+		return nil, false
+	}
+
+	values := make([]string, 0, len(raw))
+	for _, value := range raw {
+		values = append(values, string(value))
+	}
+
+	return values, true
+}
+
+// set parses the values of the parameter into the field, using the last value for fields that
+// aren't slices.
+func (f *bindField) set(v reflect.Value, values []string) error {
+	if !f.repeated {
+		return f.parse(v, values[len(values)-1])
+	}
+
+	items := reflect.MakeSlice(v.Type(), 0, len(values))
+	for _, value := range splitParamValues(values) {
+		item := reflect.New(f.elem).Elem()
+		if e := f.parse(item, value); e != nil {
+			return e
+		}
+
+		items = reflect.Append(items, item)
+	}
+
+	v.Set(items)
+	return nil
+}
+
+func (f *bindField) parse(v reflect.Value, value string) error {
+	if len(f.enum) > 0 && !slices.Contains(f.enum, value) {
+		return fmt.Errorf("must be one of %s", strings.Join(f.enum, ", "))
+	}
+
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if e := parseBindValue(elem.Elem(), value); e != nil {
+			return e
+		}
+
+		v.Set(elem)
+		return nil
+	}
+
+	return parseBindValue(v, value)
+}
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	timeType            = reflect.TypeFor[time.Time]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	protoEnumType       = reflect.TypeFor[protoreflect.Enum]()
+)
+
+// parseBindValue parses a single parameter value into v, returning an error describing the values
+// accepted if it can't be parsed.
+func parseBindValue(v reflect.Value, value string) error {
+	invalid := errors.New("must be " + bindParamDescription(v.Type()))
+
+	switch t := v.Type(); {
+	case t == durationType:
+		d, e := time.ParseDuration(value)
+		if e != nil {
+			return invalid
+		}
+
+		v.SetInt(int64(d))
+	case t.Implements(protoEnumType):
+		ed := v.Interface().(protoreflect.Enum).Descriptor()
+		n, ok := parseEnumParam(ed, value)
+		if !ok {
+			return invalid
+		}
+
+		v.SetInt(int64(n))
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		if v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)) != nil {
+			return invalid
+		}
+	default:
+		switch t.Kind() {
+		case reflect.String:
+			v.SetString(value)
+		case reflect.Bool:
+			b, e := strconv.ParseBool(value)
+			if e != nil {
+				return invalid
+			}
+
+			v.SetBool(b)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, e := strconv.ParseInt(value, 10, t.Bits())
+			if e != nil {
+				return invalid
+			}
+
+			v.SetInt(i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u, e := strconv.ParseUint(value, 10, t.Bits())
+			if e != nil {
+				return invalid
+			}
+
+			v.SetUint(u)
+		case reflect.Float32, reflect.Float64:
+			f, e := strconv.ParseFloat(value, t.Bits())
+			if e != nil {
+				return invalid
+			}
+
+			v.SetFloat(f)
+		}
+	}
+
+	return nil
+}
+
+// bindParamDescription describes the values accepted for a type, ie "a 32 bit integer", or returns
+// an empty string if values of the type can't be parsed from parameters.
+func bindParamDescription(t reflect.Type) string {
+	switch {
+	case t == durationType:
+		return "a duration (ie 1m30s)"
+	case t == timeType:
+		return "an RFC 3339 time"
+	case t.Implements(protoEnumType):
+		ed := reflect.Zero(t).Interface().(protoreflect.Enum).Descriptor()
+		return "one of the values of " + string(ed.Name())
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return "a valid " + t.Name()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("a %d bit integer", t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("an unsigned %d bit integer", t.Bits())
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	default:
+		return ""
+	}
+}
+
+// bindParamType returns the swagger type and format of parameters of a type, along with the
+// names of its values if it is an enum.
+func bindParamType(t reflect.Type) (string, string, []any) {
+	switch {
+	case t == durationType:
+		return "string", "duration", nil
+	case t == timeType:
+		return "string", "date-time", nil
+	case t.Implements(protoEnumType):
+		values := reflect.Zero(t).Interface().(protoreflect.Enum).Descriptor().Values()
+		enum := make([]any, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			enum = append(enum, string(values.Get(i).Name()))
+		}
+
+		return "string", "", enum
+	case reflect.PointerTo(t).Implements(textUnmarshalerType):
+		return "string", "", nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean", "", nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "integer", "int32", nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "integer", "int64", nil
+	case reflect.Float32:
+		return "number", "float", nil
+	case reflect.Float64:
+		return "number", "double", nil
+	default:
+		return "string", "", nil
+	}
+}
+
+// bindParamDefault returns the default of a parameter as a value of its swagger type.
+func bindParamDefault(typ, def string, repeated bool) any {
+	if repeated {
+		return def
+	}
+
+	switch typ {
+	case "boolean":
+		if b, e := strconv.ParseBool(def); e == nil {
+			return b
+		}
+	case "integer":
+		if i, e := strconv.ParseInt(def, 10, 64); e == nil {
+			return i
+		}
+
+		if u, e := strconv.ParseUint(def, 10, 64); e == nil {
+			return u
+		}
+	case "number":
+		if f, e := strconv.ParseFloat(def, 64); e == nil {
+			return f
+		}
+	}
+
+	return def
+}
+
+func isUnsigned(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return !t.Implements(protoEnumType)
+	default:
+		return false
+	}
+}
+
+// splitParamValues splits comma separated lists within the values of a repeated parameter, ignoring empty items.
+func splitParamValues(values []string) []string {
+	items := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+
+	return items
+}
+
+// BindParams sets top level scalar, enum and repeated scalar fields of msg, along with timestamp,
+// duration and wrapper fields, from the path parameters set by the router and the query arguments
+// of the request, matching parameters by the field name or its JSON name. Path parameters take
+// precedence over query arguments. Repeated fields are set from every query argument of the same
+// name, each of which may also be a comma separated list. Every parameter that can't be bound is
+// reported together within an *apierror.Error with status 400, see Bind.
+func BindParams(ctx *fasthttp.RequestCtx, msg object.Object) error {
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	var violations []apierror.FieldViolation
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsMap() || fd.Message() != nil && !protoParamMessage(fd.Message()) {
+			continue
+		}
+
+		for _, name := range paramNames(fd) {
+			var values []string
+			if value, ok := ctx.UserValue(name).(string); ok {
+				values = []string{value}
+			} else {
+				for _, value := range ctx.QueryArgs().PeekMulti(name) {
+					values = append(values, string(value))
+				}
+			}
+
+			if len(values) == 0 {
+				continue
+			}
+
+			if e := setParam(m, fd, values); e != nil {
+				violations = append(violations, apierror.FieldViolation{Field: name, Description: e.Error()})
+			}
+
+			break
+		}
+	}
+
+	if len(violations) > 0 {
+		return apierror.BadRequest("invalid request parameters").WithDetails(violations...)
+	}
+
+	return nil
+}
+
+func paramNames(fd protoreflect.FieldDescriptor) []string {
+	if name := string(fd.Name()); name != fd.JSONName() {
+		return []string{name, fd.JSONName()}
+	}
+
+	return []string{fd.JSONName()}
+}
+
+func setParam(m protoreflect.Message, fd protoreflect.FieldDescriptor, values []string) error {
+	if !fd.IsList() {
+		v, e := parseParam(fd, m.NewField(fd), values[len(values)-1])
+		if e != nil {
+			return e
+		}
+
+		m.Set(fd, v)
+		return nil
+	}
+
+	list := m.NewField(fd).List()
+	for _, value := range splitParamValues(values) {
+		v, e := parseParam(fd, list.NewElement(), value)
+		if e != nil {
+			return e
+		}
+
+		list.Append(v)
+	}
+
+	m.Set(fd, protoreflect.ValueOfList(list))
+	return nil
+}
+
+// parseParam parses a parameter into a value of the field's kind, with range checking of the
+// field's bit size. Messages are parsed into empty, which is a new message of the field.
+func parseParam(fd protoreflect.FieldDescriptor, empty protoreflect.Value, value string) (protoreflect.Value, error) {
+	var (
+		v       protoreflect.Value
+		e       error
+		invalid = errors.New("must be " + paramDescription(fd))
+	)
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		var b bool
+		b, e = strconv.ParseBool(value)
+		v = protoreflect.ValueOfBool(b)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var i int64
+		i, e = strconv.ParseInt(value, 10, 32)
+		v = protoreflect.ValueOfInt32(int32(i))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var i int64
+		i, e = strconv.ParseInt(value, 10, 64)
+		v = protoreflect.ValueOfInt64(i)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var u uint64
+		u, e = strconv.ParseUint(value, 10, 32)
+		v = protoreflect.ValueOfUint32(uint32(u))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var u uint64
+		u, e = strconv.ParseUint(value, 10, 64)
+		v = protoreflect.ValueOfUint64(u)
+	case protoreflect.FloatKind:
+		var f float64
+		f, e = strconv.ParseFloat(value, 32)
+		v = protoreflect.ValueOfFloat32(float32(f))
+	case protoreflect.DoubleKind:
+		var f float64
+		f, e = strconv.ParseFloat(value, 64)
+		v = protoreflect.ValueOfFloat64(f)
+	case protoreflect.StringKind:
+		v = protoreflect.ValueOfString(value)
+	case protoreflect.BytesKind:
+		v = protoreflect.ValueOfBytes([]byte(value))
+	case protoreflect.EnumKind:
+		n, ok := parseEnumParam(fd.Enum(), value)
+		if !ok {
+			return protoreflect.Value{}, invalid
+		}
+
+		v = protoreflect.ValueOfEnum(n)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if !parseMessageParam(empty.Message(), value) {
+			return protoreflect.Value{}, invalid
+		}
+
+		v = empty
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+	}
+
+	if e != nil {
+		return protoreflect.Value{}, invalid
+	}
+
+	return v, nil
+}
+
+// parseEnumParam parses the name or number of a value of the enum.
+func parseEnumParam(ed protoreflect.EnumDescriptor, value string) (protoreflect.EnumNumber, bool) {
+	if ev := ed.Values().ByName(protoreflect.Name(value)); ev != nil {
+		return ev.Number(), true
+	}
+
+	i, e := strconv.ParseInt(value, 10, 32)
+	if e != nil || ed.Values().ByNumber(protoreflect.EnumNumber(i)) == nil {
+		return 0, false
+	}
+
+	return protoreflect.EnumNumber(i), true
+}
+
+// protoParamMessage returns whether fields of the message can be bound to parameters, which is true
+// of timestamps, durations and wrappers.
+func protoParamMessage(md protoreflect.MessageDescriptor) bool {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration":
+		return true
+	default:
+		return protoWrapper(md)
+	}
+}
+
+// parseMessageParam parses a timestamp (as RFC 3339), duration (ie 1m30s) or wrapped value into msg.
+func parseMessageParam(msg protoreflect.Message, value string) bool {
+	fields := msg.Descriptor().Fields()
+
+	switch msg.Descriptor().FullName() {
+	case "google.protobuf.Timestamp":
+		t, e := time.Parse(time.RFC3339Nano, value)
+		if e != nil {
+			return false
+		}
+
+		msg.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(t.Unix()))
+		msg.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+	case "google.protobuf.Duration":
+		d, e := time.ParseDuration(value)
+		if e != nil {
+			return false
+		}
+
+		msg.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(int64(d/time.Second)))
+		msg.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(d%time.Second)))
+	default:
+		fd := fields.ByName("value")
+		v, e := parseParam(fd, protoreflect.Value{}, value)
+		if e != nil {
+			return false
+		}
+
+		msg.Set(fd, v)
+	}
+
+	return true
+}
+
+// paramDescription describes the values accepted for parameters bound to the field, see bindParamDescription.
+func paramDescription(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return "a boolean"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "a 32 bit integer"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "a 64 bit integer"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "an unsigned 32 bit integer"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "an unsigned 64 bit integer"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "a number"
+	case protoreflect.EnumKind:
+		return "one of the values of " + string(fd.Enum().Name())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch fd.Message().FullName() {
+		case "google.protobuf.Timestamp":
+			return "an RFC 3339 time"
+		case "google.protobuf.Duration":
+			return "a duration (ie 1m30s)"
+		default:
+			return paramDescription(fd.Message().Fields().ByName("value"))
+		}
+	default:
+		return "a string"
+	}
+}
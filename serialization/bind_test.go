@@ -0,0 +1,194 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+type testBindParams struct {
+	IDs     []int64                                `query:"id"`
+	Limit   uint32                                 `query:"limit" default:"20" description:"The maximum number of objects."`
+	Order   string                                 `query:"order" enum:"asc,desc" default:"asc"`
+	Since   *time.Time                             `query:"since"`
+	Type    descriptorpb.FieldDescriptorProto_Type `query:"type"`
+	Timeout time.Duration                          `header:"X-Timeout" default:"30s"`
+	Name    string                                 `path:"name"`
+	Token   string                                 `header:"X-Token,required"`
+	Ignored string
+}
+
+func newBindCtx(query string, headers map[string]string, path map[string]string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/objects?" + query)
+	for k, v := range headers {
+		ctx.Request.Header.Set(k, v)
+	}
+
+	for k, v := range path {
+		ctx.SetUserValue(k, v)
+	}
+
+	return ctx
+}
+
+func TestBind(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	ctx := newBindCtx("id=1&id=2,3&limit=5&since=2025-01-02T03:04:05Z&type=TYPE_STRING",
+		map[string]string{"X-Token": "secret"}, map[string]string{"name": "widget"})
+
+	got := &testBindParams{Ignored: "untouched"}
+	if e := Bind(ctx, got); e != nil {
+		t.Fatal(e)
+	}
+
+	want := &testBindParams{
+		IDs:     []int64{1, 2, 3},
+		Limit:   5,
+		Order:   "asc",
+		Since:   &since,
+		Type:    descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		Timeout: 30 * time.Second,
+		Name:    "widget",
+		Token:   "secret",
+		Ignored: "untouched",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Bind() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	ctx := newBindCtx("id=1,x&limit=4294967296&limit=-1&order=up&since=yesterday&type=99",
+		map[string]string{"X-Timeout": "soon"}, nil)
+
+	e := Bind(ctx, &testBindParams{})
+	apiErr, ok := apierror.As(e)
+	if !ok || apiErr.Status != http.StatusBadRequest {
+		t.Fatalf("Bind() = %v, want 400", e)
+	}
+
+	want := []apierror.FieldViolation{
+		{Field: "id", Description: "must be a 64 bit integer"},
+		{Field: "limit", Description: "must be an unsigned 32 bit integer"},
+		{Field: "order", Description: "must be one of asc, desc"},
+		{Field: "since", Description: "must be an RFC 3339 time"},
+		{Field: "type", Description: "must be one of the values of Type"},
+		{Field: "X-Timeout", Description: "must be a duration (ie 1m30s)"},
+		{Field: "name", Description: "is required"},
+		{Field: "X-Token", Description: "is required"},
+	}
+
+	if !reflect.DeepEqual(apiErr.Details, want) {
+		t.Errorf("details = %v, want %v", apiErr.Details, want)
+	}
+}
+
+func TestBindInvalidTarget(t *testing.T) {
+	var params struct {
+		Values map[string]string `query:"values"`
+	}
+
+	if e := Bind(&fasthttp.RequestCtx{}, &params); e == nil {
+		t.Errorf("Bind() of an unsupported field type didn't fail")
+	}
+
+	if e := Bind(&fasthttp.RequestCtx{}, testBindParams{}); e == nil {
+		t.Errorf("Bind() of a struct value didn't fail")
+	}
+}
+
+func TestBindParams(t *testing.T) {
+	ctx := newBindCtx("dependency=a,b&dependency=c&public_dependency=1,2&name=from-query", nil, map[string]string{"name": "from-path"})
+
+	got := &descriptorpb.FileDescriptorProto{}
+	if e := BindParams(ctx, got); e != nil {
+		t.Fatal(e)
+	}
+
+	want := &descriptorpb.FileDescriptorProto{
+		Name:             proto.String("from-path"),
+		Dependency:       []string{"a", "b", "c"},
+		PublicDependency: []int32{1, 2},
+	}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("BindParams() = %v, want %v", got, want)
+	}
+
+	ctx = newBindCtx("timestamp=2025-01-02T03:04:05.5Z&code=-1&error=ok", nil, nil)
+	resp := &GenericErrorResponse{}
+	e := BindParams(ctx, resp)
+
+	apiErr, ok := apierror.As(e)
+	if !ok || !reflect.DeepEqual(apiErr.Details, []apierror.FieldViolation{{Field: "code", Description: "must be an unsigned 32 bit integer"}}) {
+		t.Fatalf("BindParams() = %v, want a violation of code", e)
+	}
+
+	if ts := resp.GetTimestamp().AsTime(); !ts.Equal(time.Date(2025, 1, 2, 3, 4, 5, 5e8, time.UTC)) || resp.GetError() != "ok" {
+		t.Errorf("BindParams() = %v", resp)
+	}
+}
+
+func TestNewParameters(t *testing.T) {
+	params := NewParameters(&testBindParams{})
+
+	names := []string{}
+	for _, p := range params {
+		names = append(names, p.In+":"+p.Name)
+	}
+
+	if want := []string{"query:id", "query:limit", "query:order", "query:since", "query:type", "header:X-Timeout", "path:name", "header:X-Token"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("parameters = %v, want %v", names, want)
+	}
+
+	if id := params[0]; id.Type != "array" || id.CollectionFormat != "multi" || id.Items.Type != "integer" || id.Items.Format != "int64" {
+		t.Errorf("id = %s %s of %s %s", id.Type, id.CollectionFormat, id.Items.Type, id.Items.Format)
+	}
+
+	if limit := params[1]; limit.Type != "integer" || limit.Default != int64(20) || *limit.Minimum != 0 || limit.Description != "The maximum number of objects." {
+		t.Errorf("limit = %s default %v", limit.Type, limit.Default)
+	}
+
+	if order := params[2]; !reflect.DeepEqual(order.Enum, []any{"asc", "desc"}) {
+		t.Errorf("order enum = %v", order.Enum)
+	}
+
+	if since := params[3]; since.Type != "string" || since.Format != "date-time" || since.Required {
+		t.Errorf("since = %s %s required %v", since.Type, since.Format, since.Required)
+	}
+
+	if typ := params[4]; len(typ.Enum) != 18 || typ.Enum[0] != "TYPE_DOUBLE" {
+		t.Errorf("type enum = %v", typ.Enum)
+	}
+
+	if !params[6].Required || !params[7].Required {
+		t.Errorf("path and required parameters aren't required")
+	}
+}
@@ -0,0 +1,514 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fire833/go-api-utils/object"
+)
+
+// CBORMediaType is the media type of CBOR, see RFC 8949.
+const CBORMediaType string = "application/cbor"
+
+// CBOR major types.
+const (
+	cborUint byte = iota << 5
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+// CBOR tags of date/time strings and epoch based date/times.
+const (
+	cborTagDateTime uint64 = 0
+	cborTagEpoch    uint64 = 1
+)
+
+// The additional information of indefinite length items and the break stop code ending them.
+const (
+	cborIndefinite byte = 31
+	cborBreak      byte = 0xff
+)
+
+var errCBORBreak = errors.New("cbor: unexpected break")
+
+// NewCBORCodec returns the codec for application/cbor configured with opts. Objects are encoded
+// with the structure of their JSON encoding, with timestamps encoded as tagged date/time strings.
+func NewCBORCodec(opts ProtoJSONOptions) Codec { return cborCodec{opts} }
+
+type cborCodec struct{ opts ProtoJSONOptions }
+
+func (cborCodec) MediaType() string { return CBORMediaType }
+func (cborCodec) Aliases() []string { return nil }
+
+func (c cborCodec) Marshal(obj object.Object) ([]byte, error) {
+	v, e := c.opts.toValue(obj)
+	if e != nil {
+		return nil, e
+	}
+
+	return appendCBOR(nil, v)
+}
+
+func (c cborCodec) Unmarshal(data []byte, obj object.Object) error {
+	return c.UnmarshalLimited(data, obj, DecodeLimits{})
+}
+
+func (c cborCodec) UnmarshalLimited(data []byte, obj object.Object, limits DecodeLimits) error {
+	r := bytes.NewReader(data)
+
+	v, e := readCBOR(newValueReader(r, limits.maxDepth()))
+	if e != nil {
+		return unexpectedEOF(e)
+	}
+
+	if r.Len() > 0 {
+		return fmt.Errorf("cbor: %d unexpected bytes after value", r.Len())
+	}
+
+	opts := c.opts.limited(limits)
+	if e := opts.fromValue(v, obj); e != nil {
+		return valueError(e, v, obj.ProtoReflect().Descriptor(), !opts.DiscardUnknown)
+	}
+
+	return nil
+}
+
+// NewEncoder returns an encoder writing each object as consecutive values, as a CBOR sequence (RFC 8742).
+func (c cborCodec) NewEncoder(w io.Writer) Encoder { return cborEncoder{c.opts, w} }
+
+func (c cborCodec) NewDecoder(r io.Reader) Decoder { return cborDecoder{c.opts, bufio.NewReader(r)} }
+
+type cborEncoder struct {
+	opts ProtoJSONOptions
+	w    io.Writer
+}
+
+func (e cborEncoder) Encode(obj object.Object) error {
+	v, err := e.opts.toValue(obj)
+	if err != nil {
+		return err
+	}
+
+	data, err := appendCBOR(nil, v)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(data)
+	return err
+}
+
+type cborDecoder struct {
+	opts ProtoJSONOptions
+	r    *bufio.Reader
+}
+
+func (d cborDecoder) Decode(obj object.Object) error {
+	// Only the end of the stream between values is a clean EOF.
+	if _, e := d.r.Peek(1); e != nil {
+		return e
+	}
+
+	v, e := readCBOR(newValueReader(d.r, 0))
+	if e != nil {
+		return unexpectedEOF(e)
+	}
+
+	return d.opts.fromValue(v, obj)
+}
+
+func appendCBOR(b []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, cborSimple|22), nil
+	case bool:
+		if v {
+			return append(b, cborSimple|21), nil
+		}
+
+		return append(b, cborSimple|20), nil
+	case int64:
+		if v < 0 {
+			return appendCBORHead(b, cborNegInt, uint64(-(v + 1))), nil
+		}
+
+		return appendCBORHead(b, cborUint, uint64(v)), nil
+	case uint64:
+		return appendCBORHead(b, cborUint, v), nil
+	case float32:
+		return binary.BigEndian.AppendUint32(append(b, cborSimple|26), math.Float32bits(v)), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(append(b, cborSimple|27), math.Float64bits(v)), nil
+	case string:
+		return append(appendCBORHead(b, cborText, uint64(len(v))), v...), nil
+	case []byte:
+		return append(appendCBORHead(b, cborBytes, uint64(len(v))), v...), nil
+	case time.Time:
+		b = appendCBORHead(b, cborTag, cborTagDateTime)
+		return appendCBOR(b, v.UTC().Format(time.RFC3339Nano))
+	case []any:
+		b = appendCBORHead(b, cborArray, uint64(len(v)))
+
+		for _, item := range v {
+			var e error
+			if b, e = appendCBOR(b, item); e != nil {
+				return nil, e
+			}
+		}
+
+		return b, nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		m := make(valueMap, 0, len(keys))
+		for _, k := range keys {
+			m = append(m, valueMapEntry{k, v[k]})
+		}
+
+		return appendCBOR(b, m)
+	case valueMap:
+		b = appendCBORHead(b, cborMap, uint64(len(v)))
+
+		for _, entry := range v {
+			var e error
+			if b, e = appendCBOR(b, entry.Key); e != nil {
+				return nil, e
+			}
+
+			if b, e = appendCBOR(b, entry.Value); e != nil {
+				return nil, e
+			}
+		}
+
+		return b, nil
+	default:
+		return nil, fmt.Errorf("cbor: unsupported value of type %T", v)
+	}
+}
+
+// appendCBORHead appends the head of an item of the major type with the argument n.
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), n)
+	}
+}
+
+// readCBOR decodes a single data item, with maps decoded as map[string]any. Date/time strings and
+// epoch based date/times are decoded as time.Time, and any other tags are ignored.
+func readCBOR(r *valueReader) (any, error) {
+	c, e := r.ReadByte()
+	if e != nil {
+		return nil, e
+	}
+
+	if c == cborBreak {
+		return nil, errCBORBreak
+	}
+
+	major, info := c&0xe0, c&0x1f
+
+	if major == cborSimple {
+		return readCBORSimple(r, info)
+	}
+
+	// Arrays, maps, tags and indefinite length strings all contain further items.
+	if major == cborArray || major == cborMap || major == cborTag || info == cborIndefinite {
+		if e := r.nest(); e != nil {
+			return nil, e
+		}
+		defer r.unnest()
+	}
+
+	if info == cborIndefinite {
+		return readCBORIndefinite(r, major)
+	}
+
+	var n uint64
+	switch {
+	case info < 24:
+		n = uint64(info)
+	case info <= 27:
+		if n, e = readUint(r, 1<<(info-24)); e != nil {
+			return nil, e
+		}
+	default:
+		return nil, fmt.Errorf("cbor: invalid additional information %d", info)
+	}
+
+	switch major {
+	case cborUint:
+		return n, nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: negative integer -1-%d overflows int64", n)
+		}
+
+		return -1 - int64(n), nil
+	case cborBytes:
+		return readBytes(r, n)
+	case cborText:
+		b, e := readBytes(r, n)
+		return string(b), e
+	case cborArray:
+		out := make([]any, 0, min(n, 1024))
+
+		for i := uint64(0); i < n; i++ {
+			v, e := readCBOR(r)
+			if e != nil {
+				return nil, e
+			}
+
+			out = append(out, v)
+		}
+
+		return out, nil
+	case cborMap:
+		out := make(map[string]any, min(n, 1024))
+
+		for i := uint64(0); i < n; i++ {
+			if e := readCBORMapEntry(r, out); e != nil {
+				return nil, e
+			}
+		}
+
+		return out, nil
+	default:
+		return readCBORTag(r, n)
+	}
+}
+
+func readCBORSimple(r *valueReader, info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		// null and undefined.
+		return nil, nil
+	case 25:
+		u, e := readUint(r, 2)
+		return halfToFloat64(uint16(u)), e
+	case 26:
+		u, e := readUint(r, 4)
+		return float64(math.Float32frombits(uint32(u))), e
+	case 27:
+		u, e := readUint(r, 8)
+		return math.Float64frombits(u), e
+	default:
+		return nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+	}
+}
+
+// halfToFloat64 converts an IEEE 754 half precision float.
+func halfToFloat64(h uint16) float64 {
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		f = math.Inf(1)
+		if mant != 0 {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+
+	if h&0x8000 != 0 {
+		return -f
+	}
+
+	return f
+}
+
+// readCBORIndefinite decodes an indefinite length item of the major type, up to its break.
+func readCBORIndefinite(r *valueReader, major byte) (any, error) {
+	switch major {
+	case cborBytes, cborText:
+		buf := []byte{}
+
+		for {
+			chunk, e := readCBOR(r)
+			if errors.Is(e, errCBORBreak) {
+				break
+			} else if e != nil {
+				return nil, e
+			}
+
+			switch chunk := chunk.(type) {
+			case []byte:
+				if major != cborBytes {
+					return nil, errors.New("cbor: indefinite length text containing bytes")
+				}
+
+				buf = append(buf, chunk...)
+			case string:
+				if major != cborText {
+					return nil, errors.New("cbor: indefinite length bytes containing text")
+				}
+
+				buf = append(buf, chunk...)
+			default:
+				return nil, fmt.Errorf("cbor: indefinite length string containing %T", chunk)
+			}
+		}
+
+		if major == cborText {
+			return string(buf), nil
+		}
+
+		return buf, nil
+	case cborArray:
+		out := []any{}
+
+		for {
+			v, e := readCBOR(r)
+			if errors.Is(e, errCBORBreak) {
+				return out, nil
+			} else if e != nil {
+				return nil, e
+			}
+
+			out = append(out, v)
+		}
+	case cborMap:
+		out := map[string]any{}
+
+		for {
+			if e := readCBORMapEntry(r, out); errors.Is(e, errCBORBreak) {
+				return out, nil
+			} else if e != nil {
+				return nil, e
+			}
+		}
+	default:
+		return nil, fmt.Errorf("cbor: invalid indefinite length major type %d", major>>5)
+	}
+}
+
+func readCBORMapEntry(r *valueReader, out map[string]any) error {
+	k, e := readCBOR(r)
+	if e != nil {
+		return e
+	}
+
+	key, ok := k.(string)
+	if !ok {
+		return fmt.Errorf("cbor: map key of type %T is not a string", k)
+	}
+
+	v, e := readCBOR(r)
+	if errors.Is(e, errCBORBreak) {
+		return errors.New("cbor: map key without a value")
+	}
+
+	out[key] = v
+	return e
+}
+
+func readCBORTag(r *valueReader, tag uint64) (any, error) {
+	v, e := readCBOR(r)
+	if e != nil {
+		return nil, e
+	}
+
+	switch tag {
+	case cborTagDateTime:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("cbor: date/time of type %T is not a string", v)
+		}
+
+		t, e := time.Parse(time.RFC3339Nano, s)
+		if e != nil {
+			return nil, e
+		}
+
+		// Offsets can move date/times out of range once converted to UTC.
+		return cborEpochTime(t.Unix(), int64(t.Nanosecond()))
+	case cborTagEpoch:
+		switch v := v.(type) {
+		case uint64:
+			if v > math.MaxInt64 {
+				return nil, fmt.Errorf("cbor: epoch date/time %d overflows", v)
+			}
+
+			return cborEpochTime(int64(v), 0)
+		case int64:
+			return cborEpochTime(v, 0)
+		case float64:
+			if math.IsNaN(v) || math.IsInf(v, 0) || math.Abs(v) > math.MaxInt64 {
+				return nil, fmt.Errorf("cbor: epoch date/time %g overflows", v)
+			}
+
+			sec, frac := math.Modf(v)
+			return cborEpochTime(int64(sec), int64(frac*1e9))
+		default:
+			return nil, fmt.Errorf("cbor: epoch date/time of type %T is not a number", v)
+		}
+	default:
+		return v, nil
+	}
+}
+
+// Range of epoch date/times that can be represented as RFC 3339 date/times, which is what they are
+// encoded as, from 0000-01-01T00:00:00Z to 9999-12-31T23:59:59Z.
+const (
+	cborMinEpoch int64 = -62167219200
+	cborMaxEpoch int64 = 253402300799
+)
+
+func cborEpochTime(sec, nsec int64) (any, error) {
+	if sec < cborMinEpoch || sec > cborMaxEpoch || sec == cborMinEpoch && nsec < 0 {
+		return nil, fmt.Errorf("cbor: epoch date/time %d is out of range", sec)
+	}
+
+	return time.Unix(sec, nsec).UTC(), nil
+}
@@ -0,0 +1,272 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/fire833/go-api-utils/object"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+// Media types of the codecs registered out of the box.
+const (
+	JSONMediaType     string = "application/json"
+	YAMLMediaType     string = "application/yaml"
+	XMLMediaType      string = "application/xml"
+	TOMLMediaType     string = "application/toml"
+	ProtobufMediaType string = "application/protobuf"
+)
+
+// Codec encodes and decodes objects with a single media type.
+type Codec interface {
+	// MediaType returns the canonical media type of the codec, ie application/json.
+	MediaType() string
+
+	// Aliases returns alternative names of the media type that clients may use, ie text/json.
+	Aliases() []string
+
+	Marshal(obj object.Object) ([]byte, error)
+	Unmarshal(data []byte, obj object.Object) error
+
+	// NewEncoder returns an encoder writing a stream of objects to w.
+	NewEncoder(w io.Writer) Encoder
+
+	// NewDecoder returns a decoder reading a stream of objects from r.
+	NewDecoder(r io.Reader) Decoder
+}
+
+// Encoder writes a stream of objects.
+type Encoder interface {
+	Encode(obj object.Object) error
+}
+
+// Decoder reads a stream of objects, returning io.EOF once the stream is exhausted.
+type Decoder interface {
+	Decode(obj object.Object) error
+}
+
+var (
+	codecsLock sync.RWMutex
+
+	// Registered codecs, in order of preference when clients accept several of them.
+	codecs []Codec
+
+	// Registered codecs by their media types and aliases.
+	codecsByMediaType = map[string]Codec{}
+)
+
+func init() {
+	RegisterCodec(NewJSONCodec(ProtoJSONOptions{DiscardUnknown: true}))
+	RegisterCodec(NewYAMLCodec(ProtoJSONOptions{DiscardUnknown: true}))
+	RegisterCodec(xmlCodec{})
+	RegisterCodec(tomlCodec{})
+	RegisterCodec(protobufCodec{})
+	RegisterCodec(NewMsgPackCodec(ProtoJSONOptions{DiscardUnknown: true}))
+	RegisterCodec(NewCBORCodec(ProtoJSONOptions{DiscardUnknown: true}))
+}
+
+// RegisterCodec registers a codec, so that request bodies can be decoded from and responses encoded
+// with its media type. Registering a codec for a media type that already has one replaces it, keeping
+// its order of preference.
+func RegisterCodec(c Codec) {
+	codecsLock.Lock()
+	defer codecsLock.Unlock()
+
+	mediaType := strings.ToLower(c.MediaType())
+	if existing, ok := codecsByMediaType[mediaType]; ok {
+		for i := range codecs {
+			if codecs[i] == existing {
+				codecs[i] = c
+			}
+		}
+
+		for _, alias := range existing.Aliases() {
+			delete(codecsByMediaType, strings.ToLower(alias))
+		}
+	} else {
+		codecs = append(codecs, c)
+	}
+
+	codecsByMediaType[mediaType] = c
+	for _, alias := range c.Aliases() {
+		if alias = strings.ToLower(alias); alias != mediaType {
+			if _, ok := codecsByMediaType[alias]; !ok {
+				codecsByMediaType[alias] = c
+			}
+		}
+	}
+}
+
+// CodecFor returns the codec registered for a media type or one of its aliases, or false if there is none.
+// Parameters of the media type (ie charset) are ignored.
+func CodecFor(mediaType string) (Codec, bool) {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+
+	codecsLock.RLock()
+	defer codecsLock.RUnlock()
+
+	c, ok := codecsByMediaType[strings.ToLower(strings.TrimSpace(mediaType))]
+	return c, ok
+}
+
+// MediaTypes returns the media types of all registered codecs, in order of preference.
+func MediaTypes() []string {
+	codecsLock.RLock()
+	defer codecsLock.RUnlock()
+
+	types := make([]string, 0, len(codecs))
+	for _, c := range codecs {
+		types = append(types, c.MediaType())
+	}
+
+	return types
+}
+
+type xmlCodec struct{}
+
+func (xmlCodec) MediaType() string { return XMLMediaType }
+func (xmlCodec) Aliases() []string { return []string{"text/xml"} }
+
+func (xmlCodec) Marshal(obj object.Object) ([]byte, error) { return xml.Marshal(&obj) }
+
+// Unmarshal decodes data into obj, rejecting documents with document type definitions, so that
+// documents can't declare entities.
+func (c xmlCodec) Unmarshal(data []byte, obj object.Object) error {
+	return c.UnmarshalLimited(data, obj, DecodeLimits{})
+}
+
+func (xmlCodec) UnmarshalLimited(data []byte, obj object.Object, limits DecodeLimits) error {
+	if e := checkXML(data, limits.maxDepth()); e != nil {
+		return e
+	}
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = true
+	return d.Decode(obj)
+}
+
+// NewEncoder returns an encoder writing each object as a sibling element.
+func (xmlCodec) NewEncoder(w io.Writer) Encoder { return xmlEncoder{xml.NewEncoder(w)} }
+
+func (xmlCodec) NewDecoder(r io.Reader) Decoder { return xmlDecoder{xml.NewDecoder(r)} }
+
+type xmlEncoder struct{ *xml.Encoder }
+
+func (e xmlEncoder) Encode(obj object.Object) error { return e.Encoder.Encode(&obj) }
+
+type xmlDecoder struct{ *xml.Decoder }
+
+func (d xmlDecoder) Decode(obj object.Object) error { return d.Decoder.Decode(obj) }
+
+type tomlCodec struct{}
+
+func (tomlCodec) MediaType() string { return TOMLMediaType }
+func (tomlCodec) Aliases() []string { return []string{"application/x-toml"} }
+
+func (tomlCodec) Marshal(obj object.Object) ([]byte, error) { return toml.Marshal(obj) }
+
+func (tomlCodec) Unmarshal(data []byte, obj object.Object) error { return toml.Unmarshal(data, obj) }
+
+func (tomlCodec) UnmarshalLimited(data []byte, obj object.Object, limits DecodeLimits) error {
+	md, e := toml.Decode(string(data), obj)
+	if e != nil {
+		return e
+	}
+
+	if undecoded := md.Undecoded(); limits.DisallowUnknownFields && len(undecoded) > 0 {
+		return &DecodeError{Field: undecoded[0].String(), Description: "unknown field"}
+	}
+
+	return nil
+}
+
+// NewEncoder returns an encoder writing each object as a separate table. Since TOML has no notion
+// of multiple documents, streams of objects can't be decoded again.
+func (tomlCodec) NewEncoder(w io.Writer) Encoder { return tomlEncoder{w} }
+
+// NewDecoder returns a decoder reading a single object from r.
+func (tomlCodec) NewDecoder(r io.Reader) Decoder { return &tomlDecoder{r: r} }
+
+type tomlEncoder struct{ w io.Writer }
+
+func (e tomlEncoder) Encode(obj object.Object) error { return toml.NewEncoder(e.w).Encode(obj) }
+
+type tomlDecoder struct {
+	r    io.Reader
+	done bool
+}
+
+func (d *tomlDecoder) Decode(obj object.Object) error {
+	if d.done {
+		return io.EOF
+	}
+
+	d.done = true
+	_, e := toml.NewDecoder(d.r).Decode(obj)
+	return e
+}
+
+type protobufCodec struct{}
+
+func (protobufCodec) MediaType() string { return ProtobufMediaType }
+func (protobufCodec) Aliases() []string {
+	return []string{"application/x-protobuf", "application/api+protobuf"}
+}
+
+func (protobufCodec) Marshal(obj object.Object) ([]byte, error) { return proto.Marshal(obj) }
+
+func (protobufCodec) Unmarshal(data []byte, obj object.Object) error {
+	return proto.Unmarshal(data, obj)
+}
+
+func (protobufCodec) UnmarshalLimited(data []byte, obj object.Object, limits DecodeLimits) error {
+	if e := (proto.UnmarshalOptions{RecursionLimit: limits.maxDepth()}).Unmarshal(data, obj); e != nil {
+		return e
+	}
+
+	if limits.DisallowUnknownFields {
+		return unknownFieldError(obj.ProtoReflect(), "")
+	}
+
+	return nil
+}
+
+// NewEncoder returns an encoder writing each object prefixed with its varint encoded length.
+func (protobufCodec) NewEncoder(w io.Writer) Encoder { return protobufEncoder{w} }
+
+func (protobufCodec) NewDecoder(r io.Reader) Decoder { return protobufDecoder{bufio.NewReader(r)} }
+
+type protobufEncoder struct{ w io.Writer }
+
+func (e protobufEncoder) Encode(obj object.Object) error {
+	_, err := protodelim.MarshalTo(e.w, obj)
+	return err
+}
+
+type protobufDecoder struct{ r *bufio.Reader }
+
+func (d protobufDecoder) Decode(obj object.Object) error { return protodelim.UnmarshalFrom(d.r, obj) }
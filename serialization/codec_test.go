@@ -0,0 +1,133 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/object"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCodecs(t *testing.T) {
+	for _, mediaType := range []string{JSONMediaType, YAMLMediaType, XMLMediaType, TOMLMediaType, ProtobufMediaType, MsgPackMediaType, CBORMediaType} {
+		c, _ := CodecFor(mediaType)
+
+		t.Run(mediaType, func(t *testing.T) {
+			in := &OKResponse{Message: "hello", Code: 200}
+
+			data, e := c.Marshal(in)
+			if e != nil {
+				t.Fatal(e)
+			}
+
+			out := &OKResponse{}
+			if e := c.Unmarshal(data, out); e != nil {
+				t.Fatalf("Unmarshal(%s) = %v", data, e)
+			}
+
+			if !proto.Equal(in, out) {
+				t.Errorf("round trip = %v, want %v", out, in)
+			}
+		})
+	}
+}
+
+func TestCodecStreams(t *testing.T) {
+	// TOML has no notion of multiple documents, so can't be streamed.
+	for _, mediaType := range []string{JSONMediaType, YAMLMediaType, XMLMediaType, ProtobufMediaType, MsgPackMediaType, CBORMediaType} {
+		c, _ := CodecFor(mediaType)
+
+		t.Run(mediaType, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			enc := c.NewEncoder(buf)
+
+			for _, msg := range []string{"first", "second", "third"} {
+				if e := enc.Encode(&OKResponse{Message: msg}); e != nil {
+					t.Fatal(e)
+				}
+			}
+
+			dec := c.NewDecoder(buf)
+			for _, msg := range []string{"first", "second", "third"} {
+				out := &OKResponse{}
+				if e := dec.Decode(out); e != nil || out.Message != msg {
+					t.Fatalf("Decode() = %v, %q, want %q", e, out.Message, msg)
+				}
+			}
+
+			if e := dec.Decode(&OKResponse{}); !errors.Is(e, io.EOF) {
+				t.Errorf("Decode() = %v at end of stream, want EOF", e)
+			}
+		})
+	}
+}
+
+func TestCodecFor(t *testing.T) {
+	for mediaType, want := range map[string]string{
+		"application/json":                JSONMediaType,
+		"Application/JSON; charset=utf-8": JSONMediaType,
+		"application/API+Protobuf":        ProtobufMediaType,
+		"text/yaml":                       YAMLMediaType,
+		"application/octet-stream":        "",
+	} {
+		c, ok := CodecFor(mediaType)
+		if ok != (want != "") || (ok && c.MediaType() != want) {
+			t.Errorf("CodecFor(%q) = %v, %v, want %s", mediaType, c, ok, want)
+		}
+	}
+}
+
+// testCodec is a codec for a media type that isn't registered by default, which doesn't
+// implement LimitedUnmarshaler.
+type testCodec struct{ Codec }
+
+func (testCodec) MediaType() string { return "application/x-test" }
+func (testCodec) Aliases() []string { return []string{"text/x-test"} }
+
+func (testCodec) Marshal(object.Object) ([]byte, error) { return []byte("test"), nil }
+
+func (testCodec) Unmarshal([]byte, object.Object) error { return errors.New("test decoding") }
+
+func TestRegisterCodec(t *testing.T) {
+	RegisterCodec(testCodec{NewJSONCodec(ProtoJSONOptions{})})
+
+	if types := MediaTypes(); types[len(types)-1] != "application/x-test" {
+		t.Errorf("MediaTypes() = %v, want application/x-test last", types)
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.Set(fasthttp.HeaderAccept, "text/x-test")
+	ctx.Request.Header.SetContentType("application/x-test")
+
+	if e := MarshalBodyByAcceptHeader(ctx, &OKResponse{}); e != nil || string(ctx.Response.Body()) != "test" ||
+		string(ctx.Response.Header.ContentType()) != "application/x-test" {
+		t.Errorf("MarshalBodyByAcceptHeader() = %v, %s %s", e, ctx.Response.Header.ContentType(), ctx.Response.Body())
+	}
+
+	if e := UnmarshalBodyByContentHeader(ctx, &OKResponse{}); apierror.StatusOf(e) != 400 || !strings.Contains(e.Error(), "test decoding") {
+		t.Errorf("UnmarshalBodyByContentHeader() = %v, want 400 test decoding", e)
+	}
+}
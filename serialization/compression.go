@@ -0,0 +1,292 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/andybalholm/brotli"
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/klauspost/compress/zstd"
+	"github.com/valyala/fasthttp"
+)
+
+// Content codings that responses are compressed with and request bodies decompressed from.
+const (
+	GzipEncoding    string = "gzip"
+	DeflateEncoding string = "deflate"
+	BrotliEncoding  string = "br"
+	ZstdEncoding    string = "zstd"
+)
+
+// Supported content codings, in order of preference when clients accept several of them equally.
+var contentEncodings []string = []string{BrotliEncoding, ZstdEncoding, GzipEncoding, DeflateEncoding}
+
+// CompressionOptions configures CompressionHandler.
+type CompressionOptions struct {
+	// Responses with smaller bodies aren't compressed, as compressing them costs more than it saves.
+	MinSize int
+
+	// Media types of responses that are compressed, which may contain wildcards (ie text/* or
+	// application/*+json). Responses of any other media type are left uncompressed.
+	ContentTypes []string
+
+	// Compression levels of each encoding, see the fasthttp Compress* constants.
+	GzipLevel    int
+	DeflateLevel int
+	BrotliLevel  int
+	ZstdLevel    int
+
+	// The maximum size of request bodies once decompressed, or 0 for no limit. Compressed request
+	// bodies that are larger are rejected with 413, so that small bodies can't decompress into
+	// exhausting the memory of the process.
+	MaxDecompressedSize int64
+}
+
+// DefaultCompressionOptions returns the options CompressionHandler uses until SetCompressionOptions is called.
+func DefaultCompressionOptions() CompressionOptions {
+	return CompressionOptions{
+		MinSize: 1024,
+		ContentTypes: []string{
+			JSONMediaType, "application/*+json", YAMLMediaType, XMLMediaType, "application/*+xml",
+			TOMLMediaType, "application/x-ndjson", "text/*",
+		},
+		GzipLevel:           fasthttp.CompressDefaultCompression,
+		DeflateLevel:        fasthttp.CompressDefaultCompression,
+		BrotliLevel:         fasthttp.CompressBrotliDefaultCompression,
+		ZstdLevel:           fasthttp.CompressZstdDefault,
+		MaxDecompressedSize: 32 << 20,
+	}
+}
+
+var compressionOptions atomic.Pointer[CompressionOptions]
+
+func init() {
+	SetCompressionOptions(DefaultCompressionOptions())
+}
+
+// SetCompressionOptions replaces the options of every CompressionHandler.
+func SetCompressionOptions(opts CompressionOptions) {
+	compressionOptions.Store(&opts)
+}
+
+// CompressionHandler wraps next to decompress request bodies according to their Content-Encoding,
+// and compress responses with the encoding most preferred by the client according to its
+// Accept-Encoding header.
+//
+// Requests with an unsupported encoding are rejected with 415, and requests that can't be
+// decompressed with 400. Responses are only compressed if their media type is one of the
+// configured content types and their body is at least the configured minimum size. Streamed
+// responses and responses that are already encoded are never compressed.
+func CompressionHandler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		opts := compressionOptions.Load()
+
+		if e := decompressRequest(ctx, opts); e != nil {
+			if e.Status == fasthttp.StatusUnsupportedMediaType {
+				ctx.Response.Header.Set(fasthttp.HeaderAcceptEncoding, strings.Join(contentEncodings, ", "))
+			}
+
+			APIErrorResponseHandler(ctx, e)
+			return
+		}
+
+		next(ctx)
+		compressResponse(ctx, opts)
+	}
+}
+
+// decompressRequest replaces the request body with its decompressed body, decoding each of the
+// encodings it lists in the reverse order they were applied.
+func decompressRequest(ctx *fasthttp.RequestCtx, opts *CompressionOptions) *apierror.Error {
+	header := ctx.Request.Header.ContentEncoding()
+	if len(bytes.TrimSpace(header)) == 0 {
+		return nil
+	}
+
+	encodings := strings.Split(string(header), ",")
+	body := ctx.Request.Body()
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		encoding := strings.ToLower(strings.TrimSpace(encodings[i]))
+		if encoding == "identity" {
+			continue
+		}
+
+		var e *apierror.Error
+		if body, e = decompress(encoding, body, opts.MaxDecompressedSize); e != nil {
+			return e
+		}
+	}
+
+	ctx.Request.SetBodyRaw(body)
+	ctx.Request.Header.Del(fasthttp.HeaderContentEncoding)
+	ctx.Request.Header.SetContentLength(len(body))
+	return nil
+}
+
+// decompress decodes data with the encoding, with the decoded data limited to limit bytes if it isn't 0.
+func decompress(encoding string, data []byte, limit int64) ([]byte, *apierror.Error) {
+	var (
+		r io.Reader
+		e error
+	)
+
+	switch encoding {
+	case GzipEncoding, "x-gzip":
+		r, e = gzip.NewReader(bytes.NewReader(data))
+	case DeflateEncoding:
+		r, e = zlib.NewReader(bytes.NewReader(data))
+	case BrotliEncoding:
+		r = brotli.NewReader(bytes.NewReader(data))
+	case ZstdEncoding:
+		var dec *zstd.Decoder
+		if dec, e = zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderConcurrency(1)); e == nil {
+			defer dec.Close()
+			r = dec
+		}
+	default:
+		return nil, apierror.UnsupportedMediaType(fmt.Sprintf("content encoding %s is not supported", encoding))
+	}
+
+	if e != nil {
+		return nil, apierror.BadRequest("unable to decompress request body").WithCause(e)
+	}
+
+	if limit > 0 {
+		r = io.LimitReader(r, limit+1)
+	}
+
+	out, e := io.ReadAll(r)
+	if e != nil {
+		return nil, apierror.BadRequest("unable to decompress request body").WithCause(e)
+	}
+
+	if limit > 0 && int64(len(out)) > limit {
+		return nil, apierror.RequestEntityTooLarge(fmt.Sprintf("request body exceeds %d bytes once decompressed", limit))
+	}
+
+	return out, nil
+}
+
+func compressResponse(ctx *fasthttp.RequestCtx, opts *CompressionOptions) {
+	resp := &ctx.Response
+
+	switch {
+	case ctx.IsHead(), resp.IsBodyStream(), len(resp.Header.ContentEncoding()) > 0,
+		resp.StatusCode() == fasthttp.StatusNoContent, resp.StatusCode() == fasthttp.StatusNotModified,
+		!opts.compressible(string(resp.Header.ContentType())):
+		return
+	}
+
+	// Whether the response is compressed depends on the request from here on, regardless of whether it is.
+	addVary(ctx, fasthttp.HeaderAcceptEncoding)
+
+	body := resp.Body()
+	if len(body) < opts.MinSize {
+		return
+	}
+
+	encoding, ok := negotiateEncoding(ctx.Request.Header.Peek(fasthttp.HeaderAcceptEncoding))
+	if !ok {
+		return
+	}
+
+	var out []byte
+	switch encoding {
+	case GzipEncoding:
+		out = fasthttp.AppendGzipBytesLevel(nil, body, opts.GzipLevel)
+	case DeflateEncoding:
+		out = fasthttp.AppendDeflateBytesLevel(nil, body, opts.DeflateLevel)
+	case BrotliEncoding:
+		out = fasthttp.AppendBrotliBytesLevel(nil, body, opts.BrotliLevel)
+	case ZstdEncoding:
+		out = fasthttp.AppendZstdBytesLevel(nil, body, opts.ZstdLevel)
+	}
+
+	resp.SetBodyRaw(out)
+	resp.Header.SetContentEncoding(encoding)
+}
+
+// compressible returns whether responses of the media type are compressed.
+func (o *CompressionOptions) compressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	for _, pattern := range o.ContentTypes {
+		if ok, _ := path.Match(strings.ToLower(pattern), mediaType); ok && mediaType != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// negotiateEncoding returns the supported encoding most preferred by the client according to its
+// Accept-Encoding header, or false if the client doesn't accept any of them. The quality of each
+// encoding is that it is listed with, or that of * if it isn't listed.
+func negotiateEncoding(accept []byte) (string, bool) {
+	qualities := map[string]float64{}
+
+	for _, part := range strings.Split(string(accept), ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(strings.TrimSpace(key), "q") {
+				if parsed, e := strconv.ParseFloat(strings.TrimSpace(value), 64); e == nil && parsed >= 0 && parsed <= 1 {
+					q = parsed
+				}
+			}
+		}
+
+		if coding == "x-gzip" {
+			coding = GzipEncoding
+		}
+
+		qualities[coding] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range contentEncodings {
+		q, ok := qualities[encoding]
+		if !ok {
+			q = qualities["*"]
+		}
+
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+
+	return best, best != ""
+}
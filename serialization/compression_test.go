@@ -0,0 +1,161 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", GzipEncoding},
+		{"x-gzip", GzipEncoding},
+		{"gzip, deflate, br, zstd", BrotliEncoding},
+		{"gzip;q=1.0, br;q=0.5", GzipEncoding},
+		{"GZIP;Q=0.2, deflate;q=0.3", DeflateEncoding},
+		{"*", BrotliEncoding},
+		{"*;q=0.5, br;q=0, zstd;q=0", GzipEncoding},
+		{"gzip;q=0", ""},
+		{"compress", ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := negotiateEncoding([]byte(tt.accept))
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("negotiateEncoding(%q) = %q, %v, want %q", tt.accept, got, ok, tt.want)
+		}
+	}
+}
+
+func TestCompressionHandler(t *testing.T) {
+	opts := DefaultCompressionOptions()
+	opts.MaxDecompressedSize = 4096
+	SetCompressionOptions(opts)
+	t.Cleanup(func() { SetCompressionOptions(DefaultCompressionOptions()) })
+
+	large := strings.Repeat(`{"message":"hello"}`, 200)
+
+	handler := CompressionHandler(func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType(string(ctx.QueryArgs().Peek("type")))
+		ctx.SetBody(ctx.Request.Body())
+	})
+
+	request := func(contentType, acceptEncoding, contentEncoding string, body []byte) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI("/?type=" + url.QueryEscape(contentType))
+		ctx.Request.Header.Set(fasthttp.HeaderAcceptEncoding, acceptEncoding)
+		if contentEncoding != "" {
+			ctx.Request.Header.SetContentEncoding(contentEncoding)
+		}
+
+		ctx.Request.SetBody(body)
+		handler(ctx)
+		return ctx
+	}
+
+	decoders := map[string]func([]byte, []byte) ([]byte, error){
+		GzipEncoding:    fasthttp.AppendGunzipBytes,
+		DeflateEncoding: fasthttp.AppendInflateBytes,
+		BrotliEncoding:  fasthttp.AppendUnbrotliBytes,
+		ZstdEncoding:    fasthttp.AppendUnzstdBytes,
+	}
+
+	encoders := map[string]func([]byte, []byte) []byte{
+		GzipEncoding:    fasthttp.AppendGzipBytes,
+		DeflateEncoding: fasthttp.AppendDeflateBytes,
+		BrotliEncoding:  fasthttp.AppendBrotliBytes,
+		ZstdEncoding:    fasthttp.AppendZstdBytes,
+	}
+
+	for encoding, decode := range decoders {
+		t.Run(encoding, func(t *testing.T) {
+			ctx := request(JSONMediaType, encoding, encoding, encoders[encoding](nil, []byte(large)))
+			if ctx.Response.StatusCode() != 200 || string(ctx.Response.Header.ContentEncoding()) != encoding {
+				t.Fatalf("response = %d encoded %s, want 200 encoded %s: %s", ctx.Response.StatusCode(),
+					ctx.Response.Header.ContentEncoding(), encoding, ctx.Response.Body())
+			}
+
+			if vary := string(ctx.Response.Header.Peek(fasthttp.HeaderVary)); vary != "Accept-Encoding" {
+				t.Errorf("Vary = %s, want Accept-Encoding", vary)
+			}
+
+			body, e := decode(nil, ctx.Response.Body())
+			if e != nil || string(body) != large {
+				t.Errorf("decoded body = %v, %.20s..., want the request body", e, body)
+			}
+		})
+	}
+
+	t.Run("uncompressed", func(t *testing.T) {
+		for name, ctx := range map[string]*fasthttp.RequestCtx{
+			"small":        request(JSONMediaType, GzipEncoding, "", []byte(`{}`)),
+			"content type": request("image/png", GzipEncoding, "", []byte(large)),
+			"not accepted": request(JSONMediaType, "identity", "", []byte(large)),
+		} {
+			if encoding := ctx.Response.Header.ContentEncoding(); len(encoding) > 0 || ctx.Response.StatusCode() != 200 {
+				t.Errorf("%s: response = %d encoded %s, want 200 unencoded", name, ctx.Response.StatusCode(), encoding)
+			}
+		}
+	})
+
+	t.Run("wildcard content type", func(t *testing.T) {
+		ctx := request(ProblemJSONMediaType+"; charset=utf-8", GzipEncoding, "", []byte(large))
+		if encoding := string(ctx.Response.Header.ContentEncoding()); encoding != GzipEncoding {
+			t.Errorf("response encoded %s, want gzip", encoding)
+		}
+	})
+
+	t.Run("layered request encodings", func(t *testing.T) {
+		body := fasthttp.AppendBrotliBytes(nil, fasthttp.AppendGzipBytes(nil, []byte(large)))
+		ctx := request(JSONMediaType, "", "gzip, br", body)
+		if ctx.Response.StatusCode() != 200 || string(ctx.Response.Body()) != large {
+			t.Errorf("response = %d %.20s..., want 200 with the request body", ctx.Response.StatusCode(), ctx.Response.Body())
+		}
+	})
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		status   int
+	}{
+		{"unsupported encoding", "compress", []byte(large), 415},
+		{"corrupt body", GzipEncoding, []byte("not gzip"), 400},
+		{"decompression bomb", ZstdEncoding, fasthttp.AppendZstdBytes(nil, bytes.Repeat([]byte{0}, 1<<20)), 413},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := request(JSONMediaType, "", tt.encoding, tt.body)
+			if ctx.Response.StatusCode() != tt.status {
+				t.Errorf("response = %d, want %d: %s", ctx.Response.StatusCode(), tt.status, ctx.Response.Body())
+			}
+		})
+	}
+}
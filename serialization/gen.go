@@ -18,5 +18,22 @@
 
 package serialization
 
+import _ "embed"
+
 //go:generate protoc --go_out=. generictypes.proto
+//go:generate protoc --go_out=. validate.proto
 // go:generate protoc-go-inject-tag -input generictypes.pb.go
+//go:generate protoc --include_source_info --descriptor_set_out=generictypes.binpb generictypes.proto
+
+// Source info of generictypes.proto, so schemas generated from its messages are documented.
+//
+//go:embed generictypes.binpb
+var genericTypesSourceInfo []byte
+
+func init() {
+	if e := RegisterProtoSourceInfo(genericTypesSourceInfo); e != nil {
+		panic(e)
+	}
+
+	initGenericSchemas()
+}
@@ -19,8 +19,12 @@
 package serialization
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/fire833/go-api-utils/apierror"
 	"github.com/valyala/fasthttp"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -74,49 +78,114 @@ func newNotImplementedResponse(description string) *GenericErrorResponse {
 	return newErrorResponse(http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented), description)
 }
 
+func newAPIErrorResponse(e *apierror.Error) *GenericErrorResponse {
+	description := e.Message
+	if description == "" {
+		description = http.StatusText(e.Status)
+	}
+
+	resp := newErrorResponse(uint32(e.Status), http.StatusText(e.Status), description)
+	resp.ErrorCode = e.Code
+
+	for _, detail := range e.Details {
+		resp.Details = append(resp.Details, &FieldViolation{Field: detail.Field, Description: detail.Description})
+	}
+
+	return resp
+}
+
+// The response header carrying the ID of the request, which is set by the apiserver before
+// requests are handled.
+const requestIDHeader string = "X-Request-ID"
+
+// errorResponseHandler responds with the error response, with the ID of the request if there is one.
+// The response is encoded as a Problem if the client accepts problem details, and otherwise with the
+// media type preferred by the client, falling back to JSON if none of the accepted media types are
+// supported, so the error isn't masked by a 406.
+func errorResponseHandler(ctx *fasthttp.RequestCtx, resp *GenericErrorResponse) {
+	resp.RequestId = string(ctx.Response.Header.Peek(requestIDHeader))
+	addVary(ctx, fasthttp.HeaderAccept)
+
+	if problemResponseHandler(ctx, resp) {
+		return
+	}
+
+	mediaType, ok := NegotiateResponseMediaType(ctx)
+	if !ok {
+		mediaType = JSONMediaType
+	}
+
+	if e := marshalBody(ctx, mediaType, resp); e == nil {
+		ctx.SetStatusCode(int(resp.Code))
+	}
+}
+
 func OKResponseHandler(ctx *fasthttp.RequestCtx, code uint32, message string) {
-	MarshalBodyByAcceptHeader(ctx, newOKResponse(code, message))
-	ctx.SetStatusCode(int(code))
+	if e := MarshalBodyByAcceptHeader(ctx, newOKResponse(code, message)); e == nil {
+		ctx.SetStatusCode(int(code))
+	}
 }
 
 func BadRequestResponseHandler(ctx *fasthttp.RequestCtx, description string) {
-	MarshalBodyByAcceptHeader(ctx, newBadRequestResponse(description))
-	ctx.SetStatusCode(http.StatusBadRequest)
+	errorResponseHandler(ctx, newBadRequestResponse(description))
 }
 
 func NotFoundResponseHandler(ctx *fasthttp.RequestCtx, description string) {
-	MarshalBodyByAcceptHeader(ctx, newNotFoundResponse(description))
-	ctx.SetStatusCode(http.StatusNotFound)
+	errorResponseHandler(ctx, newNotFoundResponse(description))
 }
 
 func UnauthorizedResponseHandler(ctx *fasthttp.RequestCtx, description string) {
-	MarshalBodyByAcceptHeader(ctx, newUnauthorizedResponse(description))
-	ctx.SetStatusCode(http.StatusUnauthorized)
+	errorResponseHandler(ctx, newUnauthorizedResponse(description))
 }
 
 func ForbiddenResponseHandler(ctx *fasthttp.RequestCtx, description string) {
-	MarshalBodyByAcceptHeader(ctx, newForbiddenResponse(description))
-	ctx.SetStatusCode(http.StatusForbidden)
+	errorResponseHandler(ctx, newForbiddenResponse(description))
 }
 
 func InternalErrorResponseHandler(ctx *fasthttp.RequestCtx, description string) {
-	MarshalBodyByAcceptHeader(ctx, newInternalErrorResponse(description))
-	ctx.SetStatusCode(http.StatusInternalServerError)
+	errorResponseHandler(ctx, newInternalErrorResponse(description))
 }
 
 func NotAcceptableResponseHandler(ctx *fasthttp.RequestCtx, description string) {
-	MarshalBodyByAcceptHeader(ctx, newNotAcceptableResponse(description))
-	ctx.SetStatusCode(http.StatusNotAcceptable)
+	errorResponseHandler(ctx, newNotAcceptableResponse(description))
 }
 
 func MethodNotAllowedResponseHandler(ctx *fasthttp.RequestCtx, description string) {
-	MarshalBodyByAcceptHeader(ctx, newMethodNotAllowedResponse(description))
-	ctx.SetStatusCode(http.StatusMethodNotAllowed)
+	errorResponseHandler(ctx, newMethodNotAllowedResponse(description))
 }
 
 func NotImplementedResponseHandler(ctx *fasthttp.RequestCtx, description string) {
-	MarshalBodyByAcceptHeader(ctx, newNotImplementedResponse(description))
-	ctx.SetStatusCode(http.StatusNotImplemented)
+	errorResponseHandler(ctx, newNotImplementedResponse(description))
+}
+
+// APIErrorResponseHandler responds with the error, setting the Retry-After header if the
+// error has a retry delay.
+func APIErrorResponseHandler(ctx *fasthttp.RequestCtx, e *apierror.Error) {
+	if e.RetryAfter > 0 {
+		ctx.Response.Header.Set("Retry-After", strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds()))))
+	}
+
+	errorResponseHandler(ctx, newAPIErrorResponse(e))
+}
+
+func ConflictResponseHandler(ctx *fasthttp.RequestCtx, description string) {
+	APIErrorResponseHandler(ctx, apierror.Conflict(description))
+}
+
+func PreconditionFailedResponseHandler(ctx *fasthttp.RequestCtx, description string) {
+	APIErrorResponseHandler(ctx, apierror.PreconditionFailed(description))
+}
+
+func UnprocessableEntityResponseHandler(ctx *fasthttp.RequestCtx, description string, details ...apierror.FieldViolation) {
+	APIErrorResponseHandler(ctx, apierror.UnprocessableEntity(description, details...))
+}
+
+func TooManyRequestsResponseHandler(ctx *fasthttp.RequestCtx, description string, retryAfter time.Duration) {
+	APIErrorResponseHandler(ctx, apierror.TooManyRequests(description, retryAfter))
+}
+
+func ServiceUnavailableResponseHandler(ctx *fasthttp.RequestCtx, description string, retryAfter time.Duration) {
+	APIErrorResponseHandler(ctx, apierror.Unavailable(description, retryAfter))
 }
 
 func GenericOKResponseHandler(ctx *fasthttp.RequestCtx) {
@@ -154,3 +223,23 @@ func GenericMethodNotAllowedResponseHandler(ctx *fasthttp.RequestCtx) {
 func GenericNotImplementedResponseHandler(ctx *fasthttp.RequestCtx) {
 	NotImplementedResponseHandler(ctx, "request endpoint is not yet implemented")
 }
+
+func GenericConflictResponseHandler(ctx *fasthttp.RequestCtx) {
+	ConflictResponseHandler(ctx, "request conflicts with the current state of the object")
+}
+
+func GenericPreconditionFailedResponseHandler(ctx *fasthttp.RequestCtx) {
+	PreconditionFailedResponseHandler(ctx, "request preconditions do not match the current state of the object")
+}
+
+func GenericUnprocessableEntityResponseHandler(ctx *fasthttp.RequestCtx) {
+	UnprocessableEntityResponseHandler(ctx, "request was well formed but contained invalid fields")
+}
+
+func GenericTooManyRequestsResponseHandler(ctx *fasthttp.RequestCtx) {
+	TooManyRequestsResponseHandler(ctx, "too many requests have been made, please try again later", 0)
+}
+
+func GenericServiceUnavailableResponseHandler(ctx *fasthttp.RequestCtx) {
+	ServiceUnavailableResponseHandler(ctx, "service is temporarily unavailable, please try again later", 0)
+}
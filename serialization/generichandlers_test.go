@@ -19,10 +19,15 @@
 package serialization
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fire833/go-api-utils/apierror"
 	"github.com/fire833/go-api-utils/fake"
 	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // Mostly used to make sure we don't crash and the genericErrorHandlers are guaranteed to compile.
@@ -106,6 +111,32 @@ func TestOKResponseHandler(t *testing.T) {
 			}
 		}
 	})
+
+	for _, mediaType := range []string{MsgPackMediaType, CBORMediaType} {
+		t.Run(mediaType, func(t *testing.T) {
+			// Explicitly test binary marshalling, decoding the response to check it round trips.
+			for i := 0; i < 500; i++ {
+				ctx := &fasthttp.RequestCtx{}
+				ctx.Request.Header.Set("Accept", mediaType)
+
+				msg := fake.FakeStringCeil(256)
+				OKResponseHandler(ctx, 200, msg)
+				if ctx.Response.StatusCode() < 200 || ctx.Response.StatusCode() > 299 {
+					t.Errorf("OkResponseHandler() = %d, want %d", ctx.Response.StatusCode(), 200)
+				}
+
+				if ct := string(ctx.Response.Header.ContentType()); ct != mediaType {
+					t.Fatalf("content type = %s, want %s", ct, mediaType)
+				}
+
+				c, _ := CodecFor(mediaType)
+				resp := &OKResponse{}
+				if e := c.Unmarshal(ctx.Response.Body(), resp); e != nil || resp.Message != msg || resp.Code != 200 || resp.Timestamp == nil {
+					t.Fatalf("Unmarshal() = %v, %v", resp, e)
+				}
+			}
+		})
+	}
 }
 
 func TestBadRequestResponseHandler(t *testing.T) {
@@ -851,3 +882,75 @@ func BenchmarkGenericNotImplementedResponseHandler(b *testing.B) {
 		GenericNotImplementedResponseHandler(&fasthttp.RequestCtx{})
 	}
 }
+
+func TestAPIErrorResponseHandler(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Response.Header.Set("X-Request-ID", "abc123")
+
+	APIErrorResponseHandler(ctx, apierror.TooManyRequests("slow down", 1500*time.Millisecond).
+		WithField("limit", "must be at most 100").
+		WithCause(errors.New("bucket empty")))
+
+	if ctx.Response.StatusCode() != 429 || string(ctx.Response.Header.Peek("Retry-After")) != "2" {
+		t.Fatalf("APIErrorResponseHandler() = %d Retry-After %s, want 429 Retry-After 2",
+			ctx.Response.StatusCode(), ctx.Response.Header.Peek("Retry-After"))
+	}
+
+	resp := &GenericErrorResponse{}
+	if e := protojson.Unmarshal(ctx.Response.Body(), resp); e != nil {
+		t.Fatal(e)
+	}
+
+	if resp.Code != 429 || resp.Error != "Too Many Requests" || resp.Description != "slow down" ||
+		resp.ErrorCode != "too_many_requests" || resp.RequestId != "abc123" {
+		t.Errorf("response = %+v", resp)
+	}
+
+	if len(resp.Details) != 1 || resp.Details[0].Field != "limit" {
+		t.Errorf("details = %+v", resp.Details)
+	}
+
+	if strings.Contains(string(ctx.Response.Body()), "bucket empty") {
+		t.Errorf("error cause returned to client")
+	}
+}
+
+func TestGenericConflictResponseHandler(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	GenericConflictResponseHandler(ctx)
+	if ctx.Response.StatusCode() != 409 {
+		t.Errorf("GenericConflictResponseHandler() = %d, want 409", ctx.Response.StatusCode())
+	}
+}
+
+func TestGenericPreconditionFailedResponseHandler(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	GenericPreconditionFailedResponseHandler(ctx)
+	if ctx.Response.StatusCode() != 412 {
+		t.Errorf("GenericPreconditionFailedResponseHandler() = %d, want 412", ctx.Response.StatusCode())
+	}
+}
+
+func TestGenericUnprocessableEntityResponseHandler(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	GenericUnprocessableEntityResponseHandler(ctx)
+	if ctx.Response.StatusCode() != 422 {
+		t.Errorf("GenericUnprocessableEntityResponseHandler() = %d, want 422", ctx.Response.StatusCode())
+	}
+}
+
+func TestGenericTooManyRequestsResponseHandler(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	GenericTooManyRequestsResponseHandler(ctx)
+	if ctx.Response.StatusCode() != 429 || len(ctx.Response.Header.Peek("Retry-After")) != 0 {
+		t.Errorf("GenericTooManyRequestsResponseHandler() = %d, want 429 without Retry-After", ctx.Response.StatusCode())
+	}
+}
+
+func TestGenericServiceUnavailableResponseHandler(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	GenericServiceUnavailableResponseHandler(ctx)
+	if ctx.Response.StatusCode() != 503 {
+		t.Errorf("GenericServiceUnavailableResponseHandler() = %d, want 503", ctx.Response.StatusCode())
+	}
+}
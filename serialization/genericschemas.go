@@ -27,22 +27,59 @@ var (
 	// by app endpoints. The idea behind this schema is for it to be sent with every non-200 status code
 	// the API may return, and customize the fields depending on root cause. This allows for easier error
 	// handling client-side.
-	GenericErrorResponseSchema *spec.Schema = NewSchema("GenericErrorResponse", "Generic response to an error.", []spec.Schema{
-		NewSchemaStringProperty("error", "The http error string for this error."),
-		NewSchemaStringProperty("description", "A more verbose description of this error, sometimes with a subsystem reference."),
-		NewSchemaUint32Property("code", "The integer http status code corresponding to error string."),
-		NewSchemaTimestampProperty("timestamp", "The timestamp of this response being returned."),
-	})
-
-	OKResponseSchema *spec.Schema = NewSchema("OKResponse", "Generic OK response.", []spec.Schema{
-		NewSchemaStringProperty("message", "Human-readable message for the successful response."),
-		NewSchemaTimestampProperty("timestamp", "The timestamp of this response being returned."),
-		NewSchemaUint32Property("code", "The integer http status code corresponding to the response."),
-	})
+	GenericErrorResponseSchema *spec.Schema
+
+	// FieldViolationSchema is the schema of the field violations within a GenericErrorResponse.
+	FieldViolationSchema *spec.Schema
+
+	OKResponseSchema *spec.Schema
 )
 
+// initGenericSchemas generates the schemas of the generic types from their descriptors, like the
+// definitions of every other object, rather than maintaining them by hand. This is called once
+// the source info of the types is registered, so they are documented.
+func initGenericSchemas() {
+	// The descriptors are built by the init of the generated code, which may not have run yet.
+	file_generictypes_proto_init()
+
+	for _, schema := range NewSchemasFromObject(&GenericErrorResponse{}) {
+		switch schema.Title {
+		case "GenericErrorResponse":
+			GenericErrorResponseSchema = schema
+		case "FieldViolation":
+			FieldViolationSchema = schema
+		}
+	}
+
+	OKResponseSchema = NewSchemasFromObject(&OKResponse{})[0]
+}
+
+// GenericErrorResponses are the responses referenced by AddResponseBoilerplate, to be added to the
+// responses of a spec along with the GenericErrorResponse and FieldViolation definitions. Clients
+// accepting application/problem+json or application/problem+xml receive a Problem instead.
+var GenericErrorResponses map[string]spec.Response = map[string]spec.Response{
+	"IncorrectResponse":           *newGenericErrorSpecResponse("Returned if the request was malformed and could not be processed."),
+	"UnauthorizedResponse":        *newGenericErrorSpecResponse("Returned if the request did not have proper authentication credentials."),
+	"ForbiddenResponse":           *newGenericErrorSpecResponse("Returned if the request is forbidden with the provided credentials."),
+	"NotFoundResponse":            *newGenericErrorSpecResponse("Returned if the requested object was not found."),
+	"UnnaceptableResponse":        *newGenericErrorSpecResponse("Returned if the response can't be encoded in any of the accepted formats."),
+	"ConflictResponse":            *newGenericErrorSpecResponse("Returned if the request conflicts with the current state of the object."),
+	"PreconditionFailedResponse":  *newGenericErrorSpecResponse("Returned if the request preconditions do not match the current state of the object."),
+	"RequestTooLargeResponse":     *newGenericErrorSpecResponse("Returned if the request body is larger than the server accepts."),
+	"UnprocessableEntityResponse": *newGenericErrorSpecResponse("Returned if the request was well formed but contained invalid fields, which are listed within the details."),
+	"RateLimitResponse": *newGenericErrorSpecResponse("Returned if too many requests have been made.").
+		AddHeader("Retry-After", spec.ResponseHeader().Typed("integer", "").WithDescription("The number of seconds to wait before retrying the request.")),
+	"InternalErrorResponse": *newGenericErrorSpecResponse("Returned if the request cannot be processed due to an internal server error."),
+	"UnavailableResponse": *newGenericErrorSpecResponse("Returned if the service is temporarily unavailable.").
+		AddHeader("Retry-After", spec.ResponseHeader().Typed("integer", "").WithDescription("The number of seconds to wait before retrying the request.")),
+}
+
+func newGenericErrorSpecResponse(desc string) *spec.Response {
+	return spec.NewResponse().WithDescription(desc).WithSchema(spec.RefSchema("#/definitions/GenericErrorResponse"))
+}
+
 // AddResponseBoilerplate adds generic handlers for different status codes on any one API operation.
-// This includes setting the MIME types as well as error status code handlers.
+// This includes setting the MIME types of all registered codecs as well as error status code handlers.
 func AddResponseBoilerplate(resp *spec.Operation) *spec.Operation {
 	authParam := spec.ParamRef("#/parameters/Authorization")
 	acceptParam := spec.ParamRef("#/parameters/Accept")
@@ -51,8 +88,8 @@ func AddResponseBoilerplate(resp *spec.Operation) *spec.Operation {
 	resp.Parameters = append(resp.Parameters, *authParam, *acceptParam, *contentTypeParam)
 
 	return resp.
-		WithConsumes("application/json", "application/xml", "application/yaml", "application/protobuf").
-		WithProduces("application/json", "application/xml", "application/yaml", "application/protobuf").
+		WithConsumes(MediaTypes()...).
+		WithProduces(MediaTypes()...).
 		RespondsWith(400, spec.ResponseRef("#/responses/IncorrectResponse")).
 		RespondsWith(401, spec.ResponseRef("#/responses/UnauthorizedResponse")).
 		RespondsWith(404, spec.ResponseRef("#/responses/NotFoundResponse")).
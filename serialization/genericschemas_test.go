@@ -0,0 +1,92 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/fire833/go-api-utils/object"
+	"github.com/go-openapi/spec"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGenericSchemas(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *spec.Schema
+		obj    object.Object
+	}{
+		{"GenericErrorResponse", GenericErrorResponseSchema, &GenericErrorResponse{
+			Error:       "Not Found",
+			Description: "widget not found",
+			Code:        404,
+			Timestamp:   timestamppb.Now(),
+			ErrorCode:   "object_not_found",
+			Details:     []*FieldViolation{{Field: "name", Description: "required"}},
+			RequestId:   "abc-123",
+		}},
+		{"FieldViolation", FieldViolationSchema, &FieldViolation{Field: "name", Description: "required"}},
+		{"OKResponse", OKResponseSchema, &OKResponse{Message: "ok", Timestamp: timestamppb.Now(), Code: 200}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.schema == nil || tt.schema.Title != tt.name {
+				t.Fatalf("schema = %v, want %s", tt.schema, tt.name)
+			}
+
+			// Every property should be documented, and named as the populated object is encoded.
+			props := []string{}
+			for name, prop := range tt.schema.Properties {
+				props = append(props, name)
+				if prop.Description == "" {
+					t.Errorf("property %s has no description", name)
+				}
+			}
+
+			data, e := ProtoJSONOptions{}.marshal(tt.obj)
+			if e != nil {
+				t.Fatal(e)
+			}
+
+			fields := map[string]any{}
+			if e := json.Unmarshal(data, &fields); e != nil {
+				t.Fatal(e)
+			}
+
+			keys := []string{}
+			for key := range fields {
+				keys = append(keys, key)
+			}
+
+			sort.Strings(props)
+			sort.Strings(keys)
+			if len(props) != len(keys) {
+				t.Fatalf("properties = %v, want %v", props, keys)
+			}
+			for i := range props {
+				if props[i] != keys[i] {
+					t.Errorf("properties = %v, want %v", props, keys)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/object"
+	"github.com/valyala/fasthttp"
+)
+
+// Errors that handlers can return, or wrap with additional detail (ie fmt.Errorf("%w: object %s", ErrNotFound, id)),
+// to respond with the corresponding error response, see ErrorResponseHandler.
+var (
+	ErrBadRequest       = errors.New(strings.ToLower(http.StatusText(http.StatusBadRequest)))
+	ErrUnauthorized     = errors.New(strings.ToLower(http.StatusText(http.StatusUnauthorized)))
+	ErrForbidden        = errors.New(strings.ToLower(http.StatusText(http.StatusForbidden)))
+	ErrNotFound         = errors.New(strings.ToLower(http.StatusText(http.StatusNotFound)))
+	ErrMethodNotAllowed = errors.New(strings.ToLower(http.StatusText(http.StatusMethodNotAllowed)))
+	ErrNotAcceptable    = errors.New(strings.ToLower(http.StatusText(http.StatusNotAcceptable)))
+	ErrNotImplemented   = errors.New(strings.ToLower(http.StatusText(http.StatusNotImplemented)))
+)
+
+// ErrorResponseHandler responds with the error response matching e. Errors wrapping an *apierror.Error
+// are responded with using APIErrorResponseHandler, and errors wrapping one of the errors above are
+// returned to the client with their message as the description. Any other error is treated as an
+// internal error, and its message is not returned to the client.
+func ErrorResponseHandler(ctx *fasthttp.RequestCtx, e error) {
+	if apiErr, ok := apierror.As(e); ok {
+		APIErrorResponseHandler(ctx, apiErr)
+		return
+	}
+
+	switch {
+	case errors.Is(e, ErrBadRequest):
+		APIErrorResponseHandler(ctx, apierror.BadRequest(e.Error()))
+	case errors.Is(e, ErrUnauthorized):
+		APIErrorResponseHandler(ctx, apierror.Unauthorized(e.Error()))
+	case errors.Is(e, ErrForbidden):
+		APIErrorResponseHandler(ctx, apierror.Forbidden(e.Error()))
+	case errors.Is(e, ErrNotFound):
+		APIErrorResponseHandler(ctx, apierror.NotFound(e.Error()))
+	case errors.Is(e, ErrMethodNotAllowed):
+		APIErrorResponseHandler(ctx, apierror.MethodNotAllowed(e.Error()))
+	case errors.Is(e, ErrNotAcceptable):
+		APIErrorResponseHandler(ctx, apierror.NotAcceptable(e.Error()))
+	case errors.Is(e, ErrNotImplemented):
+		APIErrorResponseHandler(ctx, apierror.NotImplemented(e.Error()))
+	default:
+		GenericInternalErrorResponseHandler(ctx)
+	}
+}
+
+// HandlerFunc is a typed request handler, which is passed the decoded request and returns the
+// response to encode, or an error to respond with instead.
+type HandlerFunc[Req, Resp object.Object] func(ctx context.Context, req Req) (Resp, error)
+
+// Validator can be implemented by requests to validate them before they are handled.
+type Validator interface {
+	Validate() error
+}
+
+// Handle adapts fn into a fasthttp handler, responding with 200, or 204 if Resp is NoBody.
+// See HandleStatus.
+func Handle[Req, Resp object.Object](fn HandlerFunc[Req, Resp]) fasthttp.RequestHandler {
+	status := http.StatusOK
+	if _, ok := any(*new(Resp)).(NoBody); ok {
+		status = http.StatusNoContent
+	}
+
+	return HandleStatus(status, fn)
+}
+
+// HandleStatus adapts fn into a fasthttp handler responding with status on success. The adapter
+//
+//   - decodes the request body into a new Req with UnmarshalBodyByContentHeader, unless Req is NoBody
+//     or the body is empty,
+//   - binds path and query parameters to fields of the request with BindParams,
+//     with path parameters taking precedence over query parameters, and both over the body,
+//   - validates the request against the constraints of its fields with ValidateObject, responding
+//     with 422 listing every violation,
+//   - validates the request if it implements Validator,
+//
+// responding with 400 if any other step fails, 413 if the body exceeds the DecodeLimits of the
+// request, or 415 if the body's content type isn't supported.
+// fn is then called with the request ctx, so records logged with it are correlated with the
+// request. Any error returned is responded with using ErrorResponseHandler, otherwise the response
+// is encoded with MarshalBodyByAcceptHeader, unless Resp is NoBody or the response is nil.
+func HandleStatus[Req, Resp object.Object](status int, fn HandlerFunc[Req, Resp]) fasthttp.RequestHandler {
+	reqType := reflect.TypeFor[Req]()
+	_, noBody := any(*new(Req)).(NoBody)
+
+	return func(ctx *fasthttp.RequestCtx) {
+		req := reflect.New(reqType.Elem()).Interface().(Req)
+
+		if !noBody && len(ctx.Request.Body()) > 0 {
+			if e := UnmarshalBodyByContentHeader(ctx, req); e != nil {
+				if apiErr, ok := apierror.As(e); ok {
+					APIErrorResponseHandler(ctx, apiErr)
+				} else {
+					BadRequestResponseHandler(ctx, "unable to decode request body: "+e.Error())
+				}
+
+				return
+			}
+		}
+
+		if e := BindParams(ctx, req); e != nil {
+			ErrorResponseHandler(ctx, e)
+			return
+		}
+
+		if e := ValidateObject(req); e != nil {
+			ErrorResponseHandler(ctx, e)
+			return
+		}
+
+		if v, ok := any(req).(Validator); ok {
+			if e := v.Validate(); e != nil {
+				BadRequestResponseHandler(ctx, "invalid request: "+e.Error())
+				return
+			}
+		}
+
+		resp, e := fn(ctx, req)
+		if e != nil {
+			ErrorResponseHandler(ctx, e)
+			return
+		}
+
+		if _, ok := any(resp).(NoBody); ok || !resp.ProtoReflect().IsValid() {
+			ctx.Response.ResetBody()
+			ctx.SetStatusCode(status)
+			return
+		}
+
+		if e := MarshalBodyByAcceptHeader(ctx, resp); e == nil {
+			ctx.SetStatusCode(status)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fasthttp/router"
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/valyala/fasthttp"
+)
+
+// validatedRequest is a request that is only valid with a code set.
+type validatedRequest struct {
+	GenericErrorResponse
+}
+
+func (r *validatedRequest) Validate() error {
+	if r.Code == 0 {
+		return errors.New("code is required")
+	}
+
+	return nil
+}
+
+func TestHandle(t *testing.T) {
+	rt := router.New()
+
+	rt.POST("/errors/{description}", HandleStatus(201, func(ctx context.Context, req *GenericErrorResponse) (*GenericErrorResponse, error) {
+		return req, nil
+	}))
+
+	rt.POST("/validated", Handle(func(ctx context.Context, req *validatedRequest) (*OKResponse, error) {
+		return &OKResponse{Code: req.Code}, nil
+	}))
+
+	rt.DELETE("/errors/{description}", Handle(func(ctx context.Context, req NoBody) (NoBody, error) {
+		return nil, nil
+	}))
+
+	rt.GET("/fail/{code}", Handle(func(ctx context.Context, req *OKResponse) (*OKResponse, error) {
+		switch req.Code {
+		case 404:
+			return nil, fmt.Errorf("%w: object %s", ErrNotFound, req.Message)
+		case 403:
+			return nil, ErrForbidden
+		case 409:
+			return nil, fmt.Errorf("unable to create object: %w", apierror.Conflict("object exists"))
+		default:
+			return nil, errors.New("database password is hunter2")
+		}
+	}))
+
+	tests := []struct {
+		name   string
+		method string
+		uri    string
+		body   string
+		status int
+		want   map[string]any
+	}{
+		{"decode and bind", fasthttp.MethodPost, "/errors/from-path?code=7&error=from-query", `{"error":"from-body","code":1}`, 201,
+			map[string]any{"error": "from-query", "description": "from-path", "code": float64(7)}},
+		{"decode only", fasthttp.MethodPost, "/errors/from-path", `{"error":"from-body"}`, 201,
+			map[string]any{"error": "from-body", "description": "from-path"}},
+		{"malformed body", fasthttp.MethodPost, "/errors/x", `{"error":`, 400, nil},
+		{"malformed query", fasthttp.MethodPost, "/errors/x?code=-1", "", 400, nil},
+		{"overflowing query", fasthttp.MethodPost, "/errors/x?code=4294967296", "", 400, nil},
+		{"valid", fasthttp.MethodPost, "/validated?code=3", "", 200, map[string]any{"code": float64(3)}},
+		{"invalid", fasthttp.MethodPost, "/validated", "", 400, nil},
+		{"no content", fasthttp.MethodDelete, "/errors/x", "", 204, nil},
+		{"wrapped error", fasthttp.MethodGet, "/fail/404?message=abc", "", 404,
+			map[string]any{"error": "Not Found", "description": "not found: object abc", "code": float64(404)}},
+		{"error", fasthttp.MethodGet, "/fail/403", "", 403, nil},
+		{"api error", fasthttp.MethodGet, "/fail/409", "", 409,
+			map[string]any{"description": "object exists", "errorCode": "conflict", "code": float64(409)}},
+		{"internal error", fasthttp.MethodGet, "/fail/500", "", 500, nil},
+	}
+
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.Header.SetMethod(c.method)
+			ctx.Request.SetRequestURI(c.uri)
+			ctx.Request.SetBodyString(c.body)
+
+			rt.Handler(ctx)
+
+			if ctx.Response.StatusCode() != c.status {
+				t.Fatalf("%s %s = %d, want %d:\n%s", c.method, c.uri, ctx.Response.StatusCode(), c.status, ctx.Response.Body())
+			}
+
+			if c.status == 204 && len(ctx.Response.Body()) != 0 {
+				t.Errorf("204 response has body %s", ctx.Response.Body())
+			}
+
+			if c.status == 500 && strings.Contains(string(ctx.Response.Body()), "hunter2") {
+				t.Errorf("internal error message returned to client")
+			}
+
+			if c.want == nil {
+				return
+			}
+
+			body := map[string]any{}
+			if e := json.Unmarshal(ctx.Response.Body(), &body); e != nil {
+				t.Fatal(e)
+			}
+
+			for key, want := range c.want {
+				if body[key] != want {
+					t.Errorf("%s = %v, want %v", key, body[key], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,392 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/object"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeLimits limits the request bodies decoded by UnmarshalBodyByContentHeader, so that clients
+// can't exhaust the memory or stack of the process with crafted bodies.
+type DecodeLimits struct {
+	// The maximum size of request bodies in bytes, larger bodies are rejected with 413. 0 for no limit.
+	MaxBodySize int
+
+	// The maximum nesting depth of objects and arrays (or XML elements) within request bodies, where
+	// the top level object has a depth of 1. 0 for no limit other than the recursion limit of protobuf.
+	MaxDepth int
+
+	// The maximum number of aliases expanded when decoding YAML request bodies, which limits how
+	// far small bodies can expand (ie billion laughs). 0 for no limit.
+	MaxYAMLAliases int
+
+	// Reject request bodies containing fields that aren't part of the request, rather than ignoring
+	// them. Fields that are unknown to the XML codec are always ignored.
+	DisallowUnknownFields bool
+}
+
+// DefaultDecodeLimits returns the limits of requests until SetDecodeLimits is called.
+func DefaultDecodeLimits() DecodeLimits {
+	return DecodeLimits{
+		MaxBodySize:    4 << 20,
+		MaxDepth:       100,
+		MaxYAMLAliases: 1000,
+	}
+}
+
+var decodeLimits atomic.Pointer[DecodeLimits]
+
+func init() {
+	SetDecodeLimits(DefaultDecodeLimits())
+}
+
+// SetDecodeLimits replaces the limits of requests to handlers without their own, see WithDecodeLimits.
+func SetDecodeLimits(limits DecodeLimits) {
+	decodeLimits.Store(&limits)
+}
+
+type decodeLimitsKey struct{}
+
+// WithDecodeLimits wraps next so that request bodies decoded by it are limited by limits rather than
+// the limits set with SetDecodeLimits.
+func WithDecodeLimits(next fasthttp.RequestHandler, limits DecodeLimits) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetUserValue(decodeLimitsKey{}, &limits)
+		next(ctx)
+	}
+}
+
+// RequestDecodeLimits returns the limits request bodies of the request are decoded with.
+func RequestDecodeLimits(ctx *fasthttp.RequestCtx) DecodeLimits {
+	if limits, ok := ctx.UserValue(decodeLimitsKey{}).(*DecodeLimits); ok {
+		return *limits
+	}
+
+	return *decodeLimits.Load()
+}
+
+// LimitedUnmarshaler can be implemented by codecs to enforce the nesting, alias and unknown field
+// limits of DecodeLimits while decoding. Codecs that don't implement it are only subject to the
+// maximum body size.
+type LimitedUnmarshaler interface {
+	UnmarshalLimited(data []byte, obj object.Object, limits DecodeLimits) error
+}
+
+// DecodeError is returned by codecs when a body can't be decoded into an object because of one
+// of its fields.
+type DecodeError struct {
+	// The path of the field within the body, ie items[0].name.
+	Field string
+
+	// Why the field couldn't be decoded, ie "unknown field".
+	Description string
+
+	// The underlying error returned when decoding, if any.
+	Err error
+}
+
+func (e *DecodeError) Error() string { return e.Field + ": " + e.Description }
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// decodeAPIError translates an error decoding a request body into the error to respond with.
+func decodeAPIError(e error) *apierror.Error {
+	var decodeErr *DecodeError
+	if errors.As(e, &decodeErr) {
+		return apierror.BadRequest("unable to decode request body").
+			WithField(decodeErr.Field, decodeErr.Description).
+			WithCause(e)
+	}
+
+	return apierror.BadRequest("unable to decode request body: " + e.Error()).WithCause(e)
+}
+
+// maxDepth returns the nesting depth limit to enforce, capped at the recursion limit of protobuf.
+func (l DecodeLimits) maxDepth() int {
+	if l.MaxDepth <= 0 || l.MaxDepth > maxValueDepth {
+		return maxValueDepth
+	}
+
+	return l.MaxDepth
+}
+
+// checkJSONDepth returns an error if objects and arrays within data are nested deeper than maxDepth.
+func checkJSONDepth(data []byte, maxDepth int) error {
+	depth, inString, escaped := 0, false, false
+
+	for _, c := range data {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			switch c {
+			case '\\':
+				escaped = true
+			case '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			if depth++; depth > maxDepth {
+				return fmt.Errorf("%w of %d", errMaxDepth, maxDepth)
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+
+	return nil
+}
+
+// checkYAMLNode returns an error if mappings and sequences within the document are nested deeper than
+// the limit, or it expands more aliases than the limit, following aliases as they would be expanded.
+func checkYAMLNode(node *yaml.Node, limits DecodeLimits) error {
+	aliases := 0
+
+	var walk func(n *yaml.Node, depth int) error
+	walk = func(n *yaml.Node, depth int) error {
+		switch n.Kind {
+		case yaml.AliasNode:
+			if aliases++; limits.MaxYAMLAliases > 0 && aliases > limits.MaxYAMLAliases {
+				return fmt.Errorf("maximum of %d expanded aliases exceeded", limits.MaxYAMLAliases)
+			}
+
+			return walk(n.Alias, depth)
+		case yaml.MappingNode, yaml.SequenceNode:
+			if depth++; depth > limits.maxDepth() {
+				return fmt.Errorf("%w of %d", errMaxDepth, limits.maxDepth())
+			}
+		}
+
+		for _, child := range n.Content {
+			if e := walk(child, depth); e != nil {
+				return e
+			}
+		}
+
+		return nil
+	}
+
+	return walk(node, 0)
+}
+
+// checkXML returns an error if the document contains a document type definition, which may declare
+// entities, or elements are nested deeper than maxDepth.
+func checkXML(data []byte, maxDepth int) error {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = true
+
+	depth := 0
+	for {
+		tok, e := d.Token()
+		if errors.Is(e, io.EOF) {
+			return nil
+		} else if e != nil {
+			return e
+		}
+
+		switch tok.(type) {
+		case xml.StartElement:
+			if depth++; depth > maxDepth {
+				return fmt.Errorf("%w of %d", errMaxDepth, maxDepth)
+			}
+		case xml.EndElement:
+			depth--
+		case xml.Directive:
+			return errors.New("document type definitions and entity declarations are not allowed")
+		}
+	}
+}
+
+// valueError diagnoses why a tree of values decoded from a body couldn't be decoded into the message,
+// returning a *DecodeError with the path of the first field that can't be decoded, or e if none is found.
+func valueError(e error, v any, md protoreflect.MessageDescriptor, strict bool) error {
+	if field, desc, ok := diagnoseMessage(v, md, "", strict); ok {
+		return &DecodeError{Field: field, Description: desc, Err: e}
+	}
+
+	return e
+}
+
+// jsonError diagnoses an error decoding data as JSON into the message, see valueError.
+func jsonError(e error, data []byte, md protoreflect.MessageDescriptor, strict bool) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if dec.Decode(&v) != nil {
+		return e
+	}
+
+	return valueError(e, v, md, strict)
+}
+
+func diagnoseMessage(v any, md protoreflect.MessageDescriptor, path string, strict bool) (string, string, bool) {
+	// Well-known types have JSON representations that don't correspond to their fields.
+	if md.ParentFile().Package() == "google.protobuf" {
+		return "", "", false
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return path, "must be an object", v != nil
+	}
+
+	fields := md.Fields()
+	for key, value := range obj {
+		fieldPath := joinFieldPath(path, key)
+
+		fd := fields.ByJSONName(key)
+		if fd == nil {
+			fd = fields.ByName(protoreflect.Name(key))
+		}
+
+		if fd == nil {
+			if strict {
+				return fieldPath, "unknown field", true
+			}
+
+			continue
+		}
+
+		if value == nil {
+			continue
+		}
+
+		switch {
+		case fd.IsList():
+			items, ok := value.([]any)
+			if !ok {
+				return fieldPath, "must be an array", true
+			}
+
+			for i, item := range items {
+				if field, desc, ok := diagnoseValue(item, fd, fieldPath+"["+strconv.Itoa(i)+"]", strict); ok {
+					return field, desc, true
+				}
+			}
+		case fd.IsMap():
+			entries, ok := value.(map[string]any)
+			if !ok {
+				return fieldPath, "must be an object", true
+			}
+
+			for k, entry := range entries {
+				if field, desc, ok := diagnoseValue(entry, fd.MapValue(), fieldPath+"["+strconv.Quote(k)+"]", strict); ok {
+					return field, desc, true
+				}
+			}
+		default:
+			if field, desc, ok := diagnoseValue(value, fd, fieldPath, strict); ok {
+				return field, desc, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// diagnoseValue checks a single value of the field, for kinds of values that can never be decoded
+// into the field.
+func diagnoseValue(v any, fd protoreflect.FieldDescriptor, path string, strict bool) (string, string, bool) {
+	if v == nil {
+		return "", "", false
+	}
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return diagnoseMessage(v, fd.Message(), path, strict)
+	case protoreflect.BoolKind:
+		if _, ok := v.(bool); !ok {
+			return path, "must be a boolean", true
+		}
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		switch v.(type) {
+		case string, []byte:
+		default:
+			return path, "must be a string", true
+		}
+	case protoreflect.EnumKind:
+		switch v.(type) {
+		case map[string]any, []any, bool:
+			return path, "must be one of the values of " + string(fd.Enum().Name()), true
+		}
+	default:
+		switch v.(type) {
+		case map[string]any, []any, bool:
+			return path, "must be a number", true
+		}
+	}
+
+	return "", "", false
+}
+
+func joinFieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
+
+// unknownFieldError returns a *DecodeError for the first unknown field within the message decoded
+// from protobuf, or nil if there are none.
+func unknownFieldError(m protoreflect.Message, path string) error {
+	if unknown := m.GetUnknown(); len(unknown) > 0 {
+		num, _, _ := protowire.ConsumeTag(unknown)
+		return &DecodeError{Field: joinFieldPath(path, strconv.Itoa(int(num))), Description: "unknown field"}
+	}
+
+	var e error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fieldPath := joinFieldPath(path, fd.JSONName())
+
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			for i := 0; i < v.List().Len() && e == nil; i++ {
+				e = unknownFieldError(v.List().Get(i).Message(), fieldPath+"["+strconv.Itoa(i)+"]")
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				e = unknownFieldError(v.Message(), fieldPath+"["+strconv.Quote(k.String())+"]")
+				return e == nil
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			e = unknownFieldError(v.Message(), fieldPath)
+		}
+
+		return e == nil
+	})
+
+	return e
+}
@@ -0,0 +1,127 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/object"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func decodeWithLimits(limits DecodeLimits, contentType string, body []byte, obj object.Object) *apierror.Error {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetContentType(contentType)
+	ctx.Request.SetBody(body)
+
+	var apiErr *apierror.Error
+	WithDecodeLimits(func(ctx *fasthttp.RequestCtx) {
+		if e := UnmarshalBodyByContentHeader(ctx, obj); e != nil {
+			apiErr, _ = apierror.As(e)
+		}
+	}, limits)(ctx)
+
+	return apiErr
+}
+
+func TestDecodeLimits(t *testing.T) {
+	billionLaughs := "a: &a [x, x, x, x, x, x, x, x, x, x]\n"
+	for i, name := range []string{"b", "c", "d", "e", "f", "g"} {
+		prev := string("abcdefg"[i])
+		billionLaughs += name + ": &" + name + " [" + strings.Repeat("*"+prev+", ", 9) + "*" + prev + "]\n"
+	}
+
+	unknownProto := protowire.AppendTag(nil, 99, protowire.VarintType)
+	unknownProto = protowire.AppendVarint(unknownProto, 1)
+
+	tests := []struct {
+		name        string
+		limits      DecodeLimits
+		contentType string
+		body        string
+		status      int
+		field       string
+	}{
+		{"within limits", DefaultDecodeLimits(), JSONMediaType, `{"details":[{"field":"a"}]}`, 0, ""},
+		{"body too large", DecodeLimits{MaxBodySize: 8}, JSONMediaType, `{"error":"too large"}`, http.StatusRequestEntityTooLarge, ""},
+		{"json too deep", DecodeLimits{MaxDepth: 2}, JSONMediaType, `{"details":[{"field":"a"}]}`, http.StatusBadRequest, ""},
+		{"json nested arrays", DefaultDecodeLimits(), JSONMediaType, strings.Repeat("[", 200) + strings.Repeat("]", 200), http.StatusBadRequest, ""},
+		{"json unknown field", DecodeLimits{DisallowUnknownFields: true}, JSONMediaType, `{"details":[{"field":"a","bogus":1}]}`, http.StatusBadRequest, "details[0].bogus"},
+		{"json unknown field ignored", DecodeLimits{}, JSONMediaType, `{"details":[{"field":"a","bogus":1}]}`, 0, ""},
+		{"json wrong type", DecodeLimits{}, JSONMediaType, `{"details":[{"field":["a"]}]}`, http.StatusBadRequest, "details[0].field"},
+		{"yaml billion laughs", DefaultDecodeLimits(), YAMLMediaType, billionLaughs, http.StatusBadRequest, ""},
+		{"yaml too deep", DecodeLimits{MaxDepth: 2}, YAMLMediaType, "details:\n  - field: a\n", http.StatusBadRequest, ""},
+		{"yaml unknown field", DecodeLimits{DisallowUnknownFields: true}, YAMLMediaType, "details:\n  - bogus: a\n", http.StatusBadRequest, "details[0].bogus"},
+		{"xml doctype", DefaultDecodeLimits(), XMLMediaType, `<?xml version="1.0"?><!DOCTYPE e [<!ENTITY a "b">]><e><error>&a;</error></e>`, http.StatusBadRequest, ""},
+		{"xml too deep", DecodeLimits{MaxDepth: 2}, XMLMediaType, `<e><details><field>a</field></details></e>`, http.StatusBadRequest, ""},
+		{"msgpack too deep", DefaultDecodeLimits(), MsgPackMediaType, strings.Repeat("\x91", 200) + "\xc0", http.StatusBadRequest, ""},
+		{"cbor too deep", DefaultDecodeLimits(), CBORMediaType, strings.Repeat("\x81", 200) + "\xf6", http.StatusBadRequest, ""},
+		{"protobuf unknown field", DecodeLimits{DisallowUnknownFields: true}, ProtobufMediaType, string(unknownProto), http.StatusBadRequest, "99"},
+		{"protobuf unknown field ignored", DecodeLimits{}, ProtobufMediaType, string(unknownProto), 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := decodeWithLimits(tt.limits, tt.contentType, []byte(tt.body), &GenericErrorResponse{})
+
+			if tt.status == 0 {
+				if apiErr != nil {
+					t.Fatalf("UnmarshalBodyByContentHeader() = %v", apiErr)
+				}
+
+				return
+			}
+
+			if apiErr == nil || apiErr.Status != tt.status {
+				t.Fatalf("UnmarshalBodyByContentHeader() = %v, want %d", apiErr, tt.status)
+			}
+
+			if tt.field == "" {
+				return
+			}
+
+			if len(apiErr.Details) != 1 || apiErr.Details[0].Field != tt.field {
+				t.Errorf("details = %v, want field %s", apiErr.Details, tt.field)
+			}
+		})
+	}
+}
+
+func TestSetDecodeLimits(t *testing.T) {
+	defer SetDecodeLimits(DefaultDecodeLimits())
+	SetDecodeLimits(DecodeLimits{MaxBodySize: 8})
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetBody([]byte(`{"error":"too large"}`))
+	if e := UnmarshalBodyByContentHeader(ctx, &GenericErrorResponse{}); apierror.StatusOf(e) != http.StatusRequestEntityTooLarge {
+		t.Errorf("UnmarshalBodyByContentHeader() = %v, want 413", e)
+	}
+
+	if got := RequestDecodeLimits(ctx); got.MaxBodySize != 8 {
+		t.Errorf("RequestDecodeLimits() = %+v, want the limits set", got)
+	}
+
+	if apiErr := decodeWithLimits(DefaultDecodeLimits(), JSONMediaType, []byte(`{"error":"within limits"}`), &GenericErrorResponse{}); apiErr != nil {
+		t.Errorf("UnmarshalBodyByContentHeader() with route limits = %v", apiErr)
+	}
+}
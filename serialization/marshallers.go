@@ -19,111 +19,117 @@
 package serialization
 
 import (
-	"encoding/json"
-	"encoding/xml"
+	"fmt"
+	"mime"
 	"net/http"
 
-	"github.com/BurntSushi/toml"
+	"github.com/fire833/go-api-utils/apierror"
 	object "github.com/fire833/go-api-utils/object"
 	"github.com/valyala/fasthttp"
-	"google.golang.org/protobuf/proto"
-	"gopkg.in/yaml.v3"
 )
 
-// Default unmarshaller for unmarshalling request bodies based on thier Content-Type header.
-// This API will only support yaml and json as the content mediums.
-func UnmarshalBodyByContentHeader(ctx *fasthttp.RequestCtx, data object.Object) error {
-	switch string(ctx.Request.Header.Peek("Content-Type")) {
-	case "application/yaml":
-		{
-			if e := yaml.Unmarshal(ctx.Request.Body(), data); e != nil {
-				return e
-			} else {
-				return nil
-			}
-		}
-	case "application/toml":
-		{
-			if e := toml.Unmarshal(ctx.Request.Body(), data); e != nil {
-				return e
-			} else {
-				return nil
-			}
-		}
-	case "application/xml":
-		{
-			if e := xml.Unmarshal(ctx.Request.Body(), data); e != nil {
-				return e
-			} else {
-				return nil
-			}
-		}
-	case "application/protobuf":
-		{
-			if e := proto.Unmarshal(ctx.Request.Body(), data); e != nil {
-				return e
-			} else {
-				return nil
-			}
+// NegotiateResponseMediaType returns the media type to encode the response to a request with, according
+// to the request's Accept header, or false if none of the registered codecs are acceptable. Aliases
+// of media types within the header are accepted as the media type they alias.
+func NegotiateResponseMediaType(ctx *fasthttp.RequestCtx) (string, bool) {
+	accept := ctx.Request.Header.Peek(fasthttp.HeaderAccept)
+
+	if mediaType, ok := Negotiate(accept, MediaTypes()...); ok {
+		return mediaType, true
+	}
+
+	// Only fall back to aliases once no canonical media type is acceptable, so clients listing both
+	// receive the canonical media type.
+	for _, r := range ParseAccept(accept) {
+		if c, ok := CodecFor(r.Type + "/" + r.Subtype); ok && r.Q > 0 {
+			return c.MediaType(), true
 		}
-	default:
-		{
-			if e := json.Unmarshal(ctx.Request.Body(), data); e != nil {
-				return e
-			} else {
-				return nil
-			}
+	}
+
+	return "", false
+}
+
+// Default unmarshaller for unmarshalling request bodies based on thier Content-Type header,
+// with the codec registered for the content type. Parameters of the content type such as
+// charset are ignored, and bodies without a content type are decoded as JSON. The body is
+// decoded within the limits of the request, see RequestDecodeLimits.
+//
+// The returned error is always an *apierror.Error: 415 if there is no codec for the content type,
+// 413 if the body is larger than allowed, or 400 if the body can't be decoded, with the path of
+// the field that couldn't be decoded as a detail if it is known.
+func UnmarshalBodyByContentHeader(ctx *fasthttp.RequestCtx, data object.Object) error {
+	contentType := string(ctx.Request.Header.ContentType())
+
+	mediaType := JSONMediaType
+	if contentType != "" {
+		parsed, _, e := mime.ParseMediaType(contentType)
+		if e != nil {
+			return apierror.UnsupportedMediaType(fmt.Sprintf("invalid content type %q", contentType)).WithCause(e)
 		}
+
+		mediaType = parsed
+	}
+
+	c, ok := CodecFor(mediaType)
+	if !ok {
+		return apierror.UnsupportedMediaType(fmt.Sprintf("content type %s is not supported", mediaType))
+	}
+
+	limits := RequestDecodeLimits(ctx)
+
+	body := ctx.Request.Body()
+	if limits.MaxBodySize > 0 && len(body) > limits.MaxBodySize {
+		return apierror.RequestEntityTooLarge(fmt.Sprintf("request body exceeds %d bytes", limits.MaxBodySize))
+	}
+
+	var e error
+	if limited, ok := c.(LimitedUnmarshaler); ok {
+		e = limited.UnmarshalLimited(body, data, limits)
+	} else {
+		e = c.Unmarshal(body, data)
+	}
+
+	if e != nil {
+		return decodeAPIError(e)
 	}
+
+	return nil
 }
 
 // Default marshaller to take interface and marshal it into the body of the response body.
-// Will marshal to the correct format depending on the "Accept" header, defaults to json.
+// The body is encoded with the media type most preferred by the client according to its
+// Accept header, see NegotiateResponseMediaType, defaulting to JSON if the client doesn't
+// specify any. If none of the accepted media types are supported, a 406 response is
+// returned instead.
 func MarshalBodyByAcceptHeader(ctx *fasthttp.RequestCtx, in object.Object) error {
-	switch string(ctx.Request.Header.Peek("Accept")) {
-	default:
-		{
-			if data, e := json.Marshal(&in); e != nil {
-				InternalErrorResponseHandler(ctx, e.Error())
-				return e
-			} else {
-				ctx.Response.SetBody(data)
-				ctx.Response.SetStatusCode(http.StatusOK)
-				return nil
-			}
-		}
-	case "application/yaml":
-		{
-			if data, e := yaml.Marshal(&in); e != nil {
-				InternalErrorResponseHandler(ctx, e.Error())
-				return e
-			} else {
-				ctx.Response.SetBody(data)
-				ctx.Response.SetStatusCode(http.StatusOK)
-				return nil
-			}
-		}
-	case "application/xml":
-		{
-			if data, e := xml.Marshal(&in); e != nil {
-				InternalErrorResponseHandler(ctx, e.Error())
-				return e
-			} else {
-				ctx.Response.SetBody(data)
-				ctx.Response.SetStatusCode(http.StatusOK)
-				return nil
-			}
-		}
-	case "application/protobuf":
-		{
-			if data, e := proto.Marshal(in); e != nil {
-				InternalErrorResponseHandler(ctx, e.Error())
-				return e
-			} else {
-				ctx.Response.SetBody(data)
-				ctx.Response.SetStatusCode(http.StatusOK)
-				return nil
-			}
-		}
+	addVary(ctx, fasthttp.HeaderAccept)
+
+	mediaType, ok := NegotiateResponseMediaType(ctx)
+	if !ok {
+		e := fmt.Errorf("none of the accepted media types %q are supported", ctx.Request.Header.Peek(fasthttp.HeaderAccept))
+		NotAcceptableResponseHandler(ctx, e.Error())
+		return e
 	}
+
+	return marshalBody(ctx, mediaType, in)
+}
+
+// marshalBody encodes the object as the body of the response with the codec of the media type,
+// falling back to JSON if there is none.
+func marshalBody(ctx *fasthttp.RequestCtx, mediaType string, in object.Object) error {
+	c, ok := CodecFor(mediaType)
+	if !ok {
+		c, _ = CodecFor(JSONMediaType)
+	}
+
+	data, e := c.Marshal(in)
+	if e != nil {
+		InternalErrorResponseHandler(ctx, e.Error())
+		return e
+	}
+
+	ctx.Response.Header.SetContentType(c.MediaType())
+	ctx.Response.SetBody(data)
+	ctx.Response.SetStatusCode(http.StatusOK)
+	return nil
 }
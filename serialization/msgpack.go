@@ -0,0 +1,442 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fire833/go-api-utils/object"
+)
+
+// MsgPackMediaType is the media type of MessagePack, see https://github.com/msgpack/msgpack/blob/master/spec.md.
+const MsgPackMediaType string = "application/msgpack"
+
+// The extension type of MessagePack timestamps, -1.
+const msgpackTimestampExt byte = 0xff
+
+// NewMsgPackCodec returns the codec for application/msgpack configured with opts. Objects are
+// encoded with the structure of their JSON encoding, with timestamps encoded as the timestamp
+// extension type.
+func NewMsgPackCodec(opts ProtoJSONOptions) Codec { return msgpackCodec{opts} }
+
+type msgpackCodec struct{ opts ProtoJSONOptions }
+
+func (msgpackCodec) MediaType() string { return MsgPackMediaType }
+func (msgpackCodec) Aliases() []string {
+	return []string{"application/x-msgpack", "application/vnd.msgpack"}
+}
+
+func (c msgpackCodec) Marshal(obj object.Object) ([]byte, error) {
+	v, e := c.opts.toValue(obj)
+	if e != nil {
+		return nil, e
+	}
+
+	return appendMsgPack(nil, v)
+}
+
+func (c msgpackCodec) Unmarshal(data []byte, obj object.Object) error {
+	return c.UnmarshalLimited(data, obj, DecodeLimits{})
+}
+
+func (c msgpackCodec) UnmarshalLimited(data []byte, obj object.Object, limits DecodeLimits) error {
+	r := bytes.NewReader(data)
+
+	v, e := readMsgPack(newValueReader(r, limits.maxDepth()))
+	if e != nil {
+		return unexpectedEOF(e)
+	}
+
+	if r.Len() > 0 {
+		return fmt.Errorf("msgpack: %d unexpected bytes after value", r.Len())
+	}
+
+	opts := c.opts.limited(limits)
+	if e := opts.fromValue(v, obj); e != nil {
+		return valueError(e, v, obj.ProtoReflect().Descriptor(), !opts.DiscardUnknown)
+	}
+
+	return nil
+}
+
+// NewEncoder returns an encoder writing each object as consecutive values.
+func (c msgpackCodec) NewEncoder(w io.Writer) Encoder { return msgpackEncoder{c.opts, w} }
+
+func (c msgpackCodec) NewDecoder(r io.Reader) Decoder {
+	return msgpackDecoder{c.opts, bufio.NewReader(r)}
+}
+
+type msgpackEncoder struct {
+	opts ProtoJSONOptions
+	w    io.Writer
+}
+
+func (e msgpackEncoder) Encode(obj object.Object) error {
+	v, err := e.opts.toValue(obj)
+	if err != nil {
+		return err
+	}
+
+	data, err := appendMsgPack(nil, v)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(data)
+	return err
+}
+
+type msgpackDecoder struct {
+	opts ProtoJSONOptions
+	r    *bufio.Reader
+}
+
+func (d msgpackDecoder) Decode(obj object.Object) error {
+	// Only the end of the stream between values is a clean EOF.
+	if _, e := d.r.Peek(1); e != nil {
+		return e
+	}
+
+	v, e := readMsgPack(newValueReader(d.r, 0))
+	if e != nil {
+		return unexpectedEOF(e)
+	}
+
+	return d.opts.fromValue(v, obj)
+}
+
+func appendMsgPack(b []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if v {
+			return append(b, 0xc3), nil
+		}
+
+		return append(b, 0xc2), nil
+	case int64:
+		return appendMsgPackInt(b, v), nil
+	case uint64:
+		return appendMsgPackUint(b, v), nil
+	case float32:
+		return binary.BigEndian.AppendUint32(append(b, 0xca), math.Float32bits(v)), nil
+	case float64:
+		return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(v)), nil
+	case string:
+		b = appendMsgPackHeader(b, len(v), 0xa0, 31, 0xd9)
+		return append(b, v...), nil
+	case []byte:
+		b = appendMsgPackHeader(b, len(v), 0, 0, 0xc4)
+		return append(b, v...), nil
+	case time.Time:
+		return appendMsgPackTime(b, v), nil
+	case []any:
+		b = appendMsgPackHeader(b, len(v), 0x90, 15, 0xdc)
+
+		for _, item := range v {
+			var e error
+			if b, e = appendMsgPack(b, item); e != nil {
+				return nil, e
+			}
+		}
+
+		return b, nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		m := make(valueMap, 0, len(keys))
+		for _, k := range keys {
+			m = append(m, valueMapEntry{k, v[k]})
+		}
+
+		return appendMsgPack(b, m)
+	case valueMap:
+		b = appendMsgPackHeader(b, len(v), 0x80, 15, 0xde)
+
+		for _, entry := range v {
+			var e error
+			if b, e = appendMsgPack(b, entry.Key); e != nil {
+				return nil, e
+			}
+
+			if b, e = appendMsgPack(b, entry.Value); e != nil {
+				return nil, e
+			}
+		}
+
+		return b, nil
+	default:
+		return nil, fmt.Errorf("msgpack: unsupported value of type %T", v)
+	}
+}
+
+func appendMsgPackInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0:
+		return appendMsgPackUint(b, uint64(i))
+	case i >= -32:
+		return append(b, byte(i))
+	case i >= math.MinInt8:
+		return append(b, 0xd0, byte(i))
+	case i >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(i))
+	case i >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(i))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(i))
+	}
+}
+
+func appendMsgPackUint(b []byte, u uint64) []byte {
+	switch {
+	case u <= math.MaxInt8:
+		return append(b, byte(u))
+	case u <= math.MaxUint8:
+		return append(b, 0xcc, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(u))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), u)
+	}
+}
+
+// appendMsgPackHeader appends the header of a string, binary, array or map of length n. fix is the
+// first byte of the fixed length format, which is used for lengths up to fixMax if fix isn't 0,
+// and first is the first byte of the 8 bit length format, or 16 bit length format if the type has
+// no 8 bit length format. Each following format is the next byte.
+func appendMsgPackHeader(b []byte, n int, fix byte, fixMax int, first byte) []byte {
+	hasUint8 := first == 0xd9 || first == 0xc4
+
+	switch {
+	case fix != 0 && n <= fixMax:
+		return append(b, fix|byte(n))
+	case hasUint8 && n <= math.MaxUint8:
+		return append(b, first, byte(n))
+	case hasUint8 && n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, first+1), uint16(n))
+	case hasUint8:
+		return binary.BigEndian.AppendUint32(append(b, first+2), uint32(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, first), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(b, first+1), uint32(n))
+	}
+}
+
+// appendMsgPackTime appends t with the smallest timestamp format that can represent it.
+func appendMsgPackTime(b []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond())
+
+	switch {
+	case sec >= 0 && sec <= math.MaxUint32 && nsec == 0:
+		return binary.BigEndian.AppendUint32(append(b, 0xd6, msgpackTimestampExt), uint32(sec))
+	case sec >= 0 && sec < 1<<34:
+		return binary.BigEndian.AppendUint64(append(b, 0xd7, msgpackTimestampExt), nsec<<34|uint64(sec))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xc7, 12, msgpackTimestampExt), uint32(nsec))
+		return binary.BigEndian.AppendUint64(b, uint64(sec))
+	}
+}
+
+// readMsgPack decodes a single value, with maps decoded as map[string]any.
+func readMsgPack(r *valueReader) (any, error) {
+	c, e := r.ReadByte()
+	if e != nil {
+		return nil, e
+	}
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xe0 == 0xa0:
+		return readMsgPackString(r, int(c&0x1f))
+	case c&0xf0 == 0x90:
+		return readMsgPackArray(r, int(c&0x0f))
+	case c&0xf0 == 0x80:
+		return readMsgPackMap(r, int(c&0x0f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return readUint(r, 1<<(c-0xcc))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		u, e := readUint(r, 1<<(c-0xd0))
+		if e != nil {
+			return nil, e
+		}
+
+		// Sign extend from the size of the integer.
+		shift := 64 - 8*(1<<(c-0xd0))
+		return int64(u<<shift) >> shift, nil
+	case 0xca:
+		u, e := readUint(r, 4)
+		return float64(math.Float32frombits(uint32(u))), e
+	case 0xcb:
+		u, e := readUint(r, 8)
+		return math.Float64frombits(u), e
+	case 0xd9, 0xda, 0xdb:
+		n, e := readUint(r, 1<<(c-0xd9))
+		if e != nil {
+			return nil, e
+		}
+
+		return readMsgPackString(r, n)
+	case 0xc4, 0xc5, 0xc6:
+		n, e := readUint(r, 1<<(c-0xc4))
+		if e != nil {
+			return nil, e
+		}
+
+		return readBytes(r, n)
+	case 0xdc, 0xdd:
+		n, e := readUint(r, 2<<(c-0xdc))
+		if e != nil {
+			return nil, e
+		}
+
+		return readMsgPackArray(r, int(n))
+	case 0xde, 0xdf:
+		n, e := readUint(r, 2<<(c-0xde))
+		if e != nil {
+			return nil, e
+		}
+
+		return readMsgPackMap(r, int(n))
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return readMsgPackExt(r, 1<<(c-0xd4))
+	case 0xc7, 0xc8, 0xc9:
+		n, e := readUint(r, 1<<(c-0xc7))
+		if e != nil {
+			return nil, e
+		}
+
+		return readMsgPackExt(r, n)
+	default:
+		return nil, fmt.Errorf("msgpack: invalid type byte 0x%02x", c)
+	}
+}
+
+func readMsgPackString[N int | uint64](r *valueReader, n N) (any, error) {
+	b, e := readBytes(r, n)
+	if e != nil {
+		return nil, e
+	}
+
+	return string(b), nil
+}
+
+func readMsgPackArray(r *valueReader, n int) (any, error) {
+	if e := r.nest(); e != nil {
+		return nil, e
+	}
+	defer r.unnest()
+
+	out := make([]any, 0, min(n, 1024))
+
+	for i := 0; i < n; i++ {
+		v, e := readMsgPack(r)
+		if e != nil {
+			return nil, e
+		}
+
+		out = append(out, v)
+	}
+
+	return out, nil
+}
+
+func readMsgPackMap(r *valueReader, n int) (any, error) {
+	if e := r.nest(); e != nil {
+		return nil, e
+	}
+	defer r.unnest()
+
+	out := make(map[string]any, min(n, 1024))
+
+	for i := 0; i < n; i++ {
+		k, e := readMsgPack(r)
+		if e != nil {
+			return nil, e
+		}
+
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("msgpack: map key of type %T is not a string", k)
+		}
+
+		if out[key], e = readMsgPack(r); e != nil {
+			return nil, e
+		}
+	}
+
+	return out, nil
+}
+
+// readMsgPackExt decodes an extension value with n bytes of data. Timestamps are the only
+// supported extension type.
+func readMsgPackExt[N int | uint64](r *valueReader, n N) (any, error) {
+	typ, e := r.ReadByte()
+	if e != nil {
+		return nil, e
+	}
+
+	if typ != msgpackTimestampExt {
+		return nil, fmt.Errorf("msgpack: unsupported extension type %d", int8(typ))
+	}
+
+	data, e := readBytes(r, n)
+	if e != nil {
+		return nil, e
+	}
+
+	switch len(data) {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+	case 8:
+		u := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(u&(1<<34-1)), int64(u>>34)).UTC(), nil
+	case 12:
+		return time.Unix(int64(binary.BigEndian.Uint64(data[4:])), int64(binary.BigEndian.Uint32(data))).UTC(), nil
+	default:
+		return nil, fmt.Errorf("msgpack: invalid timestamp of %d bytes", len(data))
+	}
+}
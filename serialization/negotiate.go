@@ -0,0 +1,155 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// MediaRange is a single media range of an Accept header, ie application/* or application/json.
+type MediaRange struct {
+	// The type and subtype of the range, either of which may be *.
+	Type    string
+	Subtype string
+
+	// Parameters of the range other than its quality.
+	Params map[string]string
+
+	// The quality (q) of the range between 0 and 1, where 0 means not acceptable.
+	Q float64
+}
+
+// Matches returns whether the media type is within the range.
+func (r MediaRange) Matches(mediaType string) bool {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	return (r.Type == "*" || r.Type == typ) && (r.Subtype == "*" || r.Subtype == subtype)
+}
+
+// specificity orders ranges from */* to exact media types, so the most specific matching range
+// of a media type determines its quality.
+func (r MediaRange) specificity() int {
+	switch {
+	case r.Type == "*":
+		return 0
+	case r.Subtype == "*":
+		return 1
+	default:
+		return 2 + len(r.Params)
+	}
+}
+
+// ParseAccept parses the media ranges of an Accept header, in order of decreasing quality. Ranges
+// that can't be parsed are ignored, and types and parameter names are lowercased.
+func ParseAccept(accept []byte) []MediaRange {
+	ranges := []MediaRange{}
+
+	for _, part := range bytes.Split(accept, []byte(",")) {
+		params := strings.Split(string(part), ";")
+		typ, subtype, ok := strings.Cut(strings.ToLower(strings.TrimSpace(params[0])), "/")
+		if !ok || typ == "" || subtype == "" || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		r := MediaRange{Type: typ, Subtype: subtype, Q: 1}
+		for _, param := range params[1:] {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok {
+				continue
+			}
+
+			key = strings.ToLower(strings.TrimSpace(key))
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+
+			if key == "q" {
+				if q, e := strconv.ParseFloat(value, 64); e == nil && q >= 0 && q <= 1 {
+					r.Q = q
+				}
+
+				// Parameters after the quality are accept extensions, not media type parameters.
+				break
+			}
+
+			if r.Params == nil {
+				r.Params = map[string]string{}
+			}
+
+			r.Params[key] = value
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].Q > ranges[j].Q })
+	return ranges
+}
+
+// Negotiate returns the offered media type most preferred by the client according to its Accept
+// header, or false if none of the offers are acceptable. The quality of each offer is that of the
+// most specific range matching it, and offers of equal quality are preferred in the order they
+// are offered. All offers are acceptable if the header is empty or none of its ranges can be
+// parsed, such as the bare * sent by some legacy clients.
+func Negotiate(accept []byte, offers ...string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+
+	ranges := ParseAccept(accept)
+	if len(ranges) == 0 {
+		return offers[0], true
+	}
+
+	best, bestQ := "", 0.0
+
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			if r.Matches(offer) && r.specificity() > specificity {
+				q, specificity = r.Q, r.specificity()
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+
+	return best, best != ""
+}
+
+// addVary adds the header to the Vary header of the response, unless it's already listed.
+func addVary(ctx *fasthttp.RequestCtx, header string) {
+	vary := ctx.Response.Header.Peek(fasthttp.HeaderVary)
+	for _, field := range bytes.Split(vary, []byte(",")) {
+		if strings.EqualFold(string(bytes.TrimSpace(field)), header) {
+			return
+		}
+	}
+
+	if len(vary) == 0 {
+		ctx.Response.Header.Set(fasthttp.HeaderVary, header)
+		return
+	}
+
+	ctx.Response.Header.Set(fasthttp.HeaderVary, string(vary)+", "+header)
+}
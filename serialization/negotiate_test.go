@@ -0,0 +1,137 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/valyala/fasthttp"
+)
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{JSONMediaType, YAMLMediaType, XMLMediaType}
+
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", JSONMediaType},
+		{"*/*", JSONMediaType},
+		{"application/yaml", YAMLMediaType},
+		{"application/yaml; charset=utf-8", YAMLMediaType},
+		{"APPLICATION/XML", XMLMediaType},
+		{"application/json, text/plain;q=0.9", JSONMediaType},
+		{"text/plain, application/*;q=0.5", JSONMediaType},
+		{"application/json;q=0.4, application/xml;q=0.6, */*;q=0.1", XMLMediaType},
+		{"application/*, application/json;q=0", YAMLMediaType},
+		{"application/yaml;q=0.8;foo=bar, application/xml;q=0.8", YAMLMediaType},
+		{"text/html", ""},
+		{"application/json;q=0", ""},
+		{"*", JSONMediaType},
+		{"garbage", JSONMediaType},
+		{" , ", JSONMediaType},
+	}
+
+	for _, tt := range tests {
+		got, ok := Negotiate([]byte(tt.accept), offers...)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("Negotiate(%q) = %q, %v, want %q", tt.accept, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParseAccept(t *testing.T) {
+	ranges := ParseAccept([]byte(`text/*;q=0.3, text/html;level=1, */*;q=0.5, text/html;q=0.7;ext=1`))
+	if len(ranges) != 4 {
+		t.Fatalf("got %d ranges, want 4", len(ranges))
+	}
+
+	if r := ranges[0]; r.Type != "text" || r.Subtype != "html" || r.Params["level"] != "1" || r.Q != 1 {
+		t.Errorf("first range = %+v, want text/html;level=1", r)
+	}
+
+	if r := ranges[1]; r.Subtype != "html" || r.Q != 0.7 || r.Params != nil {
+		t.Errorf("second range = %+v, want text/html;q=0.7 without parameters", r)
+	}
+}
+
+func TestMarshalBodyByAcceptHeader(t *testing.T) {
+	tests := []struct {
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"", 200, JSONMediaType},
+		{"application/yaml; charset=utf-8", 200, YAMLMediaType},
+		{"application/x-protobuf", 200, ProtobufMediaType},
+		{"text/html;q=0.9, application/xml;q=0.5", 200, XMLMediaType},
+		{"text/html", 406, JSONMediaType},
+	}
+
+	for _, tt := range tests {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.Set(fasthttp.HeaderAccept, tt.accept)
+
+		e := MarshalBodyByAcceptHeader(ctx, &OKResponse{Message: "hello"})
+		if (e != nil) != (tt.status != 200) {
+			t.Errorf("MarshalBodyByAcceptHeader(%q) = %v", tt.accept, e)
+		}
+
+		if ctx.Response.StatusCode() != tt.status || string(ctx.Response.Header.ContentType()) != tt.contentType {
+			t.Errorf("Accept %q = %d %s, want %d %s", tt.accept, ctx.Response.StatusCode(),
+				ctx.Response.Header.ContentType(), tt.status, tt.contentType)
+		}
+
+		if vary := string(ctx.Response.Header.Peek(fasthttp.HeaderVary)); vary != "Accept" {
+			t.Errorf("Vary = %q, want Accept", vary)
+		}
+	}
+}
+
+func TestUnmarshalBodyByContentHeader(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		status      int
+	}{
+		{"", `{"message":"hello"}`, 0},
+		{"application/json; charset=utf-8", `{"message":"hello"}`, 0},
+		{"application/x-yaml", "message: hello", 0},
+		{"text/plain", "hello", http.StatusUnsupportedMediaType},
+		{"application/json; charset", "hello", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetContentType(tt.contentType)
+		ctx.Request.SetBodyString(tt.body)
+
+		resp := &OKResponse{}
+		e := UnmarshalBodyByContentHeader(ctx, resp)
+		if tt.status == 0 && (e != nil || resp.Message != "hello") {
+			t.Errorf("Content-Type %q = %v, %q", tt.contentType, e, resp.Message)
+		}
+
+		if tt.status != 0 && apierror.StatusOf(e) != tt.status {
+			t.Errorf("Content-Type %q = %v, want status %d", tt.contentType, e, tt.status)
+		}
+	}
+}
@@ -20,6 +20,9 @@ package serialization
 
 import "strconv"
 
+// Parse functions parse single parameter values, with range checking of the bit size of the
+// returned type. See Bind for binding every parameter of a request at once.
+
 func Parsestring(in []byte) (string, error) {
 	return string(in), nil
 }
@@ -33,28 +36,26 @@ func Parseint(in []byte) (int, error) {
 }
 
 func Parseint32(in []byte) (int32, error) {
-	i, e := strconv.Atoi(string(in))
+	i, e := strconv.ParseInt(string(in), 10, 32)
 	return int32(i), e
 }
 
 func Parseint64(in []byte) (int64, error) {
-	i, e := strconv.Atoi(string(in))
-	return int64(i), e
+	return strconv.ParseInt(string(in), 10, 64)
 }
 
 func Parseuint(in []byte) (uint, error) {
-	i, e := Parseint(in)
-	return uint(i), e
+	u, e := strconv.ParseUint(string(in), 10, strconv.IntSize)
+	return uint(u), e
 }
 
 func Parseuint32(in []byte) (uint32, error) {
-	i, e := strconv.Atoi(string(in))
-	return uint32(i), e
+	u, e := strconv.ParseUint(string(in), 10, 32)
+	return uint32(u), e
 }
 
 func Parseuint64(in []byte) (uint64, error) {
-	i, e := strconv.Atoi(string(in))
-	return uint64(i), e
+	return strconv.ParseUint(string(in), 10, 64)
 }
 
 func Parsefloat32(in []byte) (float32, error) {
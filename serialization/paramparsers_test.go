@@ -40,3 +40,21 @@ func FuzzParseint(f *testing.F) {
 		}
 	})
 }
+
+func TestParseRange(t *testing.T) {
+	if _, e := Parseint32([]byte("2147483648")); e == nil {
+		t.Errorf("Parseint32() overflowed without an error")
+	}
+
+	if _, e := Parseuint32([]byte("4294967296")); e == nil {
+		t.Errorf("Parseuint32() overflowed without an error")
+	}
+
+	if _, e := Parseuint64([]byte("-1")); e == nil {
+		t.Errorf("Parseuint64() parsed a negative number")
+	}
+
+	if u, e := Parseuint64([]byte("18446744073709551615")); e != nil || u != 18446744073709551615 {
+		t.Errorf("Parseuint64() = %d, %v", u, e)
+	}
+}
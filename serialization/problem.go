@@ -0,0 +1,299 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// Media types of RFC 9457 problem details.
+const (
+	ProblemJSONMediaType string = "application/problem+json"
+	ProblemXMLMediaType  string = "application/problem+xml"
+)
+
+// Namespace of problem details encoded as XML.
+const problemXMLNamespace string = "urn:ietf:rfc:7807"
+
+// Problem is an RFC 9457 problem details object, an alternative to GenericErrorResponse that
+// error responses are encoded as when the client accepts application/problem+json or
+// application/problem+xml.
+type Problem struct {
+	// A URI reference identifying the problem type, about:blank if the problem has no
+	// semantics beyond the status code.
+	Type string `json:"type,omitempty"`
+
+	// A short summary of the problem type.
+	Title string `json:"title,omitempty"`
+
+	// The HTTP status code of the response.
+	Status int `json:"status,omitempty"`
+
+	// A description specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+
+	// A URI reference identifying this occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+
+	// Extension members of the problem, which are encoded alongside the standard members.
+	// Keys of the standard members are ignored.
+	Extensions map[string]any `json:"-"`
+}
+
+// NewProblem returns the problem describing an error response.
+func NewProblem(resp *GenericErrorResponse, instance string) *Problem {
+	p := &Problem{
+		Type:       "about:blank",
+		Title:      resp.Error,
+		Status:     int(resp.Code),
+		Detail:     resp.Description,
+		Instance:   instance,
+		Extensions: map[string]any{},
+	}
+
+	if resp.ErrorCode != "" {
+		p.Extensions["error_code"] = resp.ErrorCode
+	}
+
+	if len(resp.Details) > 0 {
+		p.Extensions["details"] = resp.Details
+	}
+
+	if resp.RequestId != "" {
+		p.Extensions["request_id"] = resp.RequestId
+	}
+
+	if resp.Timestamp != nil {
+		p.Extensions["timestamp"] = resp.Timestamp.AsTime().Format(time.RFC3339Nano)
+	}
+
+	return p
+}
+
+var problemMembers = map[string]bool{"type": true, "title": true, "status": true, "detail": true, "instance": true}
+
+// extensions returns the extension members of the problem as they are encoded as JSON.
+func (p *Problem) extensions() (map[string]any, error) {
+	if len(p.Extensions) == 0 {
+		return nil, nil
+	}
+
+	ext := make(map[string]any, len(p.Extensions))
+	for key, value := range p.Extensions {
+		if !problemMembers[key] {
+			ext[key] = value
+		}
+	}
+
+	data, e := json.Marshal(ext)
+	if e != nil {
+		return nil, e
+	}
+
+	ext = map[string]any{}
+	return ext, json.Unmarshal(data, &ext)
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	data, e := json.Marshal((*problem)(p))
+	if e != nil {
+		return nil, e
+	}
+
+	ext, e := p.extensions()
+	if e != nil || len(ext) == 0 {
+		return data, e
+	}
+
+	members := map[string]json.RawMessage{}
+	if e := json.Unmarshal(data, &members); e != nil {
+		return nil, e
+	}
+
+	for key, value := range ext {
+		raw, e := json.Marshal(value)
+		if e != nil {
+			return nil, e
+		}
+
+		members[key] = raw
+	}
+
+	// Maps are encoded with sorted keys, so the output is stable.
+	return json.Marshal(members)
+}
+
+// MarshalXML encodes the problem as described by appendix B of RFC 9457. Extension members are
+// encoded as child elements, with arrays encoded as a sequence of <i> elements.
+func (p *Problem) MarshalXML(enc *xml.Encoder, _ xml.StartElement) error {
+	ext, e := p.extensions()
+	if e != nil {
+		return e
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: "problem"}, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: problemXMLNamespace}}}
+	if e := enc.EncodeToken(start); e != nil {
+		return e
+	}
+
+	members := []struct {
+		name  string
+		value any
+	}{{"type", p.Type}, {"title", p.Title}, {"status", p.Status}, {"detail", p.Detail}, {"instance", p.Instance}}
+
+	for _, member := range members {
+		if member.value == "" || member.value == 0 {
+			continue
+		}
+
+		if e := encodeProblemXML(enc, member.name, member.value); e != nil {
+			return e
+		}
+	}
+
+	keys := make([]string, 0, len(ext))
+	for key := range ext {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if e := encodeProblemXML(enc, key, ext[key]); e != nil {
+			return e
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+// encodeProblemXML encodes a value decoded from JSON as an element.
+func encodeProblemXML(enc *xml.Encoder, name string, value any) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if e := enc.EncodeToken(start); e != nil {
+		return e
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+		for _, key := range keys {
+			if e := encodeProblemXML(enc, key, v[key]); e != nil {
+				return e
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if e := encodeProblemXML(enc, "i", item); e != nil {
+				return e
+			}
+		}
+	case nil:
+	case string:
+		if e := enc.EncodeToken(xml.CharData(v)); e != nil {
+			return e
+		}
+	case int:
+		if e := enc.EncodeToken(xml.CharData(strconv.Itoa(v))); e != nil {
+			return e
+		}
+	case float64:
+		if e := enc.EncodeToken(xml.CharData(strconv.FormatFloat(v, 'f', -1, 64))); e != nil {
+			return e
+		}
+	case bool:
+		if e := enc.EncodeToken(xml.CharData(strconv.FormatBool(v))); e != nil {
+			return e
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+// problemMediaType returns the problem media type the client prefers according to its Accept
+// header, or an empty string if it doesn't accept either or prefers the media type of a registered
+// codec. Clients must explicitly list a problem media type, wildcards aren't taken to accept them.
+func problemMediaType(accept []byte) string {
+	var problems []string
+	offers := MediaTypes()
+
+	for _, r := range ParseAccept(accept) {
+		mediaType := r.Type + "/" + r.Subtype
+		if mediaType == ProblemJSONMediaType || mediaType == ProblemXMLMediaType {
+			problems = append(problems, mediaType)
+		} else if _, ok := CodecFor(mediaType); ok {
+			// Aliases are offered as well, as NegotiateResponseMediaType accepts them.
+			offers = append(offers, mediaType)
+		}
+	}
+
+	if len(problems) == 0 {
+		return ""
+	}
+
+	// Problem media types are offered first so they are preferred over codecs with the same quality.
+	if mediaType, ok := Negotiate(accept, append(problems, offers...)...); ok && (mediaType == ProblemJSONMediaType || mediaType == ProblemXMLMediaType) {
+		return mediaType
+	}
+
+	return ""
+}
+
+// problemResponseHandler responds with the error response as a problem if the client accepts
+// problem details, returning false if it doesn't.
+func problemResponseHandler(ctx *fasthttp.RequestCtx, resp *GenericErrorResponse) bool {
+	mediaType := problemMediaType(ctx.Request.Header.Peek(fasthttp.HeaderAccept))
+	if mediaType == "" {
+		return false
+	}
+
+	p := NewProblem(resp, string(ctx.Request.URI().Path()))
+
+	var (
+		data []byte
+		e    error
+	)
+
+	if mediaType == ProblemXMLMediaType {
+		data, e = xml.Marshal(p)
+		data = append([]byte(xml.Header), data...)
+	} else {
+		data, e = json.Marshal(p)
+	}
+
+	if e != nil {
+		return false
+	}
+
+	ctx.Response.Header.SetContentType(mediaType)
+	ctx.Response.SetBody(data)
+	ctx.SetStatusCode(p.Status)
+	return true
+}
@@ -0,0 +1,156 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/valyala/fasthttp"
+)
+
+func TestProblemMediaType(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ""},
+		{"application/json", ""},
+		{"*/*", ""},
+		{"application/problem+json", ProblemJSONMediaType},
+		{"application/json, Application/Problem+XML", ProblemXMLMediaType},
+		{"application/problem+json;q=0.5, application/problem+xml;q=0.8", ProblemXMLMediaType},
+		{"application/problem+json; charset=utf-8", ProblemJSONMediaType},
+		{"application/problem+json;q=0", ""},
+		{"application/json, application/problem+json;q=0.1", ""},
+		{"application/problem+json;q=0.5, */*", ""},
+		{"text/xml, application/problem+xml;q=0.9", ""},
+		{"application/problem+json, application/json", ProblemJSONMediaType},
+		{"application/json;q=0.5, application/problem+xml;q=0.8", ProblemXMLMediaType},
+	}
+
+	for _, tt := range tests {
+		if got := problemMediaType([]byte(tt.accept)); got != tt.want {
+			t.Errorf("problemMediaType(%q) = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestProblemResponse(t *testing.T) {
+	newCtx := func(accept string) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.SetRequestURI("/objects/abc")
+		ctx.Request.Header.Set(fasthttp.HeaderAccept, accept)
+		ctx.Response.Header.Set("X-Request-ID", "req1")
+		return ctx
+	}
+
+	err := apierror.UnprocessableEntity("object is invalid").WithField("name", "must be set")
+
+	t.Run("json", func(t *testing.T) {
+		ctx := newCtx("application/problem+json")
+		APIErrorResponseHandler(ctx, err)
+
+		if ctx.Response.StatusCode() != 422 || string(ctx.Response.Header.ContentType()) != ProblemJSONMediaType {
+			t.Fatalf("response = %d %s", ctx.Response.StatusCode(), ctx.Response.Header.ContentType())
+		}
+
+		p := map[string]any{}
+		if e := json.Unmarshal(ctx.Response.Body(), &p); e != nil {
+			t.Fatal(e)
+		}
+
+		want := map[string]any{
+			"type":       "about:blank",
+			"title":      "Unprocessable Entity",
+			"status":     float64(422),
+			"detail":     "object is invalid",
+			"instance":   "/objects/abc",
+			"error_code": "unprocessable_entity",
+			"request_id": "req1",
+		}
+
+		for key, value := range want {
+			if p[key] != value {
+				t.Errorf("%s = %v, want %v", key, p[key], value)
+			}
+		}
+
+		if details, ok := p["details"].([]any); !ok || len(details) != 1 || details[0].(map[string]any)["field"] != "name" {
+			t.Errorf("details = %v", p["details"])
+		}
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		ctx := newCtx("application/problem+xml")
+		NotFoundResponseHandler(ctx, "object abc was not found")
+
+		if ctx.Response.StatusCode() != 404 || string(ctx.Response.Header.ContentType()) != ProblemXMLMediaType {
+			t.Fatalf("response = %d %s", ctx.Response.StatusCode(), ctx.Response.Header.ContentType())
+		}
+
+		p := struct {
+			XMLName   xml.Name
+			Title     string `xml:"title"`
+			Status    int    `xml:"status"`
+			Detail    string `xml:"detail"`
+			RequestID string `xml:"request_id"`
+		}{}
+
+		if e := xml.Unmarshal(ctx.Response.Body(), &p); e != nil {
+			t.Fatal(e)
+		}
+
+		if p.XMLName.Space != problemXMLNamespace || p.XMLName.Local != "problem" || p.Title != "Not Found" ||
+			p.Status != 404 || p.Detail != "object abc was not found" || p.RequestID != "req1" {
+			t.Errorf("problem = %+v", p)
+		}
+	})
+
+	t.Run("generic", func(t *testing.T) {
+		ctx := newCtx("application/json")
+		NotFoundResponseHandler(ctx, "object abc was not found")
+
+		if strings.Contains(string(ctx.Response.Body()), "about:blank") {
+			t.Errorf("problem returned without being accepted: %s", ctx.Response.Body())
+		}
+	})
+}
+
+func TestProblemExtensionsXML(t *testing.T) {
+	p := &Problem{Type: "about:blank", Status: 400, Extensions: map[string]any{
+		"status":  "ignored",
+		"invalid": []any{map[string]any{"name": "age", "reason": "must be positive"}},
+		"balance": 30,
+	}}
+
+	data, e := xml.Marshal(p)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	want := `<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><status>400</status><balance>30</balance>` +
+		`<invalid><i><name>age</name><reason>must be positive</reason></i></invalid></problem>`
+	if string(data) != want {
+		t.Errorf("xml.Marshal() = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,263 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/fire833/go-api-utils/object"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+// ProtoJSONOptions configures how objects are encoded as and decoded from JSON, and YAML, which
+// is bridged through JSON. Objects are encoded with the protobuf JSON mapping, so field names
+// follow the json_name option, well-known types use their JSON representation (ie timestamps as
+// RFC 3339 strings) and oneofs are encoded as the field that is set.
+type ProtoJSONOptions struct {
+	// Encode fields with their zero value, rather than omitting them.
+	EmitUnpopulated bool
+
+	// Encode enums as their numbers rather than their names.
+	UseEnumNumbers bool
+
+	// Encode fields with their original names within the .proto file rather than their JSON names.
+	UseProtoNames bool
+
+	// Ignore fields that aren't part of the object when decoding, rather than returning an error.
+	DiscardUnknown bool
+}
+
+// limited returns the options to decode with under limits.
+func (o ProtoJSONOptions) limited(limits DecodeLimits) ProtoJSONOptions {
+	if limits.DisallowUnknownFields {
+		o.DiscardUnknown = false
+	}
+
+	return o
+}
+
+func (o ProtoJSONOptions) marshal(obj object.Object) ([]byte, error) {
+	return protojson.MarshalOptions{
+		EmitUnpopulated: o.EmitUnpopulated,
+		UseEnumNumbers:  o.UseEnumNumbers,
+		UseProtoNames:   o.UseProtoNames,
+	}.Marshal(obj)
+}
+
+func (o ProtoJSONOptions) unmarshal(data []byte, obj object.Object) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: o.DiscardUnknown}.Unmarshal(data, obj)
+}
+
+// NewJSONCodec returns the codec for application/json configured with opts.
+func NewJSONCodec(opts ProtoJSONOptions) Codec { return protoJSONCodec{opts} }
+
+type protoJSONCodec struct{ opts ProtoJSONOptions }
+
+func (protoJSONCodec) MediaType() string { return JSONMediaType }
+func (protoJSONCodec) Aliases() []string { return []string{"text/json"} }
+
+func (c protoJSONCodec) Marshal(obj object.Object) ([]byte, error) { return c.opts.marshal(obj) }
+
+func (c protoJSONCodec) Unmarshal(data []byte, obj object.Object) error {
+	return c.opts.unmarshal(data, obj)
+}
+
+func (c protoJSONCodec) UnmarshalLimited(data []byte, obj object.Object, limits DecodeLimits) error {
+	if e := checkJSONDepth(data, limits.maxDepth()); e != nil {
+		return e
+	}
+
+	opts := c.opts.limited(limits)
+	if e := opts.unmarshal(data, obj); e != nil {
+		return jsonError(e, data, obj.ProtoReflect().Descriptor(), !opts.DiscardUnknown)
+	}
+
+	return nil
+}
+
+// NewEncoder returns an encoder writing each object on its own line.
+func (c protoJSONCodec) NewEncoder(w io.Writer) Encoder { return protoJSONEncoder{c.opts, w} }
+
+func (c protoJSONCodec) NewDecoder(r io.Reader) Decoder {
+	return protoJSONDecoder{c.opts, json.NewDecoder(r)}
+}
+
+type protoJSONEncoder struct {
+	opts ProtoJSONOptions
+	w    io.Writer
+}
+
+func (e protoJSONEncoder) Encode(obj object.Object) error {
+	data, err := e.opts.marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	// protojson output is deliberately unstable, so compact it to keep a single object per line.
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)+1))
+	if err := json.Compact(buf, data); err != nil {
+		return err
+	}
+
+	buf.WriteByte('\n')
+	_, err = e.w.Write(buf.Bytes())
+	return err
+}
+
+type protoJSONDecoder struct {
+	opts ProtoJSONOptions
+	dec  *json.Decoder
+}
+
+func (d protoJSONDecoder) Decode(obj object.Object) error {
+	var raw json.RawMessage
+	if e := d.dec.Decode(&raw); e != nil {
+		return e
+	}
+
+	return d.opts.unmarshal(raw, obj)
+}
+
+// NewYAMLCodec returns the codec for application/yaml configured with opts. Objects are bridged
+// through their JSON encoding, so are encoded the same as they would be as JSON.
+func NewYAMLCodec(opts ProtoJSONOptions) Codec { return protoYAMLCodec{opts} }
+
+type protoYAMLCodec struct{ opts ProtoJSONOptions }
+
+func (protoYAMLCodec) MediaType() string { return YAMLMediaType }
+func (protoYAMLCodec) Aliases() []string { return []string{"application/x-yaml", "text/yaml"} }
+
+func (c protoYAMLCodec) Marshal(obj object.Object) ([]byte, error) {
+	node, e := c.opts.yamlNode(obj)
+	if e != nil {
+		return nil, e
+	}
+
+	return yaml.Marshal(node)
+}
+
+func (c protoYAMLCodec) Unmarshal(data []byte, obj object.Object) error {
+	var v any
+	if e := yaml.Unmarshal(data, &v); e != nil {
+		return e
+	}
+
+	return c.opts.unmarshalYAMLValue(v, obj)
+}
+
+// UnmarshalLimited checks the nesting and aliases of the document before expanding it.
+func (c protoYAMLCodec) UnmarshalLimited(data []byte, obj object.Object, limits DecodeLimits) error {
+	node := &yaml.Node{}
+	if e := yaml.Unmarshal(data, node); e != nil {
+		return e
+	}
+
+	if e := checkYAMLNode(node, limits); e != nil {
+		return e
+	}
+
+	var v any
+	if e := node.Decode(&v); e != nil {
+		return e
+	}
+
+	opts := c.opts.limited(limits)
+	if e := opts.unmarshalYAMLValue(v, obj); e != nil {
+		return valueError(e, v, obj.ProtoReflect().Descriptor(), !opts.DiscardUnknown)
+	}
+
+	return nil
+}
+
+// NewEncoder returns an encoder writing each object as a separate document.
+func (c protoYAMLCodec) NewEncoder(w io.Writer) Encoder {
+	return protoYAMLEncoder{c.opts, yaml.NewEncoder(w)}
+}
+
+func (c protoYAMLCodec) NewDecoder(r io.Reader) Decoder {
+	return protoYAMLDecoder{c.opts, yaml.NewDecoder(r)}
+}
+
+type protoYAMLEncoder struct {
+	opts ProtoJSONOptions
+	enc  *yaml.Encoder
+}
+
+func (e protoYAMLEncoder) Encode(obj object.Object) error {
+	node, err := e.opts.yamlNode(obj)
+	if err != nil {
+		return err
+	}
+
+	return e.enc.Encode(node)
+}
+
+type protoYAMLDecoder struct {
+	opts ProtoJSONOptions
+	dec  *yaml.Decoder
+}
+
+func (d protoYAMLDecoder) Decode(obj object.Object) error {
+	var v any
+	if e := d.dec.Decode(&v); e != nil {
+		return e
+	}
+
+	return d.opts.unmarshalYAMLValue(v, obj)
+}
+
+// yamlNode returns the YAML document of the object's JSON encoding.
+func (o ProtoJSONOptions) yamlNode(obj object.Object) (*yaml.Node, error) {
+	data, e := o.marshal(obj)
+	if e != nil {
+		return nil, e
+	}
+
+	node := &yaml.Node{}
+	if e := yaml.Unmarshal(data, node); e != nil {
+		return nil, e
+	}
+
+	clearYAMLStyle(node)
+	return node, nil
+}
+
+// clearYAMLStyle clears the flow and quoting styles nodes decoded from JSON are given, so they are
+// encoded as regular YAML. Scalars keep their tags, so strings are still quoted wherever they
+// would otherwise be decoded as another type.
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
+
+// unmarshalYAMLValue decodes a value decoded from YAML into the object through its JSON encoding.
+func (o ProtoJSONOptions) unmarshalYAMLValue(v any, obj object.Object) error {
+	data, e := json.Marshal(v)
+	if e != nil {
+		return e
+	}
+
+	return o.unmarshal(data, obj)
+}
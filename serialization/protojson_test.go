@@ -0,0 +1,134 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestProtoJSONOptions(t *testing.T) {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("error_code"),
+		JsonName: proto.String("errorCode"),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+	}
+
+	tests := []struct {
+		name   string
+		opts   ProtoJSONOptions
+		want   map[string]any
+		absent []string
+	}{
+		{"default", ProtoJSONOptions{}, map[string]any{"jsonName": "errorCode", "type": "TYPE_STRING"}, []string{"number"}},
+		{"proto names", ProtoJSONOptions{UseProtoNames: true}, map[string]any{"json_name": "errorCode"}, []string{"jsonName"}},
+		{"enum numbers", ProtoJSONOptions{UseEnumNumbers: true}, map[string]any{"type": 9.0}, nil},
+		{"emit unpopulated", ProtoJSONOptions{EmitUnpopulated: true}, map[string]any{"number": nil, "options": nil}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewJSONCodec(tt.opts)
+
+			data, e := c.Marshal(field)
+			if e != nil {
+				t.Fatal(e)
+			}
+
+			// protojson randomly inserts spaces into its output, so it is compared once decoded.
+			out := map[string]any{}
+			if e := json.Unmarshal(data, &out); e != nil {
+				t.Fatalf("Marshal() = %s, %v", data, e)
+			}
+
+			for key, want := range tt.want {
+				if got, ok := out[key]; !ok || got != want {
+					t.Errorf("Marshal() = %s, want %s to be %v", data, key, want)
+				}
+			}
+
+			for _, absent := range tt.absent {
+				if _, ok := out[absent]; ok {
+					t.Errorf("Marshal() = %s, want it to not contain %s", data, absent)
+				}
+			}
+
+			decoded := &descriptorpb.FieldDescriptorProto{}
+			if e := c.Unmarshal(data, decoded); e != nil || !proto.Equal(decoded, field) {
+				t.Errorf("Unmarshal() = %v, %v, want %v", decoded, e, field)
+			}
+		})
+	}
+}
+
+func TestProtoJSONUnknownFields(t *testing.T) {
+	for _, tt := range []struct {
+		mediaType string
+		body      string
+	}{
+		{JSONMediaType, `{"message":"hello","unknown":true}`},
+		{YAMLMediaType, "message: hello\nunknown: true\n"},
+	} {
+		strict, lax := NewJSONCodec(ProtoJSONOptions{}), NewJSONCodec(ProtoJSONOptions{DiscardUnknown: true})
+		if tt.mediaType == YAMLMediaType {
+			strict, lax = NewYAMLCodec(ProtoJSONOptions{}), NewYAMLCodec(ProtoJSONOptions{DiscardUnknown: true})
+		}
+
+		if e := strict.Unmarshal([]byte(tt.body), &OKResponse{}); e == nil {
+			t.Errorf("%s: strict Unmarshal() = nil, want an error", tt.mediaType)
+		}
+
+		out := &OKResponse{}
+		if e := lax.Unmarshal([]byte(tt.body), out); e != nil || out.Message != "hello" {
+			t.Errorf("%s: Unmarshal() = %v, %q, want hello", tt.mediaType, e, out.Message)
+		}
+	}
+}
+
+func TestProtoYAMLCodec(t *testing.T) {
+	c := NewYAMLCodec(ProtoJSONOptions{})
+	in := &GenericErrorResponse{
+		Code:      404,
+		ErrorCode: "not_found",
+		Timestamp: timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}
+
+	data, e := c.Marshal(in)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	// The YAML is encoded from the JSON mapping, rather than being flow styled JSON.
+	for _, want := range []string{"errorCode: not_found\n", "timestamp: \"2025-01-02T03:04:05Z\"\n", "code: 404\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Marshal() = %s, want it to contain %q", data, want)
+		}
+	}
+
+	out := &GenericErrorResponse{}
+	if e := c.Unmarshal(data, out); e != nil || !proto.Equal(in, out) {
+		t.Errorf("Unmarshal() = %v, %v, want %v", out, e, in)
+	}
+}
@@ -0,0 +1,581 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fire833/go-api-utils/object"
+	"github.com/go-openapi/spec"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Prefix of references to schemas generated from protobuf messages.
+const protoSchemaRefPrefix string = "#/definitions/"
+
+// NewSchemasFromObject returns the schemas describing the JSON form of an object, see NewSchemasFromMessage.
+func NewSchemasFromObject(obj object.Object) []*spec.Schema {
+	return NewSchemasFromMessage(obj.ProtoReflect().Descriptor())
+}
+
+// NewSchemasFromMessage walks a message descriptor and returns a schema describing the protobuf
+// JSON mapping of the message, followed by schemas for every message and enum it references.
+// Referenced messages and enums are linked with references to #/definitions/<name>, where
+// the name is the full name of the type without its package (ie BuildInfo or Outer.Inner), and
+// is set as the title of each returned schema, so they can be passed directly to RegisterSysAPIHandler
+// or added to the definitions of a spec.
+//
+// Well-known types are inlined using their JSON mapping (ie Timestamp as a date-time string),
+// fields marked as REQUIRED with the google.api.field_behavior option or proto2 required are
+// listed as required, and OUTPUT_ONLY fields are marked as read only. Constraints of fields set
+// with the (apitypes.rules) option are documented as required fields and validations of the
+// field schemas, where they can be represented, see ValidateObject. Leading (or otherwise
+// trailing) comments of messages, fields, enums and enum values are used as descriptions. Generated
+// Go code doesn't retain comments, so they are only available if the source info of the file has
+// been registered with RegisterProtoSourceInfo.
+//
+// Members of oneofs are documented as optional properties, as in the JSON mapping, so the oneofs
+// of a message are listed by name in its x-oneof extension, and the description of each member
+// lists the members of its oneof. Types with the same name in different packages are returned as
+// separate schemas with the same title, which fail to register, see RegisterOperation.
+func NewSchemasFromMessage(md protoreflect.MessageDescriptor) []*spec.Schema {
+	g := &protoSchemaGenerator{definitions: make(map[protoreflect.FullName]*spec.Schema)}
+
+	// Well-known types have no definitions of their own, so are returned as is.
+	if s, ok := g.wellKnown(md); ok {
+		s.Title = protoSchemaName(md)
+		return []*spec.Schema{s}
+	}
+
+	root := g.message(md)
+	schemas := []*spec.Schema{root}
+
+	names := make([]protoreflect.FullName, 0, len(g.definitions))
+	for name := range g.definitions {
+		if name != md.FullName() {
+			names = append(names, name)
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if ti, tj := g.definitions[names[i]].Title, g.definitions[names[j]].Title; ti != tj {
+			return ti < tj
+		}
+
+		return names[i] < names[j]
+	})
+
+	for _, name := range names {
+		schemas = append(schemas, g.definitions[name])
+	}
+
+	return schemas
+}
+
+type protoSchemaGenerator struct {
+	// Definitions keyed by full name, so types with the same name in different packages
+	// don't replace each other.
+	definitions map[protoreflect.FullName]*spec.Schema
+}
+
+// message adds the definition for md and everything it references, returning the definition.
+func (g *protoSchemaGenerator) message(md protoreflect.MessageDescriptor) *spec.Schema {
+	if s, ok := g.definitions[md.FullName()]; ok {
+		return s
+	}
+
+	s := &spec.Schema{
+		SchemaProps: spec.SchemaProps{
+			Title:       protoSchemaName(md),
+			Description: protoComments(md),
+			Type:        []string{"object"},
+			Properties:  spec.SchemaProperties{},
+		},
+	}
+
+	// Added before walking the fields, so recursive messages resolve to a reference.
+	g.definitions[md.FullName()] = s
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		prop := g.field(fd)
+		prop.Title = fd.JSONName()
+		if desc := protoComments(fd); desc != "" {
+			prop.Description = desc
+		}
+
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			prop.Description = strings.TrimSpace(prop.Description + "\n\n" +
+				fmt.Sprintf("Only one of %s can be set (oneof %s).", strings.Join(protoOneofMembers(oneof), ", "), oneof.Name()))
+		}
+
+		for _, behavior := range protoFieldBehaviors(fd) {
+			switch behavior {
+			case annotations.FieldBehavior_REQUIRED:
+				s.Required = append(s.Required, fd.JSONName())
+			case annotations.FieldBehavior_OUTPUT_ONLY:
+				prop.ReadOnly = true
+			}
+		}
+
+		if fd.Cardinality() == protoreflect.Required {
+			s.Required = append(s.Required, fd.JSONName())
+		}
+
+		if rules := protoFieldRules(fd); rules != nil {
+			if rules.GetRequired() && !slices.Contains(s.Required, fd.JSONName()) {
+				s.Required = append(s.Required, fd.JSONName())
+			}
+
+			fieldRulesSchema(prop, fd, rules)
+		}
+
+		s.Properties[fd.JSONName()] = *prop
+	}
+
+	oneofs := map[string][]string{}
+	for i := 0; i < md.Oneofs().Len(); i++ {
+		if oneof := md.Oneofs().Get(i); !oneof.IsSynthetic() {
+			oneofs[string(oneof.Name())] = protoOneofMembers(oneof)
+		}
+	}
+
+	if len(oneofs) > 0 {
+		s.AddExtension("x-oneof", oneofs)
+	}
+
+	return s
+}
+
+// protoOneofMembers returns the JSON names of the members of a oneof.
+func protoOneofMembers(oneof protoreflect.OneofDescriptor) []string {
+	members := make([]string, 0, oneof.Fields().Len())
+	for i := 0; i < oneof.Fields().Len(); i++ {
+		members = append(members, oneof.Fields().Get(i).JSONName())
+	}
+
+	return members
+}
+
+// field returns the schema of a field, including repeated and map fields.
+func (g *protoSchemaGenerator) field(fd protoreflect.FieldDescriptor) *spec.Schema {
+	switch {
+	case fd.IsMap():
+		return spec.MapProperty(g.singular(fd.MapValue()))
+	case fd.IsList():
+		return spec.ArrayProperty(g.singular(fd))
+	default:
+		return g.singular(fd)
+	}
+}
+
+// singular returns the schema of a single value of a field.
+func (g *protoSchemaGenerator) singular(fd protoreflect.FieldDescriptor) *spec.Schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return spec.BoolProperty()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return spec.Int32Property()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return spec.Int64Property().WithMinimum(0, false)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64 bit integers are encoded as strings, as they can't be represented by JSON numbers.
+		return spec.StrFmtProperty("int64")
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return spec.StringProperty().WithPattern(`^[0-9]+$`)
+	case protoreflect.FloatKind:
+		return spec.Float32Property()
+	case protoreflect.DoubleKind:
+		return spec.Float64Property()
+	case protoreflect.StringKind:
+		return spec.StringProperty()
+	case protoreflect.BytesKind:
+		return spec.StrFmtProperty("byte")
+	case protoreflect.EnumKind:
+		return g.enum(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if s, ok := g.wellKnown(fd.Message()); ok {
+			return s
+		}
+
+		g.message(fd.Message())
+		return spec.RefSchema(protoSchemaRefPrefix + protoSchemaName(fd.Message()))
+	default:
+		return &spec.Schema{}
+	}
+}
+
+// fieldRulesSchema documents the constraints of a field within its schema.
+func fieldRulesSchema(s *spec.Schema, fd protoreflect.FieldDescriptor, rules *FieldRules) {
+	switch {
+	case fd.IsList():
+		repeated := rules.GetRepeated()
+		if repeated == nil {
+			return
+		}
+
+		if repeated.MinItems != nil {
+			s.WithMinItems(int64(repeated.GetMinItems()))
+		}
+
+		if repeated.MaxItems != nil {
+			s.WithMaxItems(int64(repeated.GetMaxItems()))
+		}
+
+		if repeated.GetUnique() {
+			s.UniqueValues()
+		}
+
+		if repeated.GetItems() != nil && s.Items != nil && s.Items.Schema != nil {
+			singularRulesSchema(s.Items.Schema, fd, repeated.GetItems())
+		}
+	case fd.IsMap():
+		m := rules.GetMap()
+		if m == nil {
+			return
+		}
+
+		if m.MinPairs != nil {
+			s.WithMinProperties(int64(m.GetMinPairs()))
+		}
+
+		if m.MaxPairs != nil {
+			s.WithMaxProperties(int64(m.GetMaxPairs()))
+		}
+
+		if m.GetValues() != nil && s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+			singularRulesSchema(s.AdditionalProperties.Schema, fd.MapValue(), m.GetValues())
+		}
+	default:
+		singularRulesSchema(s, fd, rules)
+	}
+}
+
+// singularRulesSchema documents the constraints of a single value of a field within its schema.
+// Referenced schemas can't be constrained, and neither can 64 bit integers and bytes, as they are
+// encoded as strings.
+func singularRulesSchema(s *spec.Schema, fd protoreflect.FieldDescriptor, rules *FieldRules) {
+	if s.Ref.String() != "" {
+		return
+	}
+
+	if fd.Message() != nil && protoWrapper(fd.Message()) {
+		fd = fd.Message().Fields().ByName("value")
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		str := rules.GetString_()
+		if str == nil {
+			return
+		}
+
+		if str.MinLen != nil {
+			s.WithMinLength(int64(str.GetMinLen()))
+		}
+
+		if str.MaxLen != nil {
+			s.WithMaxLength(int64(str.GetMaxLen()))
+		}
+
+		if str.GetPattern() != "" {
+			s.WithPattern(str.GetPattern())
+		}
+
+		for _, value := range str.GetIn() {
+			s.Enum = append(s.Enum, value)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if i := rules.GetInt(); i != nil {
+			numberRulesSchema(s, i.Gt, i.Gte, i.Lt, i.Lte)
+			for _, value := range i.GetIn() {
+				s.Enum = append(s.Enum, value)
+			}
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if u := rules.GetUint(); u != nil {
+			numberRulesSchema(s, u.Gt, u.Gte, u.Lt, u.Lte)
+			for _, value := range u.GetIn() {
+				s.Enum = append(s.Enum, value)
+			}
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if d := rules.GetDouble(); d != nil {
+			numberRulesSchema(s, d.Gt, d.Gte, d.Lt, d.Lte)
+		}
+	}
+}
+
+func numberRulesSchema[T int64 | uint64 | float64](s *spec.Schema, gt, gte, lt, lte *T) {
+	switch {
+	case gt != nil:
+		s.WithMinimum(float64(*gt), true)
+	case gte != nil:
+		s.WithMinimum(float64(*gte), false)
+	}
+
+	switch {
+	case lt != nil:
+		s.WithMaximum(float64(*lt), true)
+	case lte != nil:
+		s.WithMaximum(float64(*lte), false)
+	}
+}
+
+// enum adds the definition of an enum, and returns a reference to it.
+func (g *protoSchemaGenerator) enum(ed protoreflect.EnumDescriptor) *spec.Schema {
+	if ed.FullName() == "google.protobuf.NullValue" {
+		return &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"null"}}}
+	}
+
+	name := protoSchemaName(ed)
+	if _, ok := g.definitions[ed.FullName()]; !ok {
+		values := ed.Values()
+		enum := make([]interface{}, 0, values.Len())
+		descriptions := []string{}
+		for i := 0; i < values.Len(); i++ {
+			value := values.Get(i)
+			enum = append(enum, string(value.Name()))
+			if desc := protoComments(value); desc != "" {
+				descriptions = append(descriptions, fmt.Sprintf("- %s: %s", value.Name(), desc))
+			}
+		}
+
+		desc := protoComments(ed)
+		if len(descriptions) > 0 {
+			desc = strings.TrimSpace(desc + "\n\n" + strings.Join(descriptions, "\n"))
+		}
+
+		g.definitions[ed.FullName()] = &spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Title:       name,
+				Description: desc,
+				Type:        []string{"string"},
+				Enum:        enum,
+			},
+		}
+	}
+
+	return spec.RefSchema(protoSchemaRefPrefix + name)
+}
+
+// wellKnown returns the inlined schema of a well-known type with a special JSON mapping, or false
+// if md isn't one.
+func (g *protoSchemaGenerator) wellKnown(md protoreflect.MessageDescriptor) (*spec.Schema, bool) {
+	var s *spec.Schema
+
+	switch md.FullName() {
+	case "google.protobuf.Timestamp":
+		s = spec.DateTimeProperty()
+	case "google.protobuf.Duration":
+		s = spec.StringProperty().WithPattern(`^-?[0-9]+(\.[0-9]{1,9})?s$`).
+			WithDescription("A duration in seconds with up to nine fractional digits, suffixed with 's' (ie 1.5s).")
+	case "google.protobuf.FieldMask":
+		s = spec.StringProperty().WithDescription("A comma separated list of field paths.")
+	case "google.protobuf.Any":
+		s = spec.MapProperty(&spec.Schema{}).
+			SetProperty("@type", *spec.StringProperty().WithDescription("A URL identifying the type of the serialized message."))
+		s.Required = []string{"@type"}
+	case "google.protobuf.Struct":
+		s = spec.MapProperty(&spec.Schema{})
+	case "google.protobuf.ListValue":
+		s = spec.ArrayProperty(&spec.Schema{})
+	case "google.protobuf.Value":
+		s = &spec.Schema{}
+	case "google.protobuf.Empty":
+		s = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"object"}}}
+	case "google.protobuf.BoolValue", "google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value", "google.protobuf.FloatValue",
+		"google.protobuf.DoubleValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		// Wrappers are encoded as their wrapped value.
+		s = g.singular(md.Fields().ByName("value"))
+	default:
+		return nil, false
+	}
+
+	return s, true
+}
+
+// protoSchemaName returns the name of the definition of a message or enum.
+func protoSchemaName(d protoreflect.Descriptor) string {
+	name := string(d.FullName())
+	if pkg := string(d.ParentFile().Package()); pkg != "" {
+		name = strings.TrimPrefix(name, pkg+".")
+	}
+
+	return name
+}
+
+func protoFieldBehaviors(fd protoreflect.FieldDescriptor) []annotations.FieldBehavior {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil || !proto.HasExtension(opts, annotations.E_FieldBehavior) {
+		return nil
+	}
+
+	behaviors, _ := proto.GetExtension(opts, annotations.E_FieldBehavior).([]annotations.FieldBehavior)
+	return behaviors
+}
+
+// Files with source info registered with RegisterProtoSourceInfo, keyed by path.
+var (
+	protoSourceLock  sync.RWMutex
+	protoSourceFiles map[string]protoreflect.FileDescriptor = map[string]protoreflect.FileDescriptor{}
+)
+
+// RegisterProtoSourceInfo registers the source info of the files within a serialized FileDescriptorSet,
+// such as one generated with protoc --include_source_info --descriptor_set_out, so that comments
+// within those files are used as descriptions of generated schemas.
+func RegisterProtoSourceInfo(data []byte) error {
+	set := &descriptorpb.FileDescriptorSet{}
+	if e := proto.Unmarshal(data, set); e != nil {
+		return e
+	}
+
+	files := make(map[string]protoreflect.FileDescriptor, len(set.File))
+	for _, fdp := range set.File {
+		// Dependencies may not be registered yet when called from init functions, and aren't
+		// needed for source info, so are allowed to be unresolved.
+		fd, e := protodesc.FileOptions{AllowUnresolvable: true}.New(fdp, protoregistry.GlobalFiles)
+		if e != nil {
+			return fmt.Errorf("unable to load source info of %s: %w", fdp.GetName(), e)
+		}
+
+		files[fd.Path()] = fd
+	}
+
+	protoSourceLock.Lock()
+	defer protoSourceLock.Unlock()
+
+	for path, fd := range files {
+		protoSourceFiles[path] = fd
+	}
+
+	return nil
+}
+
+// protoComments returns the comments attached to a descriptor, from either the file it was
+// loaded from or registered source info, as a description.
+func protoComments(d protoreflect.Descriptor) string {
+	file := d.ParentFile()
+	if file == nil {
+		return ""
+	}
+
+	loc := file.SourceLocations().ByDescriptor(d)
+	if loc.LeadingComments == "" && loc.TrailingComments == "" {
+		protoSourceLock.RLock()
+		source, ok := protoSourceFiles[file.Path()]
+		protoSourceLock.RUnlock()
+
+		if ok {
+			if sd := findProtoDescriptor(source, d.FullName()); sd != nil {
+				loc = source.SourceLocations().ByDescriptor(sd)
+			}
+		}
+	}
+
+	comments := loc.LeadingComments
+	if strings.TrimSpace(comments) == "" {
+		comments = loc.TrailingComments
+	}
+
+	return formatProtoComments(comments)
+}
+
+// findProtoDescriptor looks up a message, enum, field or enum value by full name within a file.
+func findProtoDescriptor(file protoreflect.FileDescriptor, name protoreflect.FullName) protoreflect.Descriptor {
+	var find func(messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors) protoreflect.Descriptor
+	find = func(messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors) protoreflect.Descriptor {
+		// Enum values are scoped to the parent of their enum, rather than to the enum itself.
+		for i := 0; i < enums.Len(); i++ {
+			ed := enums.Get(i)
+			if ed.FullName() == name {
+				return ed
+			}
+
+			if v := ed.Values().ByName(name.Name()); v != nil && v.FullName() == name {
+				return v
+			}
+		}
+
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			if md.FullName() == name {
+				return md
+			}
+
+			if md.FullName() == name.Parent() {
+				if fd := md.Fields().ByName(name.Name()); fd != nil {
+					return fd
+				}
+			}
+
+			if d := find(md.Messages(), md.Enums()); d != nil {
+				return d
+			}
+		}
+
+		return nil
+	}
+
+	return find(file.Messages(), file.Enums())
+}
+
+// formatProtoComments joins the lines of each paragraph of a comment, since proto comments are
+// wrapped to fit the source rather than the reader. Struct tag injection and go-swagger annotations
+// are dropped, as they are directives for tooling.
+func formatProtoComments(comments string) string {
+	paragraphs := []string{}
+	lines := []string{}
+
+	flush := func() {
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, " "))
+			lines = lines[:0]
+		}
+	}
+
+	for _, line := range strings.Split(comments, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "@gotags:") || strings.HasPrefix(line, "swagger:") {
+			continue
+		}
+
+		if line == "" {
+			flush()
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	flush()
+	return strings.Join(paragraphs, "\n\n")
+}
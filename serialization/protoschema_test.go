@@ -0,0 +1,367 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// testProtoFile returns a file describing:
+//
+//	// A widget.
+//	message Widget {
+//	  // The name of the widget.
+//	  string name = 1 [(google.api.field_behavior) = REQUIRED];
+//	  int64 id = 2 [(google.api.field_behavior) = OUTPUT_ONLY];
+//	  repeated Part parts = 3;
+//	  map<string, Color> colors = 4;
+//	  google.protobuf.Timestamp created = 5;
+//	  google.protobuf.StringValue nickname = 6;
+//	  Widget parent = 7;
+//	  message Part { bytes data = 1; }
+//	}
+//
+//	enum Color {
+//	  COLOR_UNSPECIFIED = 0;
+//	  // Like the sky.
+//	  BLUE = 1;
+//	}
+func testProtoFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+
+		return f
+	}
+
+	behavior := func(f *descriptorpb.FieldDescriptorProto, b annotations.FieldBehavior) *descriptorpb.FieldDescriptorProto {
+		f.Options = &descriptorpb.FieldOptions{}
+		proto.SetExtension(f.Options, annotations.E_FieldBehavior, []annotations.FieldBehavior{b})
+		return f
+	}
+
+	parts := field("parts", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.Widget.Part")
+	parts.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+
+	colors := field("colors", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.Widget.ColorsEntry")
+	colors.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/widget.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto", "google/protobuf/wrappers.proto", "google/api/field_behavior.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Widget"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				behavior(field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""), annotations.FieldBehavior_REQUIRED),
+				behavior(field("id", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""), annotations.FieldBehavior_OUTPUT_ONLY),
+				parts,
+				colors,
+				field("created", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+				field("nickname", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue"),
+				field("parent", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.Widget"),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{
+				{
+					Name:  proto.String("Part"),
+					Field: []*descriptorpb.FieldDescriptorProto{field("data", 1, descriptorpb.FieldDescriptorProto_TYPE_BYTES, "")},
+				},
+				{
+					Name: proto.String("ColorsEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+						field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".test.Color"),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				},
+			},
+		}},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Color"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("COLOR_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("BLUE"), Number: proto.Int32(1)},
+			},
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{
+				{Path: []int32{4, 0}, Span: []int32{0, 0, 0}, LeadingComments: proto.String(" A widget.\n")},
+				{Path: []int32{4, 0, 2, 0}, Span: []int32{1, 0, 0}, LeadingComments: proto.String(" The name of\n the widget.\n")},
+				{Path: []int32{5, 0, 2, 1}, Span: []int32{2, 0, 0}, LeadingComments: proto.String(" Like the sky.\n")},
+			},
+		},
+	}
+
+	fd, e := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	return fd
+}
+
+func TestNewSchemasFromMessage(t *testing.T) {
+	schemas := NewSchemasFromMessage(testProtoFile(t).Messages().ByName("Widget"))
+
+	titles := []string{}
+	defs := map[string]*spec.Schema{}
+	for _, s := range schemas {
+		titles = append(titles, s.Title)
+		defs[s.Title] = s
+	}
+
+	if want := []string{"Widget", "Color", "Widget.Part"}; !reflect.DeepEqual(titles, want) {
+		t.Fatalf("schemas = %v, want %v", titles, want)
+	}
+
+	widget := defs["Widget"]
+	if widget.Description != "A widget." {
+		t.Errorf("Widget description = %q", widget.Description)
+	}
+
+	if !reflect.DeepEqual(widget.Required, []string{"name"}) {
+		t.Errorf("Widget required = %v, want [name]", widget.Required)
+	}
+
+	tests := []struct {
+		field  string
+		typ    string
+		format string
+		ref    string
+	}{
+		{"name", "string", "", ""},
+		{"id", "string", "int64", ""},
+		{"created", "string", "date-time", ""},
+		{"nickname", "string", "", ""},
+		{"parent", "", "", "#/definitions/Widget"},
+	}
+
+	for _, tt := range tests {
+		prop := widget.Properties[tt.field]
+		if len(prop.Type) > 0 && prop.Type[0] != tt.typ || len(prop.Type) == 0 && tt.typ != "" {
+			t.Errorf("%s type = %v, want %s", tt.field, prop.Type, tt.typ)
+		}
+
+		if prop.Format != tt.format {
+			t.Errorf("%s format = %s, want %s", tt.field, prop.Format, tt.format)
+		}
+
+		if ref := prop.Ref.String(); ref != tt.ref {
+			t.Errorf("%s ref = %s, want %s", tt.field, ref, tt.ref)
+		}
+	}
+
+	if desc := widget.Properties["name"].Description; desc != "The name of the widget." {
+		t.Errorf("name description = %q", desc)
+	}
+
+	if !widget.Properties["id"].ReadOnly {
+		t.Errorf("id is not read only")
+	}
+
+	if parts := widget.Properties["parts"]; !parts.Type.Contains("array") || parts.Items.Schema.Ref.String() != "#/definitions/Widget.Part" {
+		t.Errorf("parts = %v items %v, want array of Widget.Part", parts.Type, parts.Items.Schema.Ref.String())
+	}
+
+	if colors := widget.Properties["colors"]; !colors.Type.Contains("object") || colors.AdditionalProperties.Schema.Ref.String() != "#/definitions/Color" {
+		t.Errorf("colors = %v, want map of Color", colors.Type)
+	}
+
+	color := defs["Color"]
+	if !reflect.DeepEqual(color.Enum, []interface{}{"COLOR_UNSPECIFIED", "BLUE"}) {
+		t.Errorf("Color enum = %v", color.Enum)
+	}
+
+	if color.Description != "- BLUE: Like the sky." {
+		t.Errorf("Color description = %q", color.Description)
+	}
+
+	if data := defs["Widget.Part"].Properties["data"]; data.Format != "byte" {
+		t.Errorf("Widget.Part data format = %s, want byte", data.Format)
+	}
+}
+
+func TestNewSchemasFromMessageOneofsAndNameCollisions(t *testing.T) {
+	// Describes, in package test.a:
+	//
+	//	message Status { string code = 1; }
+	//
+	// and in package test.b:
+	//
+	//	message Status { int32 code = 1; }
+	//	message Result {
+	//	  oneof outcome {
+	//	    string message = 1;
+	//	    test.a.Status status = 2;
+	//	  }
+	//	  Status local = 3;
+	//	}
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, oneof *int32) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:       proto.String(name),
+			JsonName:   proto.String(name),
+			Number:     proto.Int32(number),
+			Label:      descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:       typ.Enum(),
+			OneofIndex: oneof,
+		}
+
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+
+		return f
+	}
+
+	files := &protoregistry.Files{}
+
+	a, e := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test/a.proto"),
+		Package: proto.String("test.a"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name:  proto.String("Status"),
+			Field: []*descriptorpb.FieldDescriptorProto{field("code", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", nil)},
+		}},
+	}, files)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if e := files.RegisterFile(a); e != nil {
+		t.Fatal(e)
+	}
+
+	b, e := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/b.proto"),
+		Package:    proto.String("test.b"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"test/a.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:  proto.String("Status"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("code", 1, descriptorpb.FieldDescriptorProto_TYPE_INT32, "", nil)},
+			},
+			{
+				Name: proto.String("Result"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("message", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", proto.Int32(0)),
+					field("status", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.a.Status", proto.Int32(0)),
+					field("local", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.b.Status", nil),
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("outcome")}},
+			},
+		},
+	}, files)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	schemas := NewSchemasFromMessage(b.Messages().ByName("Result"))
+
+	titles := []string{}
+	for _, s := range schemas {
+		titles = append(titles, s.Title)
+	}
+
+	// Both Status messages are returned rather than one replacing the other.
+	if want := []string{"Result", "Status", "Status"}; !reflect.DeepEqual(titles, want) {
+		t.Fatalf("schemas = %v, want %v", titles, want)
+	}
+
+	result := schemas[0]
+	if oneofs, ok := result.Extensions["x-oneof"]; !ok || !reflect.DeepEqual(oneofs, map[string][]string{"outcome": {"message", "status"}}) {
+		t.Errorf("Result x-oneof = %v", oneofs)
+	}
+
+	if desc := result.Properties["message"].Description; desc != "Only one of message, status can be set (oneof outcome)." {
+		t.Errorf("message description = %q", desc)
+	}
+
+	if desc := result.Properties["local"].Description; desc != "" {
+		t.Errorf("local description = %q, want none", desc)
+	}
+
+	s := &spec.Swagger{}
+	if e := RegisterOperation(s, "GET", "/result", spec.NewOperation("getResult"), schemas, nil); e == nil {
+		t.Errorf("RegisterOperation() with colliding schemas = nil, want an error")
+	}
+
+	if s.Paths != nil && len(s.Paths.Paths) > 0 || len(s.Definitions) > 0 {
+		t.Errorf("failed registration modified the spec")
+	}
+}
+
+func TestNewSchemasFromObjectSourceInfo(t *testing.T) {
+	schemas := NewSchemasFromObject(&GenericErrorResponse{})
+	if len(schemas) != 2 || schemas[1].Title != "FieldViolation" {
+		t.Fatalf("got %d schemas, want GenericErrorResponse and FieldViolation", len(schemas))
+	}
+
+	// Comments are only available from the embedded source info of generictypes.proto.
+	if desc := schemas[0].Properties["error"].Description; desc != "The http error string for this error.\n\nrequired: true" {
+		t.Errorf("error description = %q", desc)
+	}
+
+	if format := schemas[0].Properties["timestamp"].Format; format != "date-time" {
+		t.Errorf("timestamp format = %s, want date-time", format)
+	}
+}
+
+func TestFormatProtoComments(t *testing.T) {
+	tests := []struct {
+		comments string
+		want     string
+	}{
+		{"", ""},
+		{" Single line.\n", "Single line."},
+		{" Wrapped\n over lines.\n", "Wrapped over lines."},
+		{" First.\n\n Second\n paragraph.\n", "First.\n\nSecond paragraph."},
+		{" Tagged.\n\n @gotags: yaml:\"x\"\n", "Tagged."},
+	}
+
+	for _, tt := range tests {
+		if got := formatProtoComments(tt.comments); got != tt.want {
+			t.Errorf("formatProtoComments(%q) = %q, want %q", tt.comments, got, tt.want)
+		}
+	}
+}
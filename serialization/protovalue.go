@@ -0,0 +1,378 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fire833/go-api-utils/object"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The binary codecs (MessagePack and CBOR) encode objects with the same structure as the protobuf
+// JSON mapping, so field names, enums and well-known types are shared with JSON and YAML, except
+// that values are encoded with their native types rather than as strings: 64 bit integers are
+// encoded as integers, bytes as binary, and timestamps with the format's timestamp type.
+//
+// Objects are first converted to a tree of values, which is then encoded by the codec. The tree
+// consists of nil, bool, int64, uint64, float32, float64, string, []byte, time.Time, []any,
+// map[string]any and valueMap values. Decoded trees are converted back to objects through their
+// JSON encoding, so decoding is exactly as permissive as decoding JSON.
+
+// valueMap is a map with ordered keys, so that messages are encoded deterministically.
+type valueMap []valueMapEntry
+
+type valueMapEntry struct {
+	Key   string
+	Value any
+}
+
+// Well-known types wrapping a single value, which are encoded as the value.
+var protoWrapperTypes = map[protoreflect.FullName]bool{
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+}
+
+// toValue converts obj to a tree of values.
+func (o ProtoJSONOptions) toValue(obj object.Object) (any, error) {
+	return o.messageValue(obj.ProtoReflect())
+}
+
+func (o ProtoJSONOptions) messageValue(m protoreflect.Message) (any, error) {
+	md := m.Descriptor()
+
+	switch name := md.FullName(); {
+	case name == "google.protobuf.Timestamp":
+		fields := md.Fields()
+		return time.Unix(m.Get(fields.ByNumber(1)).Int(), m.Get(fields.ByNumber(2)).Int()).UTC(), nil
+	case protoWrapperTypes[name]:
+		fd := md.Fields().ByNumber(1)
+		return o.singularValue(fd, m.Get(fd))
+	case md.ParentFile().Package() == "google.protobuf":
+		// The remaining well-known types (ie Any, Duration, Struct) have JSON representations
+		// that don't correspond to their fields, so are converted from their JSON encoding.
+		return o.jsonValue(m)
+	}
+
+	fields := md.Fields()
+	out := make(valueMap, 0, fields.Len())
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+
+		name := fd.JSONName()
+		if o.UseProtoNames {
+			name = string(fd.Name())
+		}
+
+		if !m.Has(fd) {
+			if oneof := fd.ContainingOneof(); !o.EmitUnpopulated || oneof != nil && !oneof.IsSynthetic() {
+				continue
+			}
+
+			if fd.HasPresence() {
+				out = append(out, valueMapEntry{name, nil})
+				continue
+			}
+		}
+
+		v, e := o.fieldValue(fd, m.Get(fd))
+		if e != nil {
+			return nil, e
+		}
+
+		out = append(out, valueMapEntry{name, v})
+	}
+
+	return out, nil
+}
+
+func (o ProtoJSONOptions) fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, error) {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		out := make([]any, 0, list.Len())
+
+		for i := 0; i < list.Len(); i++ {
+			item, e := o.singularValue(fd, list.Get(i))
+			if e != nil {
+				return nil, e
+			}
+
+			out = append(out, item)
+		}
+
+		return out, nil
+	case fd.IsMap():
+		keys := []protoreflect.MapKey{}
+		v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+
+		sort.Slice(keys, func(i, j int) bool {
+			switch fd.MapKey().Kind() {
+			case protoreflect.BoolKind:
+				return !keys[i].Bool() && keys[j].Bool()
+			case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+				protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+				return keys[i].Int() < keys[j].Int()
+			case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+				return keys[i].Uint() < keys[j].Uint()
+			default:
+				return keys[i].String() < keys[j].String()
+			}
+		})
+
+		out := make(valueMap, 0, len(keys))
+		for _, k := range keys {
+			item, e := o.singularValue(fd.MapValue(), v.Map().Get(k))
+			if e != nil {
+				return nil, e
+			}
+
+			out = append(out, valueMapEntry{k.String(), item})
+		}
+
+		return out, nil
+	default:
+		return o.singularValue(fd, v)
+	}
+}
+
+func (o ProtoJSONOptions) singularValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool(), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int(), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint(), nil
+	case protoreflect.FloatKind:
+		return float32(v.Float()), nil
+	case protoreflect.DoubleKind:
+		return v.Float(), nil
+	case protoreflect.StringKind:
+		return v.String(), nil
+	case protoreflect.BytesKind:
+		return v.Bytes(), nil
+	case protoreflect.EnumKind:
+		if fd.Enum().FullName() == "google.protobuf.NullValue" {
+			return nil, nil
+		}
+
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil && !o.UseEnumNumbers {
+			return string(ev.Name()), nil
+		}
+
+		return int64(v.Enum()), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return o.messageValue(v.Message())
+	default:
+		return nil, fmt.Errorf("unsupported field kind %s", fd.Kind())
+	}
+}
+
+// jsonValue converts a message to a tree of values from its JSON encoding.
+func (o ProtoJSONOptions) jsonValue(m protoreflect.Message) (any, error) {
+	data, e := o.marshal(m.Interface())
+	if e != nil {
+		return nil, e
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if e := dec.Decode(&v); e != nil {
+		return nil, e
+	}
+
+	return fromJSONValue(v), nil
+}
+
+// fromJSONValue replaces the numbers of a value decoded from JSON with integers where possible.
+func fromJSONValue(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, e := v.Int64(); e == nil {
+			return i
+		}
+
+		if u, e := strconv.ParseUint(string(v), 10, 64); e == nil {
+			return u
+		}
+
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = fromJSONValue(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = fromJSONValue(v[k])
+		}
+	}
+
+	return v
+}
+
+// fromValue decodes a tree of values into obj.
+func (o ProtoJSONOptions) fromValue(v any, obj object.Object) error {
+	data, e := json.Marshal(toJSONValue(v))
+	if e != nil {
+		return e
+	}
+
+	return o.unmarshal(data, obj)
+}
+
+// toJSONValue replaces values of a tree that don't have a JSON representation with the representation
+// used by the protobuf JSON mapping.
+func toJSONValue(v any) any {
+	switch v := v.(type) {
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float32:
+		return toJSONValue(float64(v))
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "NaN"
+		case math.IsInf(v, 1):
+			return "Infinity"
+		case math.IsInf(v, -1):
+			return "-Infinity"
+		}
+	case []any:
+		for i := range v {
+			v[i] = toJSONValue(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = toJSONValue(v[k])
+		}
+	case valueMap:
+		out := make(map[string]any, len(v))
+		for _, entry := range v {
+			out[entry.Key] = toJSONValue(entry.Value)
+		}
+
+		return out
+	}
+
+	return v
+}
+
+// The maximum nesting depth of values decoded without a configured limit, matching the default
+// recursion limit of protobuf.
+const maxValueDepth int = 10000
+
+var errMaxDepth = errors.New("maximum nesting depth exceeded")
+
+// valueReader is the reader the binary codecs decode values from, which limits the nesting depth
+// of arrays, maps and tags so that decoding can't exhaust the stack.
+type valueReader struct {
+	byteReader
+
+	depth    int
+	maxDepth int
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func newValueReader(r byteReader, maxDepth int) *valueReader {
+	if maxDepth <= 0 || maxDepth > maxValueDepth {
+		maxDepth = maxValueDepth
+	}
+
+	return &valueReader{byteReader: r, maxDepth: maxDepth}
+}
+
+// nest enters a nested value, which must be left again with unnest.
+func (r *valueReader) nest() error {
+	if r.depth++; r.depth > r.maxDepth {
+		return fmt.Errorf("%w of %d", errMaxDepth, r.maxDepth)
+	}
+
+	return nil
+}
+
+func (r *valueReader) unnest() { r.depth-- }
+
+// unexpectedEOF replaces io.EOF with io.ErrUnexpectedEOF, for values that end part way through.
+func unexpectedEOF(e error) error {
+	if errors.Is(e, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return e
+}
+
+// readUint reads a big endian unsigned integer of n bytes.
+func readUint(r io.ByteReader, n int) (uint64, error) {
+	var u uint64
+	for i := 0; i < n; i++ {
+		c, e := r.ReadByte()
+		if e != nil {
+			return 0, e
+		}
+
+		u = u<<8 | uint64(c)
+	}
+
+	return u, nil
+}
+
+// readBytes reads n bytes, without trusting n to allocate the whole buffer up front.
+func readBytes[N int | uint64](r io.Reader, n N) ([]byte, error) {
+	if uint64(n) > math.MaxInt64 {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	buf := &bytes.Buffer{}
+	if _, e := io.CopyN(buf, r, int64(n)); e != nil {
+		return nil, unexpectedEOF(e)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,251 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fire833/go-api-utils/object"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestBinaryCodecsWellKnownTypes(t *testing.T) {
+	packed, e := anypb.New(&OKResponse{Message: "hello", Code: 200})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	st, e := structpb.NewStruct(map[string]any{"a": 1.5, "b": []any{"c", true, nil}})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	objects := map[string]func() object.Object{
+		"timestamp":        func() object.Object { return &timestamppb.Timestamp{} },
+		"timestamp nanos":  func() object.Object { return &timestamppb.Timestamp{} },
+		"timestamp before": func() object.Object { return &timestamppb.Timestamp{} },
+		"duration":         func() object.Object { return &durationpb.Duration{} },
+		"any":              func() object.Object { return &anypb.Any{} },
+		"struct":           func() object.Object { return &structpb.Struct{} },
+		"int64 wrapper":    func() object.Object { return &wrapperspb.Int64Value{} },
+		"bytes wrapper":    func() object.Object { return &wrapperspb.BytesValue{} },
+	}
+
+	values := map[string]object.Object{
+		"timestamp":        timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		"timestamp nanos":  timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)),
+		"timestamp before": timestamppb.New(time.Date(1960, 1, 2, 3, 4, 5, 6, time.UTC)),
+		"duration":         durationpb.New(90*time.Second + time.Millisecond),
+		"any":              packed,
+		"struct":           st,
+		"int64 wrapper":    wrapperspb.Int64(-1 << 40),
+		"bytes wrapper":    wrapperspb.Bytes([]byte{0, 1, 2, 0xff}),
+	}
+
+	for _, mediaType := range []string{MsgPackMediaType, CBORMediaType} {
+		c, _ := CodecFor(mediaType)
+
+		for name, in := range values {
+			t.Run(mediaType+"/"+name, func(t *testing.T) {
+				data, e := c.Marshal(in)
+				if e != nil {
+					t.Fatal(e)
+				}
+
+				out := objects[name]()
+				if e := c.Unmarshal(data, out); e != nil {
+					t.Fatalf("Unmarshal(%x) = %v", data, e)
+				}
+
+				if !proto.Equal(in, out) {
+					t.Errorf("round trip = %v, want %v", out, in)
+				}
+			})
+		}
+	}
+}
+
+func TestBinaryCodecsNativeTypes(t *testing.T) {
+	in := &GenericErrorResponse{Code: 404, Error: "Not Found"}
+
+	tests := []struct {
+		mediaType string
+		want      []byte
+	}{
+		// Fields are encoded in field order, with their JSON names and native types.
+		{MsgPackMediaType, append(append([]byte{0x82, 0xa5}, "error"...), append(append([]byte{0xa9}, "Not Found"...),
+			append(append([]byte{0xa4}, "code"...), 0xcd, 0x01, 0x94)...)...)},
+		{CBORMediaType, append(append([]byte{0xa2, 0x65}, "error"...), append(append([]byte{0x69}, "Not Found"...),
+			append(append([]byte{0x64}, "code"...), 0x19, 0x01, 0x94)...)...)},
+	}
+
+	for _, tt := range tests {
+		c, _ := CodecFor(tt.mediaType)
+
+		data, e := c.Marshal(in)
+		if e != nil || string(data) != string(tt.want) {
+			t.Errorf("%s: Marshal() = %x, %v, want %x", tt.mediaType, data, e, tt.want)
+		}
+	}
+}
+
+func TestReadCBOR(t *testing.T) {
+	// Examples from RFC 8949 appendix A.
+	tests := []struct {
+		hex  string
+		want any
+	}{
+		{"1903e8", uint64(1000)},
+		{"3903e7", int64(-1000)},
+		{"f93c00", 1.0},
+		{"f9c400", -4.0},
+		{"fb7e37e43c8800759c", 1.0e+300},
+		{"f6", nil},
+		{"4401020304", []byte{1, 2, 3, 4}},
+		{"6449455446", "IETF"},
+		{"7f657374726561646d696e67ff", "streaming"},
+		{"9f018202039f0405ffff", []any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}},
+		{"bf6346756ef563416d7421ff", map[string]any{"Fun": true, "Amt": int64(-2)}},
+		{"c074323031332d30332d32315432303a30343a30305a", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{"c11a514b67b0", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.hex)
+
+		got, e := readCBOR(newValueReader(bytes.NewReader(data), 0))
+		if e != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("readCBOR(%s) = %#v, %v, want %#v", tt.hex, got, e, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"", "19", "6449", "9f01", "ff", "a1016161", "1c", "c1f9ff30", "c11b7fffffffffffffff", "c07819393939392d31322d33315432333a35393a35392d30353a3030"} {
+		data, _ := hex.DecodeString(invalid)
+		if _, e := readCBOR(newValueReader(bytes.NewReader(data), 0)); e == nil {
+			t.Errorf("readCBOR(%s) = nil, want an error", invalid)
+		}
+	}
+}
+
+func TestReadMsgPack(t *testing.T) {
+	tests := []struct {
+		hex  string
+		want any
+	}{
+		{"7f", int64(127)},
+		{"ff", int64(-1)},
+		{"d18000", int64(-32768)},
+		{"cf8000000000000000", uint64(1 << 63)},
+		{"ca3fc00000", 1.5},
+		{"a3616263", "abc"},
+		{"c40201ff", []byte{1, 0xff}},
+		{"920193c0c2c3", []any{int64(1), []any{nil, false, true}}},
+		{"81a161a162", map[string]any{"a": "b"}},
+		{"d6ff514b67b0", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{"d7ff00000018514b67b0", time.Date(2013, 3, 21, 20, 4, 0, 6, time.UTC)},
+		{"c70cff00000006fffffffffffffff0", time.Unix(-16, 6).UTC()},
+	}
+
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.hex)
+
+		got, e := readMsgPack(newValueReader(bytes.NewReader(data), 0))
+		if e != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("readMsgPack(%s) = %#v, %v, want %#v", tt.hex, got, e, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{"", "cd01", "a361", "92c0", "8101c0", "d401ff", "c1"} {
+		data, _ := hex.DecodeString(invalid)
+		if _, e := readMsgPack(newValueReader(bytes.NewReader(data), 0)); e == nil {
+			t.Errorf("readMsgPack(%s) = nil, want an error", invalid)
+		}
+	}
+}
+
+// fuzzBinaryCodec checks that read doesn't panic on arbitrary input, and that any value it reads
+// encodes to bytes that read back to the same encoding.
+func fuzzBinaryCodec(f *testing.F, mediaType string, seeds []string, read func(*valueReader) (any, error), write func([]byte, any) ([]byte, error)) {
+	c, _ := CodecFor(mediaType)
+
+	for _, seed := range seeds {
+		data, _ := hex.DecodeString(seed)
+		f.Add(data)
+	}
+
+	for _, obj := range []object.Object{
+		&GenericErrorResponse{Code: 404, Error: "Not Found"},
+		timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)),
+		wrapperspb.Bytes([]byte{0, 1, 2, 0xff}),
+	} {
+		data, e := c.Marshal(obj)
+		if e != nil {
+			f.Fatal(e)
+		}
+
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Decoding into a message must only ever fail with an error.
+		c.Unmarshal(data, &structpb.Value{})
+		c.Unmarshal(data, &GenericErrorResponse{})
+
+		v, e := read(newValueReader(bytes.NewReader(data), 0))
+		if e != nil {
+			return
+		}
+
+		encoded, e := write(nil, v)
+		if e != nil {
+			t.Fatalf("unable to encode %#v read from %x: %v", v, data, e)
+		}
+
+		v, e = read(newValueReader(bytes.NewReader(encoded), 0))
+		if e != nil {
+			t.Fatalf("unable to read %x encoded from %x: %v", encoded, data, e)
+		}
+
+		reencoded, e := write(nil, v)
+		if e != nil || !bytes.Equal(encoded, reencoded) {
+			t.Errorf("re-encoding %x = %x, %v, want %x", data, reencoded, e, encoded)
+		}
+	})
+}
+
+func FuzzReadMsgPack(f *testing.F) {
+	fuzzBinaryCodec(f, MsgPackMediaType, []string{
+		"cf8000000000000000", "ca3fc00000", "920193c0c2c3", "81a161a162", "d7ff00000018514b67b0", "c70cff00000006fffffffffffffff0",
+	}, readMsgPack, appendMsgPack)
+}
+
+func FuzzReadCBOR(f *testing.F) {
+	fuzzBinaryCodec(f, CBORMediaType, []string{
+		"3903e7", "f93c00", "7f657374726561646d696e67ff", "9f018202039f0405ffff", "bf6346756ef563416d7421ff", "c11a514b67b0",
+	}, readCBOR, appendCBOR)
+}
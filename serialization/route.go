@@ -0,0 +1,336 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fasthttp/router"
+	"github.com/fire833/go-api-utils/object"
+	"github.com/go-openapi/spec"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// NoBody can be used as the request or response type of a Route that doesn't accept or return a body.
+type NoBody = *emptypb.Empty
+
+// Route describes a single API operation along with its handler, so that the handler and its
+// documentation are always registered together. The request body and response body of the
+// operation are documented from the Req and Resp message types, see NewSchemasFromMessage.
+type Route[Req, Resp object.Object] struct {
+	// The HTTP method and path of the route, using router path syntax (ie /objects/{ID}).
+	Method string
+	Path   string
+
+	// Documentation of the operation.
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+
+	// Path, query and header parameters of the operation. The request body parameter is added
+	// automatically unless Req is NoBody.
+	Params []*spec.Parameter
+
+	// Status code and description of successful responses, defaulting to 200 and "Success.".
+	Status              int
+	ResponseDescription string
+
+	// Any additional responses of the operation, such as error responses.
+	Responses map[int]*spec.Response
+
+	// The handler of the route, which can be built from a typed handler with Handle or HandleStatus.
+	Handler fasthttp.RequestHandler
+
+	// Limits of request bodies decoded by the handler, defaulting to those set with SetDecodeLimits.
+	Limits *DecodeLimits
+}
+
+// Endpoint is a route with its spec operation and the schemas it references resolved, ready
+// to be registered with a router and spec.
+type Endpoint struct {
+	Method    string
+	Path      string
+	Handler   fasthttp.RequestHandler
+	Operation *spec.Operation
+	Schemas   []*spec.Schema
+}
+
+// Endpoint resolves the route into an Endpoint.
+func (r *Route[Req, Resp]) Endpoint() *Endpoint {
+	op := spec.NewOperation(r.OperationID).
+		WithTags(r.Tags...).
+		WithSummary(r.Summary).
+		WithDescription(r.Description)
+	op.Deprecated = r.Deprecated
+
+	for _, param := range r.Params {
+		op.AddParam(param)
+	}
+
+	handler := r.Handler
+	if r.Limits != nil && handler != nil {
+		handler = WithDecodeLimits(handler, *r.Limits)
+	}
+
+	e := &Endpoint{Method: r.Method, Path: r.Path, Handler: handler, Operation: op}
+
+	var req Req
+	if _, ok := any(req).(NoBody); !ok {
+		schema, schemas := routeSchema(req)
+		e.Schemas = append(e.Schemas, schemas...)
+		op.AddParam(spec.BodyParam("body", schema).AsRequired())
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	desc := r.ResponseDescription
+	if desc == "" {
+		desc = "Success."
+	}
+
+	resp := spec.NewResponse().WithDescription(desc)
+
+	var res Resp
+	if _, ok := any(res).(NoBody); !ok {
+		schema, schemas := routeSchema(res)
+		e.Schemas = append(e.Schemas, schemas...)
+		resp.WithSchema(schema)
+	}
+
+	op.RespondsWith(status, resp)
+	for code, resp := range r.Responses {
+		op.RespondsWith(code, resp)
+	}
+
+	return e
+}
+
+// Register resolves the route and registers it with the router and spec, see Endpoint.Register.
+func (r *Route[Req, Resp]) Register(rt *router.Router, s *spec.Swagger) error {
+	return r.Endpoint().Register(rt, s)
+}
+
+// Register registers the endpoint handler with the router, and its operation and schemas with
+// the spec, see RegisterOperation.
+func (e *Endpoint) Register(rt *router.Router, s *spec.Swagger) error {
+	return RegisterOperation(s, e.Method, e.Path, e.Operation, e.Schemas, func() {
+		rt.Handle(e.Method, e.Path, e.Handler)
+	})
+}
+
+// routeSchema returns the schema to reference obj with, along with the definitions it requires.
+func routeSchema(obj object.Object) (*spec.Schema, []*spec.Schema) {
+	md := obj.ProtoReflect().Descriptor()
+
+	// Well-known types are inlined rather than referenced.
+	g := &protoSchemaGenerator{definitions: make(map[protoreflect.FullName]*spec.Schema)}
+	if s, ok := g.wellKnown(md); ok {
+		return s, nil
+	}
+
+	return spec.RefSchema(protoSchemaRefPrefix + protoSchemaName(md)), NewSchemasFromMessage(md)
+}
+
+// RegisterOperation adds op to the spec for method on path, merging it with any other operations
+// already documented on the path, and adds each schema to the spec definitions.
+//
+// The registration is atomic: an error is returned and the spec is left untouched if there is already
+// an operation for the method on the path or with the same operation ID, or a different schema with
+// the same title, either registered already or within schemas. register, if not nil, is called once
+// the operation is known not to conflict and before the spec is modified, to register the handler of
+// the operation. If it panics, as routers do for conflicting routes, the panic is returned as an error
+// and the spec is also left untouched.
+func RegisterOperation(s *spec.Swagger, method, path string, op *spec.Operation, schemas []*spec.Schema, register func()) error {
+	if s.Paths == nil {
+		s.Paths = &spec.Paths{Paths: map[string]spec.PathItem{}}
+	}
+
+	if s.Paths.Paths == nil {
+		s.Paths.Paths = map[string]spec.PathItem{}
+	}
+
+	item := s.Paths.Paths[path]
+	slot := PathItemOperation(&item, method)
+	if slot == nil {
+		return fmt.Errorf("method %s is not supported by swagger", method)
+	}
+
+	if *slot != nil {
+		return fmt.Errorf("operation %s %s is already registered", method, path)
+	}
+
+	if op.ID != "" {
+		for other, otherItem := range s.Paths.Paths {
+			for _, otherMethod := range pathItemMethods {
+				if otherOp := *PathItemOperation(&otherItem, otherMethod); otherOp != nil && otherOp.ID == op.ID {
+					return fmt.Errorf("operation ID %s is already used by %s %s", op.ID, otherMethod, other)
+				}
+			}
+		}
+	}
+
+	if e := checkSchemas(s, schemas); e != nil {
+		return e
+	}
+
+	if register != nil {
+		if e := recoverRegistration(register); e != nil {
+			return fmt.Errorf("unable to register %s %s: %w", method, path, e)
+		}
+	}
+
+	*slot = op
+	s.Paths.Paths[path] = item
+	addSchemas(s, schemas)
+
+	return nil
+}
+
+// RegisterPathItem adds every operation of item to the spec on path that isn't documented on the
+// path already, along with the parameters of item if the path has none, and adds each schema to
+// the spec definitions. Unlike RegisterOperation, operations that are already documented are kept
+// rather than causing an error, so it can be used with partial or empty path items.
+//
+// An error is returned and the spec is left untouched if there is a different schema with the same
+// title, either registered already or within schemas, or if register panics.
+func RegisterPathItem(s *spec.Swagger, path string, item spec.PathItem, schemas []*spec.Schema, register func()) error {
+	if e := checkSchemas(s, schemas); e != nil {
+		return e
+	}
+
+	if register != nil {
+		if e := recoverRegistration(register); e != nil {
+			return fmt.Errorf("unable to register %s: %w", path, e)
+		}
+	}
+
+	if s.Paths == nil {
+		s.Paths = &spec.Paths{Paths: map[string]spec.PathItem{}}
+	}
+
+	if s.Paths.Paths == nil {
+		s.Paths.Paths = map[string]spec.PathItem{}
+	}
+
+	existing, ok := s.Paths.Paths[path]
+	if !ok {
+		existing = item
+	} else {
+		for _, method := range pathItemMethods {
+			if slot := PathItemOperation(&existing, method); *slot == nil {
+				*slot = *PathItemOperation(&item, method)
+			}
+		}
+
+		if len(existing.Parameters) == 0 {
+			existing.Parameters = item.Parameters
+		}
+	}
+
+	s.Paths.Paths[path] = existing
+	addSchemas(s, schemas)
+
+	return nil
+}
+
+func checkSchemas(s *spec.Swagger, schemas []*spec.Schema) error {
+	titles := make(map[string]*spec.Schema, len(schemas))
+
+	for _, schema := range schemas {
+		// Such as for messages with the same name in different packages, see NewSchemasFromMessage.
+		if other, ok := titles[schema.Title]; ok && !swaggerSchemasEqual(other, schema) {
+			return fmt.Errorf("different schemas are both titled %s", schema.Title)
+		}
+
+		titles[schema.Title] = schema
+
+		if existing, ok := s.Definitions[schema.Title]; ok && !swaggerSchemasEqual(&existing, schema) {
+			return fmt.Errorf("a different schema %s is already registered", schema.Title)
+		}
+	}
+
+	return nil
+}
+
+func addSchemas(s *spec.Swagger, schemas []*spec.Schema) {
+	if s.Definitions == nil && len(schemas) > 0 {
+		s.Definitions = spec.Definitions{}
+	}
+
+	for _, schema := range schemas {
+		s.Definitions[schema.Title] = *schema
+	}
+}
+
+func recoverRegistration(register func()) (e error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e = fmt.Errorf("%v", r)
+		}
+	}()
+
+	register()
+	return nil
+}
+
+// Methods of operations within a path item.
+var pathItemMethods []string = []string{
+	fasthttp.MethodGet, fasthttp.MethodPut, fasthttp.MethodPost, fasthttp.MethodDelete,
+	fasthttp.MethodOptions, fasthttp.MethodHead, fasthttp.MethodPatch,
+}
+
+// PathItemOperation returns a pointer to the operation slot of the path item for method, or nil
+// if method isn't supported by swagger.
+func PathItemOperation(item *spec.PathItem, method string) **spec.Operation {
+	switch strings.ToUpper(method) {
+	case fasthttp.MethodGet:
+		return &item.Get
+	case fasthttp.MethodPut:
+		return &item.Put
+	case fasthttp.MethodPost:
+		return &item.Post
+	case fasthttp.MethodDelete:
+		return &item.Delete
+	case fasthttp.MethodOptions:
+		return &item.Options
+	case fasthttp.MethodHead:
+		return &item.Head
+	case fasthttp.MethodPatch:
+		return &item.Patch
+	default:
+		return nil
+	}
+}
+
+func swaggerSchemasEqual(a, b *spec.Schema) bool {
+	ab, e1 := json.Marshal(a)
+	bb, e2 := json.Marshal(b)
+	return e1 == nil && e2 == nil && bytes.Equal(ab, bb)
+}
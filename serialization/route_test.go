@@ -0,0 +1,163 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fasthttp/router"
+	"github.com/go-openapi/spec"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRouteEndpoint(t *testing.T) {
+	route := &Route[*OKResponse, *GenericErrorResponse]{
+		Method:      fasthttp.MethodPut,
+		Path:        "/objects/{ID}",
+		OperationID: "putObject",
+		Tags:        []string{"objects"},
+		Params:      []*spec.Parameter{spec.PathParam("ID").Typed("string", "")},
+		Status:      201,
+	}
+
+	e := route.Endpoint()
+	if e.Method != fasthttp.MethodPut || e.Path != "/objects/{ID}" || e.Operation.ID != "putObject" {
+		t.Fatalf("endpoint = %s %s %s", e.Method, e.Path, e.Operation.ID)
+	}
+
+	if len(e.Operation.Parameters) != 2 || e.Operation.Parameters[1].In != "body" ||
+		e.Operation.Parameters[1].Schema.Ref.String() != "#/definitions/OKResponse" {
+		t.Errorf("parameters = %+v, want ID and an OKResponse body", e.Operation.Parameters)
+	}
+
+	resp, ok := e.Operation.Responses.StatusCodeResponses[201]
+	if !ok || resp.Schema.Ref.String() != "#/definitions/GenericErrorResponse" {
+		t.Errorf("201 response = %+v, want GenericErrorResponse", resp)
+	}
+
+	titles := []string{}
+	for _, schema := range e.Schemas {
+		titles = append(titles, schema.Title)
+	}
+
+	if strings.Join(titles, ",") != "OKResponse,GenericErrorResponse,FieldViolation" {
+		t.Errorf("schemas = %v, want OKResponse, GenericErrorResponse and FieldViolation", titles)
+	}
+
+	empty := (&Route[NoBody, *wrapperspb.StringValue]{Method: fasthttp.MethodGet, Path: "/name"}).Endpoint()
+	if len(empty.Operation.Parameters) != 0 {
+		t.Errorf("NoBody route has parameters %+v", empty.Operation.Parameters)
+	}
+
+	if resp := empty.Operation.Responses.StatusCodeResponses[200]; resp.Schema == nil || !resp.Schema.Type.Contains("string") {
+		t.Errorf("StringValue response = %+v, want an inlined string", resp.Schema)
+	}
+
+	if len(empty.Schemas) != 0 {
+		t.Errorf("well-known route has schemas %v", empty.Schemas)
+	}
+}
+
+func TestRouteRegister(t *testing.T) {
+	rt := router.New()
+	s := &spec.Swagger{}
+	h := func(ctx *fasthttp.RequestCtx) {}
+
+	// Routes registered with the router alone are not known to the spec.
+	rt.GET("/raw/{ID}", h)
+
+	get := &Route[NoBody, *OKResponse]{Method: fasthttp.MethodGet, Path: "/objects", OperationID: "getObjects", Handler: h}
+	put := &Route[*OKResponse, *OKResponse]{Method: fasthttp.MethodPut, Path: "/objects", OperationID: "putObjects", Handler: h}
+
+	if e := get.Register(rt, s); e != nil {
+		t.Fatal(e)
+	}
+
+	if e := put.Register(rt, s); e != nil {
+		t.Fatal(e)
+	}
+
+	item := s.Paths.Paths["/objects"]
+	if item.Get == nil || item.Put == nil {
+		t.Fatalf("path item = %+v, want GET and PUT merged", item)
+	}
+
+	if _, ok := s.Definitions["OKResponse"]; !ok {
+		t.Errorf("OKResponse not within definitions")
+	}
+
+	before, _ := s.MarshalJSON()
+
+	conflicts := []struct {
+		name  string
+		route *Endpoint
+		want  string
+	}{
+		{"duplicate method", (&Route[NoBody, NoBody]{Method: fasthttp.MethodGet, Path: "/objects"}).Endpoint(), "already registered"},
+		{"duplicate operation ID", (&Route[NoBody, NoBody]{Method: fasthttp.MethodGet, Path: "/other", OperationID: "putObjects"}).Endpoint(), "operation ID"},
+		{"unsupported method", (&Route[NoBody, NoBody]{Method: "TRACE", Path: "/other"}).Endpoint(), "not supported"},
+		{"schema conflict", &Endpoint{Method: fasthttp.MethodGet, Path: "/other", Operation: spec.NewOperation(""),
+			Schemas: []*spec.Schema{spec.StringProperty().WithTitle("OKResponse")}}, "OKResponse"},
+		{"router conflict", (&Route[NoBody, NoBody]{Method: fasthttp.MethodGet, Path: "/raw/{ID}", Handler: h}).Endpoint(), "unable to register"},
+	}
+
+	for _, c := range conflicts {
+		t.Run(c.name, func(t *testing.T) {
+			e := c.route.Register(rt, s)
+			if e == nil || !strings.Contains(e.Error(), c.want) {
+				t.Fatalf("Register() = %v, want error containing %q", e, c.want)
+			}
+
+			if after, _ := s.MarshalJSON(); string(after) != string(before) {
+				t.Errorf("spec modified by failed registration")
+			}
+		})
+	}
+
+	// Routes rejected due to conflicts within the spec must not be served either.
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/other")
+	rt.Handler(ctx)
+	if ctx.Response.StatusCode() != fasthttp.StatusNotFound {
+		t.Errorf("GET /other = %d, want 404", ctx.Response.StatusCode())
+	}
+}
+
+func TestRouteLimits(t *testing.T) {
+	route := &Route[*OKResponse, *OKResponse]{
+		Method: fasthttp.MethodPost,
+		Path:   "/objects",
+		Limits: &DecodeLimits{MaxBodySize: 8},
+		Handler: func(ctx *fasthttp.RequestCtx) {
+			if e := UnmarshalBodyByContentHeader(ctx, &OKResponse{}); e != nil {
+				ErrorResponseHandler(ctx, e)
+			}
+		},
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetBody([]byte(`{"message":"too large"}`))
+	route.Endpoint().Handler(ctx)
+
+	if status := ctx.Response.StatusCode(); status != fasthttp.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", status)
+	}
+}
@@ -49,15 +49,11 @@ func NewSchemaRequired(name, desc string, properties []spec.Schema, required []s
 	}
 }
 
-// Generic constructor of a new operation within the API.
+// Generic constructor of a new operation within the API, consuming and producing the media
+// types of all registered codecs.
 func NewOperation(id, desc string, tags []string, params []*spec.Parameter, responses map[uint]*spec.Response) *spec.Operation {
-	var (
-		ConsumeMIMES []string = []string{"application/json", "application/xml", "application/yaml", "application/API+Protobuf"}
-		ProduceMIMES []string = []string{"application/json", "application/xml", "application/yaml", "application/API+Protobuf"}
-	)
-
 	op := spec.NewOperation(id).WithTags(tags...).
-		WithSummary(desc).WithConsumes(ConsumeMIMES...).WithProduces(ProduceMIMES...).
+		WithSummary(desc).WithConsumes(MediaTypes()...).WithProduces(MediaTypes()...).
 		WithDescription(desc)
 
 	for _, param := range params {
@@ -164,7 +160,7 @@ func NewSchemaBooleanProperty(name, desc string) spec.Schema {
 }
 
 func NewSchemaTimestampProperty(name, desc string) spec.Schema {
-	return NewSchemaProperty(name, desc, "string", "time-format")
+	return NewSchemaProperty(name, desc, "string", "date-time")
 }
 
 func NewSchemaEnumProperty(name, desc, propType, format string, enum []interface{}) spec.Schema {
@@ -0,0 +1,173 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/fire833/go-api-utils/object"
+	"github.com/valyala/fasthttp"
+)
+
+// NDJSONMediaType is the media type of newline delimited JSON, where each line is a JSON object.
+const NDJSONMediaType string = "application/x-ndjson"
+
+// StreamMediaTypes returns the media types lists can be streamed with by StreamListResponseHandler,
+// in order of preference, for documenting the operations that stream them:
+//
+//   - application/json, as a JSON array of the items,
+//   - application/x-ndjson, as a JSON object per line,
+//   - application/protobuf, as a sequence of items each prefixed by their varint encoded size.
+func StreamMediaTypes() []string {
+	return []string{JSONMediaType, NDJSONMediaType, ProtobufMediaType}
+}
+
+// Number of items written between flushes of the response, so clients receive items as they are
+// produced rather than once the write buffer fills.
+const streamFlushInterval int = 64
+
+// StreamListResponseHandler responds with status and the list of items, encoding and writing each
+// item as it is produced by items, rather than holding the entire list in memory. The items are
+// encoded with the stream media type most preferred by the client according to its Accept header,
+// see StreamMediaTypes, and a 406 response is returned instead if none of them are acceptable.
+//
+// The items are iterated once the handler has returned, so items must not reference ctx. If err
+// isn't nil, it is called once the items are exhausted and returns the error that ended the items
+// early, if any, in which case the response is left incomplete (ie the JSON array isn't closed) so
+// clients can tell that the list was truncated. Iteration also stops early if the client goes away.
+func StreamListResponseHandler(ctx *fasthttp.RequestCtx, status int, items iter.Seq[object.Object], err func() error) {
+	addVary(ctx, fasthttp.HeaderAccept)
+
+	mediaType, ok := negotiateStreamMediaType(ctx.Request.Header.Peek(fasthttp.HeaderAccept))
+	if !ok {
+		NotAcceptableResponseHandler(ctx, fmt.Sprintf("none of the accepted media types %q are supported for lists",
+			ctx.Request.Header.Peek(fasthttp.HeaderAccept)))
+		return
+	}
+
+	ctx.Response.Header.SetContentType(mediaType)
+	ctx.SetStatusCode(status)
+
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		streamList(w, mediaType, items, err)
+	})
+}
+
+// negotiateStreamMediaType returns the stream media type most preferred by the client, accepting
+// aliases of the media types once none of the media types themselves are acceptable.
+func negotiateStreamMediaType(accept []byte) (string, bool) {
+	offers := StreamMediaTypes()
+	if mediaType, ok := Negotiate(accept, offers...); ok {
+		return mediaType, true
+	}
+
+	for _, r := range ParseAccept(accept) {
+		if c, ok := CodecFor(r.Type + "/" + r.Subtype); ok && r.Q > 0 {
+			for _, offer := range offers {
+				if c.MediaType() == offer {
+					return offer, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+// streamList writes the items to w with the stream media type, returning once the items are exhausted,
+// either of them fails to encode, or w fails to write.
+func streamList(w *bufio.Writer, mediaType string, items iter.Seq[object.Object], err func() error) {
+	enc := newStreamEncoder(w, mediaType)
+
+	written := 0
+	for item := range items {
+		if e := enc.Encode(item); e != nil {
+			return
+		}
+
+		if written++; written%streamFlushInterval == 0 {
+			if e := w.Flush(); e != nil {
+				return
+			}
+		}
+	}
+
+	if err != nil && err() != nil {
+		return
+	}
+
+	if closer, ok := enc.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// newStreamEncoder returns the encoder of the stream media type.
+func newStreamEncoder(w io.Writer, mediaType string) Encoder {
+	switch mediaType {
+	case JSONMediaType:
+		c, _ := CodecFor(JSONMediaType)
+		return &jsonArrayEncoder{c: c, w: w}
+	case NDJSONMediaType:
+		c, _ := CodecFor(JSONMediaType)
+		return c.NewEncoder(w)
+	default:
+		c, _ := CodecFor(ProtobufMediaType)
+		return c.NewEncoder(w)
+	}
+}
+
+// jsonArrayEncoder writes objects as the elements of a JSON array, which is closed by Close.
+type jsonArrayEncoder struct {
+	c     Codec
+	w     io.Writer
+	count int
+}
+
+func (e *jsonArrayEncoder) Encode(obj object.Object) error {
+	data, err := e.c.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	sep := ","
+	if e.count == 0 {
+		sep = "["
+	}
+
+	e.count++
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonArrayEncoder) Close() error {
+	end := "]"
+	if e.count == 0 {
+		end = "[]"
+	}
+
+	_, err := io.WriteString(e.w, end)
+	return err
+}
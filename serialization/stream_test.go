@@ -0,0 +1,118 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/fire833/go-api-utils/object"
+	"github.com/valyala/fasthttp"
+)
+
+func testItems(n int) iter.Seq[object.Object] {
+	return func(yield func(object.Object) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(&OKResponse{Message: "item " + strconv.Itoa(i), Code: uint32(i)}) {
+				return
+			}
+		}
+	}
+}
+
+func TestStreamListResponseHandler(t *testing.T) {
+	stream := func(accept string, n int, err func() error) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.Set(fasthttp.HeaderAccept, accept)
+		StreamListResponseHandler(ctx, 200, testItems(n), err)
+		return ctx
+	}
+
+	t.Run("json", func(t *testing.T) {
+		for _, n := range []int{0, 1, 1000} {
+			ctx := stream("", n, nil)
+			if ct := string(ctx.Response.Header.ContentType()); ct != JSONMediaType || ctx.Response.StatusCode() != 200 {
+				t.Fatalf("response = %d %s, want 200 %s", ctx.Response.StatusCode(), ct, JSONMediaType)
+			}
+
+			items := []map[string]any{}
+			if e := json.Unmarshal(ctx.Response.Body(), &items); e != nil || len(items) != n {
+				t.Fatalf("%d items: got %d items, %v: %.40s", n, len(items), e, ctx.Response.Body())
+			}
+
+			if n > 0 && items[n-1]["message"] != "item "+strconv.Itoa(n-1) {
+				t.Errorf("last item = %v", items[n-1])
+			}
+		}
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		ctx := stream(NDJSONMediaType, 3, nil)
+		lines := strings.Split(strings.TrimSuffix(string(ctx.Response.Body()), "\n"), "\n")
+		if ct := string(ctx.Response.Header.ContentType()); ct != NDJSONMediaType || len(lines) != 3 {
+			t.Fatalf("response = %s with %d lines, want %s with 3 lines", ct, len(lines), NDJSONMediaType)
+		}
+
+		for i, line := range lines {
+			item := &OKResponse{}
+			if c, _ := CodecFor(JSONMediaType); c.Unmarshal([]byte(line), item) != nil || item.Code != uint32(i) {
+				t.Errorf("line %d = %s", i, line)
+			}
+		}
+	})
+
+	t.Run("protobuf", func(t *testing.T) {
+		ctx := stream("application/x-protobuf", 3, nil)
+		if ct := string(ctx.Response.Header.ContentType()); ct != ProtobufMediaType {
+			t.Fatalf("content type = %s, want %s", ct, ProtobufMediaType)
+		}
+
+		c, _ := CodecFor(ProtobufMediaType)
+		dec := c.NewDecoder(bytes.NewReader(ctx.Response.Body()))
+		for i := 0; i < 3; i++ {
+			item := &OKResponse{}
+			if e := dec.Decode(item); e != nil || item.Code != uint32(i) {
+				t.Fatalf("item %d = %v, %v", i, item, e)
+			}
+		}
+
+		if e := dec.Decode(&OKResponse{}); !errors.Is(e, io.EOF) {
+			t.Errorf("Decode() = %v after the last item, want EOF", e)
+		}
+	})
+
+	t.Run("not acceptable", func(t *testing.T) {
+		if ctx := stream(XMLMediaType, 3, nil); ctx.Response.StatusCode() != 406 {
+			t.Errorf("response = %d, want 406", ctx.Response.StatusCode())
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		ctx := stream(JSONMediaType, 3, func() error { return errors.New("connection reset") })
+		if body := ctx.Response.Body(); !bytes.HasPrefix(body, []byte("[")) || bytes.HasSuffix(body, []byte("]")) {
+			t.Errorf("body = %s, want an unterminated array", body)
+		}
+	})
+}
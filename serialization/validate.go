@@ -0,0 +1,372 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/object"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ValidateObject checks obj against the constraints set on its fields with the (apitypes.rules) option,
+// see validate.proto, along with the fields of every message nested within it. Every violation is
+// returned together within an *apierror.Error with status 422, with the path of each field using
+// JSON names (ie items[0].name), or nil is returned if obj is valid.
+//
+// Fields with presence, such as messages and optional fields, are only checked when set, whereas
+// other fields are checked even if they have their zero value.
+func ValidateObject(obj object.Object) error {
+	v := &objectValidator{}
+	v.message(obj.ProtoReflect(), "")
+
+	if v.err != nil {
+		return v.err
+	}
+
+	if len(v.violations) > 0 {
+		return apierror.UnprocessableEntity("request contains invalid fields", v.violations...)
+	}
+
+	return nil
+}
+
+type objectValidator struct {
+	violations []apierror.FieldViolation
+
+	// Set if the constraints themselves are invalid, such as an invalid pattern.
+	err error
+}
+
+func (v *objectValidator) violation(path, format string, args ...any) {
+	v.violations = append(v.violations, apierror.FieldViolation{Field: path, Description: fmt.Sprintf(format, args...)})
+}
+
+func (v *objectValidator) message(m protoreflect.Message, path string) {
+	// Well-known types have no constraints of their own.
+	if m.Descriptor().ParentFile().Package() == "google.protobuf" {
+		return
+	}
+
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		rules := protoFieldRules(fd)
+		fieldPath := joinFieldPath(path, fd.JSONName())
+
+		if !m.Has(fd) {
+			if rules.GetRequired() {
+				v.violation(fieldPath, "is required")
+				continue
+			}
+
+			if fd.HasPresence() {
+				continue
+			}
+		}
+
+		switch {
+		case fd.IsList():
+			v.list(m.Get(fd).List(), fd, rules.GetRepeated(), fieldPath)
+		case fd.IsMap():
+			v.mapEntries(m.Get(fd).Map(), fd, rules.GetMap(), fieldPath)
+		default:
+			v.value(m.Get(fd), fd, rules, fieldPath)
+		}
+	}
+}
+
+func (v *objectValidator) list(list protoreflect.List, fd protoreflect.FieldDescriptor, rules *RepeatedRules, path string) {
+	if rules == nil {
+		rules = &RepeatedRules{}
+	}
+
+	n := uint64(list.Len())
+	if rules.MinItems != nil && n < rules.GetMinItems() {
+		v.violation(path, "must have at least %d items", rules.GetMinItems())
+	}
+
+	if rules.MaxItems != nil && n > rules.GetMaxItems() {
+		v.violation(path, "must have at most %d items", rules.GetMaxItems())
+	}
+
+	seen, duplicate := map[any]struct{}{}, false
+	for i := 0; i < list.Len(); i++ {
+		item := list.Get(i)
+		v.value(item, fd, rules.GetItems(), path+"["+strconv.Itoa(i)+"]")
+
+		if !rules.GetUnique() || fd.Message() != nil {
+			continue
+		}
+
+		key := item.Interface()
+		if b, ok := key.([]byte); ok {
+			key = string(b)
+		}
+
+		if _, ok := seen[key]; ok {
+			duplicate = true
+		}
+
+		seen[key] = struct{}{}
+	}
+
+	if duplicate {
+		v.violation(path, "must have unique items")
+	}
+}
+
+func (v *objectValidator) mapEntries(entries protoreflect.Map, fd protoreflect.FieldDescriptor, rules *MapRules, path string) {
+	if rules == nil {
+		rules = &MapRules{}
+	}
+
+	n := uint64(entries.Len())
+	if rules.MinPairs != nil && n < rules.GetMinPairs() {
+		v.violation(path, "must have at least %d entries", rules.GetMinPairs())
+	}
+
+	if rules.MaxPairs != nil && n > rules.GetMaxPairs() {
+		v.violation(path, "must have at most %d entries", rules.GetMaxPairs())
+	}
+
+	keys := make([]protoreflect.MapKey, 0, entries.Len())
+	entries.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+
+	// Sorted, so violations are always reported in the same order.
+	slices.SortFunc(keys, func(a, b protoreflect.MapKey) int { return strings.Compare(a.String(), b.String()) })
+
+	for _, k := range keys {
+		entryPath := path + "[" + strconv.Quote(k.String()) + "]"
+		v.value(k.Value(), fd.MapKey(), rules.GetKeys(), entryPath)
+		v.value(entries.Get(k), fd.MapValue(), rules.GetValues(), entryPath)
+	}
+}
+
+// value checks a single value of the field, which is a list item or map key or value for repeated
+// and map fields.
+func (v *objectValidator) value(val protoreflect.Value, fd protoreflect.FieldDescriptor, rules *FieldRules, path string) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// Wrappers are checked against the constraints of their wrapped value.
+		if wrapped := fd.Message().Fields().ByName("value"); wrapped != nil && protoWrapper(fd.Message()) {
+			v.value(val.Message().Get(wrapped), wrapped, rules, path)
+			return
+		}
+
+		v.message(val.Message(), path)
+	case protoreflect.StringKind:
+		v.string(val.String(), rules.GetString_(), path)
+	case protoreflect.BytesKind:
+		v.bytes(val.Bytes(), rules.GetBytes(), path)
+	case protoreflect.EnumKind:
+		v.enum(val.Enum(), fd.Enum(), rules.GetEnum(), path)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v.int(val.Int(), rules.GetInt(), path)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v.uint(val.Uint(), rules.GetUint(), path)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		v.double(val.Float(), rules.GetDouble(), path)
+	}
+}
+
+func (v *objectValidator) string(s string, rules *StringRules, path string) {
+	if rules == nil {
+		return
+	}
+
+	n := uint64(utf8.RuneCountInString(s))
+	if rules.MinLen != nil && n < rules.GetMinLen() {
+		v.violation(path, "must be at least %d characters long", rules.GetMinLen())
+	}
+
+	if rules.MaxLen != nil && n > rules.GetMaxLen() {
+		v.violation(path, "must be at most %d characters long", rules.GetMaxLen())
+	}
+
+	if rules.GetPattern() != "" {
+		re, e := compileRulePattern(rules.GetPattern())
+		if e != nil {
+			v.err = e
+			return
+		}
+
+		if !re.MatchString(s) {
+			v.violation(path, "must match the pattern %s", rules.GetPattern())
+		}
+	}
+
+	if len(rules.GetIn()) > 0 && !slices.Contains(rules.GetIn(), s) {
+		v.violation(path, "must be one of %s", strings.Join(rules.GetIn(), ", "))
+	}
+}
+
+func (v *objectValidator) bytes(b []byte, rules *BytesRules, path string) {
+	if rules == nil {
+		return
+	}
+
+	n := uint64(len(b))
+	if rules.MinLen != nil && n < rules.GetMinLen() {
+		v.violation(path, "must be at least %d bytes long", rules.GetMinLen())
+	}
+
+	if rules.MaxLen != nil && n > rules.GetMaxLen() {
+		v.violation(path, "must be at most %d bytes long", rules.GetMaxLen())
+	}
+}
+
+func (v *objectValidator) enum(n protoreflect.EnumNumber, ed protoreflect.EnumDescriptor, rules *EnumRules, path string) {
+	if rules.GetDefinedOnly() && ed.Values().ByNumber(n) == nil {
+		v.violation(path, "must be one of the values of %s", ed.Name())
+	}
+
+	if len(rules.GetIn()) > 0 && !slices.Contains(rules.GetIn(), int32(n)) {
+		v.violation(path, "must be one of %s", enumValueNames(ed, rules.GetIn()))
+	}
+
+	if slices.Contains(rules.GetNotIn(), int32(n)) {
+		v.violation(path, "must not be %s", enumValueNames(ed, []int32{int32(n)}))
+	}
+}
+
+func (v *objectValidator) int(i int64, rules *IntRules, path string) {
+	switch {
+	case rules == nil:
+	case rules.Gt != nil && i <= rules.GetGt():
+		v.violation(path, "must be greater than %d", rules.GetGt())
+	case rules.Gte != nil && i < rules.GetGte():
+		v.violation(path, "must be greater than or equal to %d", rules.GetGte())
+	case rules.Lt != nil && i >= rules.GetLt():
+		v.violation(path, "must be less than %d", rules.GetLt())
+	case rules.Lte != nil && i > rules.GetLte():
+		v.violation(path, "must be less than or equal to %d", rules.GetLte())
+	case len(rules.GetIn()) > 0 && !slices.Contains(rules.GetIn(), i):
+		v.violation(path, "must be one of %s", formatRuleValues(rules.GetIn()))
+	}
+}
+
+func (v *objectValidator) uint(u uint64, rules *UintRules, path string) {
+	switch {
+	case rules == nil:
+	case rules.Gt != nil && u <= rules.GetGt():
+		v.violation(path, "must be greater than %d", rules.GetGt())
+	case rules.Gte != nil && u < rules.GetGte():
+		v.violation(path, "must be greater than or equal to %d", rules.GetGte())
+	case rules.Lt != nil && u >= rules.GetLt():
+		v.violation(path, "must be less than %d", rules.GetLt())
+	case rules.Lte != nil && u > rules.GetLte():
+		v.violation(path, "must be less than or equal to %d", rules.GetLte())
+	case len(rules.GetIn()) > 0 && !slices.Contains(rules.GetIn(), u):
+		v.violation(path, "must be one of %s", formatRuleValues(rules.GetIn()))
+	}
+}
+
+func (v *objectValidator) double(f float64, rules *DoubleRules, path string) {
+	switch {
+	case rules == nil:
+	case rules.GetFinite() && (math.IsInf(f, 0) || math.IsNaN(f)):
+		v.violation(path, "must be finite")
+	case rules.Gt != nil && !(f > rules.GetGt()):
+		v.violation(path, "must be greater than %g", rules.GetGt())
+	case rules.Gte != nil && !(f >= rules.GetGte()):
+		v.violation(path, "must be greater than or equal to %g", rules.GetGte())
+	case rules.Lt != nil && !(f < rules.GetLt()):
+		v.violation(path, "must be less than %g", rules.GetLt())
+	case rules.Lte != nil && !(f <= rules.GetLte()):
+		v.violation(path, "must be less than or equal to %g", rules.GetLte())
+	}
+}
+
+func formatRuleValues[T int64 | uint64](values []T) string {
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		strs = append(strs, fmt.Sprint(value))
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// enumValueNames returns the names of the enum values, or their numbers if they aren't defined.
+func enumValueNames(ed protoreflect.EnumDescriptor, numbers []int32) string {
+	names := make([]string, 0, len(numbers))
+	for _, n := range numbers {
+		if value := ed.Values().ByNumber(protoreflect.EnumNumber(n)); value != nil {
+			names = append(names, string(value.Name()))
+		} else {
+			names = append(names, strconv.Itoa(int(n)))
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// protoWrapper returns whether md is one of the well-known wrapper types, ie google.protobuf.StringValue.
+func protoWrapper(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Path() == "google/protobuf/wrappers.proto"
+}
+
+// Constraints of fields and compiled patterns, which are cached as they are looked up for every request.
+var (
+	protoFieldRulesCache sync.Map // protoreflect.FieldDescriptor -> *FieldRules
+	rulePatternCache     sync.Map // string -> *regexp.Regexp
+)
+
+// protoFieldRules returns the constraints of the field set with the (apitypes.rules) option, or nil.
+func protoFieldRules(fd protoreflect.FieldDescriptor) *FieldRules {
+	if rules, ok := protoFieldRulesCache.Load(fd); ok {
+		return rules.(*FieldRules)
+	}
+
+	var rules *FieldRules
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts != nil && proto.HasExtension(opts, E_Rules) {
+		rules, _ = proto.GetExtension(opts, E_Rules).(*FieldRules)
+	}
+
+	protoFieldRulesCache.Store(fd, rules)
+	return rules
+}
+
+func compileRulePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := rulePatternCache.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, e := regexp.Compile(pattern)
+	if e != nil {
+		return nil, fmt.Errorf("invalid field pattern %s: %w", pattern, e)
+	}
+
+	rulePatternCache.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,998 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.21.5
+// source: validate.proto
+
+package serialization
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FieldRules are the constraints of a single field, where the rules set must match the kind of the field.
+type FieldRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The field must be set. Fields without presence must not have their zero value, and
+	// repeated and map fields must not be empty.
+	Required bool `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	// Types that are valid to be assigned to Type:
+	//
+	//	*FieldRules_String_
+	//	*FieldRules_Bytes
+	//	*FieldRules_Int
+	//	*FieldRules_Uint
+	//	*FieldRules_Double
+	//	*FieldRules_Enum
+	//	*FieldRules_Repeated
+	//	*FieldRules_Map
+	Type          isFieldRules_Type `protobuf_oneof:"type"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldRules) Reset() {
+	*x = FieldRules{}
+	mi := &file_validate_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldRules) ProtoMessage() {}
+
+func (x *FieldRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldRules.ProtoReflect.Descriptor instead.
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldRules) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *FieldRules) GetType() isFieldRules_Type {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *FieldRules) GetString_() *StringRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_String_); ok {
+			return x.String_
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetBytes() *BytesRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Bytes); ok {
+			return x.Bytes
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetInt() *IntRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Int); ok {
+			return x.Int
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetUint() *UintRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Uint); ok {
+			return x.Uint
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetDouble() *DoubleRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Double); ok {
+			return x.Double
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetEnum() *EnumRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Enum); ok {
+			return x.Enum
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetRepeated() *RepeatedRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Repeated); ok {
+			return x.Repeated
+		}
+	}
+	return nil
+}
+
+func (x *FieldRules) GetMap() *MapRules {
+	if x != nil {
+		if x, ok := x.Type.(*FieldRules_Map); ok {
+			return x.Map
+		}
+	}
+	return nil
+}
+
+type isFieldRules_Type interface {
+	isFieldRules_Type()
+}
+
+type FieldRules_String_ struct {
+	String_ *StringRules `protobuf:"bytes,2,opt,name=string,proto3,oneof"`
+}
+
+type FieldRules_Bytes struct {
+	Bytes *BytesRules `protobuf:"bytes,3,opt,name=bytes,proto3,oneof"`
+}
+
+type FieldRules_Int struct {
+	Int *IntRules `protobuf:"bytes,4,opt,name=int,proto3,oneof"`
+}
+
+type FieldRules_Uint struct {
+	Uint *UintRules `protobuf:"bytes,5,opt,name=uint,proto3,oneof"`
+}
+
+type FieldRules_Double struct {
+	Double *DoubleRules `protobuf:"bytes,6,opt,name=double,proto3,oneof"`
+}
+
+type FieldRules_Enum struct {
+	Enum *EnumRules `protobuf:"bytes,7,opt,name=enum,proto3,oneof"`
+}
+
+type FieldRules_Repeated struct {
+	Repeated *RepeatedRules `protobuf:"bytes,8,opt,name=repeated,proto3,oneof"`
+}
+
+type FieldRules_Map struct {
+	Map *MapRules `protobuf:"bytes,9,opt,name=map,proto3,oneof"`
+}
+
+func (*FieldRules_String_) isFieldRules_Type() {}
+
+func (*FieldRules_Bytes) isFieldRules_Type() {}
+
+func (*FieldRules_Int) isFieldRules_Type() {}
+
+func (*FieldRules_Uint) isFieldRules_Type() {}
+
+func (*FieldRules_Double) isFieldRules_Type() {}
+
+func (*FieldRules_Enum) isFieldRules_Type() {}
+
+func (*FieldRules_Repeated) isFieldRules_Type() {}
+
+func (*FieldRules_Map) isFieldRules_Type() {}
+
+// StringRules are the constraints of string fields.
+type StringRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The minimum and maximum length of the string in characters.
+	MinLen *uint64 `protobuf:"varint,1,opt,name=min_len,json=minLen,proto3,oneof" json:"min_len,omitempty"`
+	MaxLen *uint64 `protobuf:"varint,2,opt,name=max_len,json=maxLen,proto3,oneof" json:"max_len,omitempty"`
+	// An RE2 regular expression the string must match.
+	Pattern string `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// The values the string must be one of, if not empty.
+	In            []string `protobuf:"bytes,4,rep,name=in,proto3" json:"in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StringRules) Reset() {
+	*x = StringRules{}
+	mi := &file_validate_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StringRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringRules) ProtoMessage() {}
+
+func (x *StringRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringRules.ProtoReflect.Descriptor instead.
+func (*StringRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StringRules) GetMinLen() uint64 {
+	if x != nil && x.MinLen != nil {
+		return *x.MinLen
+	}
+	return 0
+}
+
+func (x *StringRules) GetMaxLen() uint64 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+func (x *StringRules) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *StringRules) GetIn() []string {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+// BytesRules are the constraints of bytes fields.
+type BytesRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The minimum and maximum length of the bytes.
+	MinLen        *uint64 `protobuf:"varint,1,opt,name=min_len,json=minLen,proto3,oneof" json:"min_len,omitempty"`
+	MaxLen        *uint64 `protobuf:"varint,2,opt,name=max_len,json=maxLen,proto3,oneof" json:"max_len,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BytesRules) Reset() {
+	*x = BytesRules{}
+	mi := &file_validate_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BytesRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BytesRules) ProtoMessage() {}
+
+func (x *BytesRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BytesRules.ProtoReflect.Descriptor instead.
+func (*BytesRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BytesRules) GetMinLen() uint64 {
+	if x != nil && x.MinLen != nil {
+		return *x.MinLen
+	}
+	return 0
+}
+
+func (x *BytesRules) GetMaxLen() uint64 {
+	if x != nil && x.MaxLen != nil {
+		return *x.MaxLen
+	}
+	return 0
+}
+
+// IntRules are the constraints of signed integer fields.
+type IntRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bounds of the integer, exclusive (gt, lt) or inclusive (gte, lte).
+	Gt  *int64 `protobuf:"varint,1,opt,name=gt,proto3,oneof" json:"gt,omitempty"`
+	Gte *int64 `protobuf:"varint,2,opt,name=gte,proto3,oneof" json:"gte,omitempty"`
+	Lt  *int64 `protobuf:"varint,3,opt,name=lt,proto3,oneof" json:"lt,omitempty"`
+	Lte *int64 `protobuf:"varint,4,opt,name=lte,proto3,oneof" json:"lte,omitempty"`
+	// The values the integer must be one of, if not empty.
+	In            []int64 `protobuf:"varint,5,rep,packed,name=in,proto3" json:"in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IntRules) Reset() {
+	*x = IntRules{}
+	mi := &file_validate_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IntRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntRules) ProtoMessage() {}
+
+func (x *IntRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntRules.ProtoReflect.Descriptor instead.
+func (*IntRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IntRules) GetGt() int64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *IntRules) GetGte() int64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *IntRules) GetLt() int64 {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return 0
+}
+
+func (x *IntRules) GetLte() int64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *IntRules) GetIn() []int64 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+// UintRules are the constraints of unsigned integer fields.
+type UintRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bounds of the integer, exclusive (gt, lt) or inclusive (gte, lte).
+	Gt  *uint64 `protobuf:"varint,1,opt,name=gt,proto3,oneof" json:"gt,omitempty"`
+	Gte *uint64 `protobuf:"varint,2,opt,name=gte,proto3,oneof" json:"gte,omitempty"`
+	Lt  *uint64 `protobuf:"varint,3,opt,name=lt,proto3,oneof" json:"lt,omitempty"`
+	Lte *uint64 `protobuf:"varint,4,opt,name=lte,proto3,oneof" json:"lte,omitempty"`
+	// The values the integer must be one of, if not empty.
+	In            []uint64 `protobuf:"varint,5,rep,packed,name=in,proto3" json:"in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UintRules) Reset() {
+	*x = UintRules{}
+	mi := &file_validate_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UintRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UintRules) ProtoMessage() {}
+
+func (x *UintRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UintRules.ProtoReflect.Descriptor instead.
+func (*UintRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UintRules) GetGt() uint64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *UintRules) GetGte() uint64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *UintRules) GetLt() uint64 {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return 0
+}
+
+func (x *UintRules) GetLte() uint64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *UintRules) GetIn() []uint64 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+// DoubleRules are the constraints of float and double fields.
+type DoubleRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bounds of the number, exclusive (gt, lt) or inclusive (gte, lte).
+	Gt  *float64 `protobuf:"fixed64,1,opt,name=gt,proto3,oneof" json:"gt,omitempty"`
+	Gte *float64 `protobuf:"fixed64,2,opt,name=gte,proto3,oneof" json:"gte,omitempty"`
+	Lt  *float64 `protobuf:"fixed64,3,opt,name=lt,proto3,oneof" json:"lt,omitempty"`
+	Lte *float64 `protobuf:"fixed64,4,opt,name=lte,proto3,oneof" json:"lte,omitempty"`
+	// The number must not be infinite or NaN.
+	Finite        bool `protobuf:"varint,5,opt,name=finite,proto3" json:"finite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoubleRules) Reset() {
+	*x = DoubleRules{}
+	mi := &file_validate_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoubleRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoubleRules) ProtoMessage() {}
+
+func (x *DoubleRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoubleRules.ProtoReflect.Descriptor instead.
+func (*DoubleRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DoubleRules) GetGt() float64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetGte() float64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetLt() float64 {
+	if x != nil && x.Lt != nil {
+		return *x.Lt
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetLte() float64 {
+	if x != nil && x.Lte != nil {
+		return *x.Lte
+	}
+	return 0
+}
+
+func (x *DoubleRules) GetFinite() bool {
+	if x != nil {
+		return x.Finite
+	}
+	return false
+}
+
+// EnumRules are the constraints of enum fields.
+type EnumRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The value must be one of the values defined by the enum.
+	DefinedOnly bool `protobuf:"varint,1,opt,name=defined_only,json=definedOnly,proto3" json:"defined_only,omitempty"`
+	// The numbers of the values the enum must be one of, if not empty.
+	In []int32 `protobuf:"varint,2,rep,packed,name=in,proto3" json:"in,omitempty"`
+	// The numbers of the values the enum must not be.
+	NotIn         []int32 `protobuf:"varint,3,rep,packed,name=not_in,json=notIn,proto3" json:"not_in,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnumRules) Reset() {
+	*x = EnumRules{}
+	mi := &file_validate_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnumRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnumRules) ProtoMessage() {}
+
+func (x *EnumRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnumRules.ProtoReflect.Descriptor instead.
+func (*EnumRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *EnumRules) GetDefinedOnly() bool {
+	if x != nil {
+		return x.DefinedOnly
+	}
+	return false
+}
+
+func (x *EnumRules) GetIn() []int32 {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *EnumRules) GetNotIn() []int32 {
+	if x != nil {
+		return x.NotIn
+	}
+	return nil
+}
+
+// RepeatedRules are the constraints of repeated fields.
+type RepeatedRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The minimum and maximum number of items.
+	MinItems *uint64 `protobuf:"varint,1,opt,name=min_items,json=minItems,proto3,oneof" json:"min_items,omitempty"`
+	MaxItems *uint64 `protobuf:"varint,2,opt,name=max_items,json=maxItems,proto3,oneof" json:"max_items,omitempty"`
+	// Items of scalar fields must be unique.
+	Unique bool `protobuf:"varint,3,opt,name=unique,proto3" json:"unique,omitempty"`
+	// The constraints of each item.
+	Items         *FieldRules `protobuf:"bytes,4,opt,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RepeatedRules) Reset() {
+	*x = RepeatedRules{}
+	mi := &file_validate_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RepeatedRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepeatedRules) ProtoMessage() {}
+
+func (x *RepeatedRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepeatedRules.ProtoReflect.Descriptor instead.
+func (*RepeatedRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RepeatedRules) GetMinItems() uint64 {
+	if x != nil && x.MinItems != nil {
+		return *x.MinItems
+	}
+	return 0
+}
+
+func (x *RepeatedRules) GetMaxItems() uint64 {
+	if x != nil && x.MaxItems != nil {
+		return *x.MaxItems
+	}
+	return 0
+}
+
+func (x *RepeatedRules) GetUnique() bool {
+	if x != nil {
+		return x.Unique
+	}
+	return false
+}
+
+func (x *RepeatedRules) GetItems() *FieldRules {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// MapRules are the constraints of map fields.
+type MapRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The minimum and maximum number of entries.
+	MinPairs *uint64 `protobuf:"varint,1,opt,name=min_pairs,json=minPairs,proto3,oneof" json:"min_pairs,omitempty"`
+	MaxPairs *uint64 `protobuf:"varint,2,opt,name=max_pairs,json=maxPairs,proto3,oneof" json:"max_pairs,omitempty"`
+	// The constraints of each key and value.
+	Keys          *FieldRules `protobuf:"bytes,3,opt,name=keys,proto3" json:"keys,omitempty"`
+	Values        *FieldRules `protobuf:"bytes,4,opt,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MapRules) Reset() {
+	*x = MapRules{}
+	mi := &file_validate_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MapRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapRules) ProtoMessage() {}
+
+func (x *MapRules) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapRules.ProtoReflect.Descriptor instead.
+func (*MapRules) Descriptor() ([]byte, []int) {
+	return file_validate_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MapRules) GetMinPairs() uint64 {
+	if x != nil && x.MinPairs != nil {
+		return *x.MinPairs
+	}
+	return 0
+}
+
+func (x *MapRules) GetMaxPairs() uint64 {
+	if x != nil && x.MaxPairs != nil {
+		return *x.MaxPairs
+	}
+	return 0
+}
+
+func (x *MapRules) GetKeys() *FieldRules {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *MapRules) GetValues() *FieldRules {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var file_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldRules)(nil),
+		Field:         51180,
+		Name:          "apitypes.rules",
+		Tag:           "bytes,51180,opt,name=rules",
+		Filename:      "validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// Constraints of the field, which are checked by ValidateObject and documented within
+	// the schemas generated from the message, ie:
+	//
+	//   string name = 1 [(apitypes.rules).required = true, (apitypes.rules).string.max_len = 64];
+	//
+	// optional apitypes.FieldRules rules = 51180;
+	E_Rules = &file_validate_proto_extTypes[0]
+)
+
+var File_validate_proto protoreflect.FileDescriptor
+
+const file_validate_proto_rawDesc = "" +
+	"\n" +
+	"\x0evalidate.proto\x12\bapitypes\x1a google/protobuf/descriptor.proto\"\x9d\x03\n" +
+	"\n" +
+	"FieldRules\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\bR\brequired\x12/\n" +
+	"\x06string\x18\x02 \x01(\v2\x15.apitypes.StringRulesH\x00R\x06string\x12,\n" +
+	"\x05bytes\x18\x03 \x01(\v2\x14.apitypes.BytesRulesH\x00R\x05bytes\x12&\n" +
+	"\x03int\x18\x04 \x01(\v2\x12.apitypes.IntRulesH\x00R\x03int\x12)\n" +
+	"\x04uint\x18\x05 \x01(\v2\x13.apitypes.UintRulesH\x00R\x04uint\x12/\n" +
+	"\x06double\x18\x06 \x01(\v2\x15.apitypes.DoubleRulesH\x00R\x06double\x12)\n" +
+	"\x04enum\x18\a \x01(\v2\x13.apitypes.EnumRulesH\x00R\x04enum\x125\n" +
+	"\brepeated\x18\b \x01(\v2\x17.apitypes.RepeatedRulesH\x00R\brepeated\x12&\n" +
+	"\x03map\x18\t \x01(\v2\x12.apitypes.MapRulesH\x00R\x03mapB\x06\n" +
+	"\x04type\"\x8b\x01\n" +
+	"\vStringRules\x12\x1c\n" +
+	"\amin_len\x18\x01 \x01(\x04H\x00R\x06minLen\x88\x01\x01\x12\x1c\n" +
+	"\amax_len\x18\x02 \x01(\x04H\x01R\x06maxLen\x88\x01\x01\x12\x18\n" +
+	"\apattern\x18\x03 \x01(\tR\apattern\x12\x0e\n" +
+	"\x02in\x18\x04 \x03(\tR\x02inB\n" +
+	"\n" +
+	"\b_min_lenB\n" +
+	"\n" +
+	"\b_max_len\"`\n" +
+	"\n" +
+	"BytesRules\x12\x1c\n" +
+	"\amin_len\x18\x01 \x01(\x04H\x00R\x06minLen\x88\x01\x01\x12\x1c\n" +
+	"\amax_len\x18\x02 \x01(\x04H\x01R\x06maxLen\x88\x01\x01B\n" +
+	"\n" +
+	"\b_min_lenB\n" +
+	"\n" +
+	"\b_max_len\"\x90\x01\n" +
+	"\bIntRules\x12\x13\n" +
+	"\x02gt\x18\x01 \x01(\x03H\x00R\x02gt\x88\x01\x01\x12\x15\n" +
+	"\x03gte\x18\x02 \x01(\x03H\x01R\x03gte\x88\x01\x01\x12\x13\n" +
+	"\x02lt\x18\x03 \x01(\x03H\x02R\x02lt\x88\x01\x01\x12\x15\n" +
+	"\x03lte\x18\x04 \x01(\x03H\x03R\x03lte\x88\x01\x01\x12\x0e\n" +
+	"\x02in\x18\x05 \x03(\x03R\x02inB\x05\n" +
+	"\x03_gtB\x06\n" +
+	"\x04_gteB\x05\n" +
+	"\x03_ltB\x06\n" +
+	"\x04_lte\"\x91\x01\n" +
+	"\tUintRules\x12\x13\n" +
+	"\x02gt\x18\x01 \x01(\x04H\x00R\x02gt\x88\x01\x01\x12\x15\n" +
+	"\x03gte\x18\x02 \x01(\x04H\x01R\x03gte\x88\x01\x01\x12\x13\n" +
+	"\x02lt\x18\x03 \x01(\x04H\x02R\x02lt\x88\x01\x01\x12\x15\n" +
+	"\x03lte\x18\x04 \x01(\x04H\x03R\x03lte\x88\x01\x01\x12\x0e\n" +
+	"\x02in\x18\x05 \x03(\x04R\x02inB\x05\n" +
+	"\x03_gtB\x06\n" +
+	"\x04_gteB\x05\n" +
+	"\x03_ltB\x06\n" +
+	"\x04_lte\"\x9b\x01\n" +
+	"\vDoubleRules\x12\x13\n" +
+	"\x02gt\x18\x01 \x01(\x01H\x00R\x02gt\x88\x01\x01\x12\x15\n" +
+	"\x03gte\x18\x02 \x01(\x01H\x01R\x03gte\x88\x01\x01\x12\x13\n" +
+	"\x02lt\x18\x03 \x01(\x01H\x02R\x02lt\x88\x01\x01\x12\x15\n" +
+	"\x03lte\x18\x04 \x01(\x01H\x03R\x03lte\x88\x01\x01\x12\x16\n" +
+	"\x06finite\x18\x05 \x01(\bR\x06finiteB\x05\n" +
+	"\x03_gtB\x06\n" +
+	"\x04_gteB\x05\n" +
+	"\x03_ltB\x06\n" +
+	"\x04_lte\"U\n" +
+	"\tEnumRules\x12!\n" +
+	"\fdefined_only\x18\x01 \x01(\bR\vdefinedOnly\x12\x0e\n" +
+	"\x02in\x18\x02 \x03(\x05R\x02in\x12\x15\n" +
+	"\x06not_in\x18\x03 \x03(\x05R\x05notIn\"\xb3\x01\n" +
+	"\rRepeatedRules\x12 \n" +
+	"\tmin_items\x18\x01 \x01(\x04H\x00R\bminItems\x88\x01\x01\x12 \n" +
+	"\tmax_items\x18\x02 \x01(\x04H\x01R\bmaxItems\x88\x01\x01\x12\x16\n" +
+	"\x06unique\x18\x03 \x01(\bR\x06unique\x12*\n" +
+	"\x05items\x18\x04 \x01(\v2\x14.apitypes.FieldRulesR\x05itemsB\f\n" +
+	"\n" +
+	"_min_itemsB\f\n" +
+	"\n" +
+	"_max_items\"\xc2\x01\n" +
+	"\bMapRules\x12 \n" +
+	"\tmin_pairs\x18\x01 \x01(\x04H\x00R\bminPairs\x88\x01\x01\x12 \n" +
+	"\tmax_pairs\x18\x02 \x01(\x04H\x01R\bmaxPairs\x88\x01\x01\x12(\n" +
+	"\x04keys\x18\x03 \x01(\v2\x14.apitypes.FieldRulesR\x04keys\x12,\n" +
+	"\x06values\x18\x04 \x01(\v2\x14.apitypes.FieldRulesR\x06valuesB\f\n" +
+	"\n" +
+	"_min_pairsB\f\n" +
+	"\n" +
+	"_max_pairs:K\n" +
+	"\x05rules\x12\x1d.google.protobuf.FieldOptions\x18\xec\x8f\x03 \x01(\v2\x14.apitypes.FieldRulesR\x05rulesB\x12Z\x10../serializationb\x06proto3"
+
+var (
+	file_validate_proto_rawDescOnce sync.Once
+	file_validate_proto_rawDescData []byte
+)
+
+func file_validate_proto_rawDescGZIP() []byte {
+	file_validate_proto_rawDescOnce.Do(func() {
+		file_validate_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_validate_proto_rawDesc), len(file_validate_proto_rawDesc)))
+	})
+	return file_validate_proto_rawDescData
+}
+
+var file_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_validate_proto_goTypes = []any{
+	(*FieldRules)(nil),                // 0: apitypes.FieldRules
+	(*StringRules)(nil),               // 1: apitypes.StringRules
+	(*BytesRules)(nil),                // 2: apitypes.BytesRules
+	(*IntRules)(nil),                  // 3: apitypes.IntRules
+	(*UintRules)(nil),                 // 4: apitypes.UintRules
+	(*DoubleRules)(nil),               // 5: apitypes.DoubleRules
+	(*EnumRules)(nil),                 // 6: apitypes.EnumRules
+	(*RepeatedRules)(nil),             // 7: apitypes.RepeatedRules
+	(*MapRules)(nil),                  // 8: apitypes.MapRules
+	(*descriptorpb.FieldOptions)(nil), // 9: google.protobuf.FieldOptions
+}
+var file_validate_proto_depIdxs = []int32{
+	1,  // 0: apitypes.FieldRules.string:type_name -> apitypes.StringRules
+	2,  // 1: apitypes.FieldRules.bytes:type_name -> apitypes.BytesRules
+	3,  // 2: apitypes.FieldRules.int:type_name -> apitypes.IntRules
+	4,  // 3: apitypes.FieldRules.uint:type_name -> apitypes.UintRules
+	5,  // 4: apitypes.FieldRules.double:type_name -> apitypes.DoubleRules
+	6,  // 5: apitypes.FieldRules.enum:type_name -> apitypes.EnumRules
+	7,  // 6: apitypes.FieldRules.repeated:type_name -> apitypes.RepeatedRules
+	8,  // 7: apitypes.FieldRules.map:type_name -> apitypes.MapRules
+	0,  // 8: apitypes.RepeatedRules.items:type_name -> apitypes.FieldRules
+	0,  // 9: apitypes.MapRules.keys:type_name -> apitypes.FieldRules
+	0,  // 10: apitypes.MapRules.values:type_name -> apitypes.FieldRules
+	9,  // 11: apitypes.rules:extendee -> google.protobuf.FieldOptions
+	0,  // 12: apitypes.rules:type_name -> apitypes.FieldRules
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	12, // [12:13] is the sub-list for extension type_name
+	11, // [11:12] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_validate_proto_init() }
+func file_validate_proto_init() {
+	if File_validate_proto != nil {
+		return
+	}
+	file_validate_proto_msgTypes[0].OneofWrappers = []any{
+		(*FieldRules_String_)(nil),
+		(*FieldRules_Bytes)(nil),
+		(*FieldRules_Int)(nil),
+		(*FieldRules_Uint)(nil),
+		(*FieldRules_Double)(nil),
+		(*FieldRules_Enum)(nil),
+		(*FieldRules_Repeated)(nil),
+		(*FieldRules_Map)(nil),
+	}
+	file_validate_proto_msgTypes[1].OneofWrappers = []any{}
+	file_validate_proto_msgTypes[2].OneofWrappers = []any{}
+	file_validate_proto_msgTypes[3].OneofWrappers = []any{}
+	file_validate_proto_msgTypes[4].OneofWrappers = []any{}
+	file_validate_proto_msgTypes[5].OneofWrappers = []any{}
+	file_validate_proto_msgTypes[7].OneofWrappers = []any{}
+	file_validate_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_validate_proto_rawDesc), len(file_validate_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_validate_proto_goTypes,
+		DependencyIndexes: file_validate_proto_depIdxs,
+		MessageInfos:      file_validate_proto_msgTypes,
+		ExtensionInfos:    file_validate_proto_extTypes,
+	}.Build()
+	File_validate_proto = out.File
+	file_validate_proto_goTypes = nil
+	file_validate_proto_depIdxs = nil
+}
@@ -0,0 +1,263 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package serialization
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// testRulesMessage returns a message describing:
+//
+//	message Account {
+//	  string name = 1 [(apitypes.rules) = {required: true, string: {min_len: 3, max_len: 8, pattern: "^[a-z]+$"}}];
+//	  int32 age = 2 [(apitypes.rules).int = {gte: 0, lte: 150}];
+//	  google.protobuf.DoubleValue score = 3 [(apitypes.rules).double = {gt: 0, finite: true}];
+//	  repeated string tags = 4 [(apitypes.rules).repeated = {max_items: 3, unique: true, items: {string: {min_len: 2}}}];
+//	  map<string, string> labels = 5 [(apitypes.rules).map = {max_pairs: 2, values: {string: {max_len: 4}}}];
+//	  Color color = 6 [(apitypes.rules).enum = {defined_only: true, not_in: [0]}];
+//	  Account parent = 7;
+//	  string role = 8 [(apitypes.rules).string.in = ["admin", "user"]];
+//	}
+//
+//	enum Color {
+//	  COLOR_UNSPECIFIED = 0;
+//	  BLUE = 1;
+//	}
+func testRulesMessage(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, rules *FieldRules) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+
+		if rules != nil {
+			f.Options = &descriptorpb.FieldOptions{}
+			proto.SetExtension(f.Options, E_Rules, rules)
+		}
+
+		return f
+	}
+
+	tags := field("tags", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", &FieldRules{Type: &FieldRules_Repeated{Repeated: &RepeatedRules{
+		MaxItems: proto.Uint64(3),
+		Unique:   true,
+		Items:    &FieldRules{Type: &FieldRules_String_{String_: &StringRules{MinLen: proto.Uint64(2)}}},
+	}}})
+	tags.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+
+	labels := field("labels", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.rules.Account.LabelsEntry", &FieldRules{Type: &FieldRules_Map{Map: &MapRules{
+		MaxPairs: proto.Uint64(2),
+		Values:   &FieldRules{Type: &FieldRules_String_{String_: &StringRules{MaxLen: proto.Uint64(4)}}},
+	}}})
+	labels.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/rules.proto"),
+		Package:    proto.String("test.rules"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/wrappers.proto", "validate.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Account"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", &FieldRules{Required: true, Type: &FieldRules_String_{String_: &StringRules{
+					MinLen:  proto.Uint64(3),
+					MaxLen:  proto.Uint64(8),
+					Pattern: "^[a-z]+$",
+				}}}),
+				field("age", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, "", &FieldRules{Type: &FieldRules_Int{Int: &IntRules{
+					Gte: proto.Int64(0),
+					Lte: proto.Int64(150),
+				}}}),
+				field("score", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.DoubleValue", &FieldRules{Type: &FieldRules_Double{Double: &DoubleRules{
+					Gt:     proto.Float64(0),
+					Finite: true,
+				}}}),
+				tags,
+				labels,
+				field("color", 6, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".test.rules.Color", &FieldRules{Type: &FieldRules_Enum{Enum: &EnumRules{
+					DefinedOnly: true,
+					NotIn:       []int32{0},
+				}}}),
+				field("parent", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.rules.Account", nil),
+				field("role", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", &FieldRules{Type: &FieldRules_String_{String_: &StringRules{
+					In: []string{"admin", "user"},
+				}}}),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("LabelsEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", nil),
+					field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", nil),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Color"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("COLOR_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("BLUE"), Number: proto.Int32(1)},
+			},
+		}},
+	}
+
+	fd, e := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	return fd.Messages().ByName("Account")
+}
+
+func TestValidateObject(t *testing.T) {
+	md := testRulesMessage(t)
+
+	tests := []struct {
+		name  string
+		input string
+		want  []apierror.FieldViolation
+	}{
+		{
+			"valid",
+			`{"name":"alice","age":30,"score":1.5,"tags":["ab","cd"],"labels":{"a":"b"},"color":"BLUE","parent":{"name":"bob","color":"BLUE","role":"user"},"role":"admin"}`,
+			nil,
+		},
+		{
+			"missing required",
+			`{"color":"BLUE","role":"user"}`,
+			[]apierror.FieldViolation{{Field: "name", Description: "is required"}},
+		},
+		{
+			"every violation",
+			`{"name":"Al","age":200,"score":-1,"tags":["a","bc","bc","de"],"labels":{"x":"toolong","y":"ok","z":"ok"},"color":7,"parent":{"name":"bob"},"role":"root"}`,
+			[]apierror.FieldViolation{
+				{Field: "name", Description: "must be at least 3 characters long"},
+				{Field: "name", Description: "must match the pattern ^[a-z]+$"},
+				{Field: "age", Description: "must be less than or equal to 150"},
+				{Field: "score", Description: "must be greater than 0"},
+				{Field: "tags", Description: "must have at most 3 items"},
+				{Field: "tags[0]", Description: "must be at least 2 characters long"},
+				{Field: "tags", Description: "must have unique items"},
+				{Field: "labels", Description: "must have at most 2 entries"},
+				{Field: `labels["x"]`, Description: "must be at most 4 characters long"},
+				{Field: "color", Description: "must be one of the values of Color"},
+				{Field: "parent.color", Description: "must not be COLOR_UNSPECIFIED"},
+				{Field: "parent.role", Description: "must be one of admin, user"},
+				{Field: "role", Description: "must be one of admin, user"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := dynamicpb.NewMessage(md)
+			if e := protojson.Unmarshal([]byte(tt.input), msg); e != nil {
+				t.Fatal(e)
+			}
+
+			e := ValidateObject(msg)
+			if tt.want == nil {
+				if e != nil {
+					t.Fatalf("ValidateObject() = %v", e)
+				}
+
+				return
+			}
+
+			apiErr, ok := apierror.As(e)
+			if !ok || apiErr.Status != http.StatusUnprocessableEntity {
+				t.Fatalf("ValidateObject() = %v, want 422", e)
+			}
+
+			if !reflect.DeepEqual(apiErr.Details, tt.want) {
+				t.Errorf("details = %v, want %v", apiErr.Details, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateObjectInvalidPattern(t *testing.T) {
+	v := &objectValidator{}
+	v.string("value", &StringRules{Pattern: "("}, "field")
+
+	if v.err == nil {
+		t.Errorf("invalid pattern didn't return an error")
+	}
+}
+
+func TestFieldRulesSchema(t *testing.T) {
+	schemas := NewSchemasFromMessage(testRulesMessage(t))
+	account := schemas[0]
+
+	if !reflect.DeepEqual(account.Required, []string{"name"}) {
+		t.Errorf("required = %v, want [name]", account.Required)
+	}
+
+	name := account.Properties["name"]
+	if *name.MinLength != 3 || *name.MaxLength != 8 || name.Pattern != "^[a-z]+$" {
+		t.Errorf("name = %d %d %s", *name.MinLength, *name.MaxLength, name.Pattern)
+	}
+
+	age := account.Properties["age"]
+	if *age.Minimum != 0 || *age.Maximum != 150 || age.ExclusiveMinimum || age.ExclusiveMaximum {
+		t.Errorf("age = %v %v", *age.Minimum, *age.Maximum)
+	}
+
+	if score := account.Properties["score"]; *score.Minimum != 0 || !score.ExclusiveMinimum {
+		t.Errorf("score minimum = %v exclusive %v, want exclusive 0", *score.Minimum, score.ExclusiveMinimum)
+	}
+
+	tags := account.Properties["tags"]
+	if *tags.MaxItems != 3 || !tags.UniqueItems || *tags.Items.Schema.MinLength != 2 {
+		t.Errorf("tags = %d %v %d", *tags.MaxItems, tags.UniqueItems, *tags.Items.Schema.MinLength)
+	}
+
+	labels := account.Properties["labels"]
+	if *labels.MaxProperties != 2 || *labels.AdditionalProperties.Schema.MaxLength != 4 {
+		t.Errorf("labels = %d %d", *labels.MaxProperties, *labels.AdditionalProperties.Schema.MaxLength)
+	}
+
+	if role := account.Properties["role"]; !reflect.DeepEqual(role.Enum, []interface{}{"admin", "user"}) {
+		t.Errorf("role enum = %v", role.Enum)
+	}
+
+	if color := account.Properties["color"]; color.Ref.String() != "#/definitions/Color" {
+		t.Errorf("color = %v, want reference", color.Ref.String())
+	}
+}
@@ -74,6 +74,18 @@ var (
 		"Specify a prefix to serve all routes from, logically. Defaults to ''",
 		"",
 	)
+
+	apiServerOpenAPIEnabled *manager.ConfigValue = manager.NewConfigValue(
+		"apiServerOpenAPIEnabled",
+		"Specify whether the OpenAPI 3.1 document for the application is served at <prefix>/openapi.json and <prefix>/openapi.yaml.",
+		true,
+	)
+
+	apiServerDocsEnabled *manager.ConfigValue = manager.NewConfigValue(
+		"apiServerDocsEnabled",
+		"Specify whether an embedded API documentation UI for the OpenAPI 3.1 document is served at <prefix>/docs. Requires apiServerOpenAPIEnabled.",
+		false,
+	)
 )
 
 func (s *APIServer) Configs() *[]*manager.ConfigValue {
@@ -88,5 +100,7 @@ func (s *APIServer) Configs() *[]*manager.ConfigValue {
 		apiServerWriteTimeout,
 		apiServerIdleTimeout,
 		apiServerPrefix,
+		apiServerOpenAPIEnabled,
+		apiServerDocsEnabled,
 	}
 }
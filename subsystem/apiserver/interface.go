@@ -19,15 +19,18 @@
 package apiserver
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/fasthttp/router"
+	"github.com/fire833/go-api-utils/buildinfo"
 	manager "github.com/fire833/go-api-utils/mgr"
+	"github.com/fire833/go-api-utils/openapi"
 	"github.com/fire833/go-api-utils/serialization"
+	"github.com/go-openapi/spec"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/valyala/fasthttp"
-	"k8s.io/klog/v2"
 )
 
 var SERVER *APIServer
@@ -53,11 +56,12 @@ type APIServer struct {
 	// router contains the route state for this api.
 	router *router.Router
 
-	// spec contains the generated Swagger 2.0 spec that will be implemented by this api.
-	// spec2 *spec.Swagger
+	// spec2 contains the Swagger 2.0 spec registered by the application for this api.
+	spec2 *spec.Swagger
+
+	// spec3 contains the OpenAPI 3.1 spec that will be implemented by this api, converted from spec2.
+	spec3 *openapi.Document
 
-	// spec contains the generated OpenAPI 3.0 spec that will be implemented by this api.
-	// spec3 openapi.OpenAPI3Spec
 	requestCount prometheus.Counter
 }
 
@@ -87,7 +91,7 @@ func (s *APIServer) Initialize(reg *manager.SystemRegistrar) error {
 	s.router.PanicHandler = func(rc *fasthttp.RequestCtx, i interface{}) {
 		// Since we should really NEVER panic inside of a handler (unless we have a bug),
 		// log this error as a global error.
-		klog.Errorf("request handler panicked: %s - %v", rc.Request.RequestURI(), i)
+		logger.ErrorContext(rc, "request handler panicked", "uri", string(rc.Request.RequestURI()), "panic", i)
 
 		serialization.GenericInternalErrorResponseHandler(rc)
 	}
@@ -100,15 +104,40 @@ func (s *APIServer) Initialize(reg *manager.SystemRegistrar) error {
 	})
 
 	// Register from the global object.
-	klog.V(5).Info("api: registering api endpoints to router")
+	logger.Log(context.Background(), manager.Verbosity(5), "registering api endpoints to router")
 	reg.Registration.RegisterEndpoints(apiServerPrefix.GetString(), s.router)
 
-	klog.V(5).Info("api: initializing fasthttp server")
+	s.spec2 = newSwagger(reg)
+
+	if routes, ok := reg.Registration.(manager.RouteRegistration); ok {
+		for _, e := range routes.RegisterRoutes(apiServerPrefix.GetString()) {
+			if err := e.Register(s.router, s.spec2); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.spec3 = openapi.FromSwagger(s.spec2)
+
+	if apiServerOpenAPIEnabled.GetBool() {
+		document := func() *openapi.Document { return s.spec3 }
+		s.router.GET(apiServerPrefix.GetString()+"/openapi.json", openapi.JSONHandler(document))
+		s.router.GET(apiServerPrefix.GetString()+"/openapi.yaml", openapi.YAMLHandler(document))
+
+		if apiServerDocsEnabled.GetBool() {
+			docs := openapi.DocsHandler(apiServerPrefix.GetString()+"/docs", apiServerPrefix.GetString()+"/openapi.json", s.spec3.Info.Title)
+			s.router.GET(apiServerPrefix.GetString()+"/docs", docs)
+			s.router.GET(apiServerPrefix.GetString()+"/docs/{FILE}", docs)
+		}
+	}
+
+	logger.Log(context.Background(), manager.Verbosity(5), "initializing fasthttp server")
+	handler := withRequestContext(serialization.CompressionHandler(s.router.Handler))
 	s.server = &fasthttp.Server{
 		Handler: func(ctx *fasthttp.RequestCtx) {
 			defer s.requestCount.Inc()
 			// TODO add auth middleware here to be done before traversing the route tree.
-			s.router.Handler(ctx)
+			handler(ctx)
 		},
 
 		// overwrite the server name for a bit more obfuscation.
@@ -136,26 +165,59 @@ func (s *APIServer) Initialize(reg *manager.SystemRegistrar) error {
 		IdleTimeout:     time.Duration(time.Second * time.Duration(apiServerIdleTimeout.GetUint())),
 	}
 
-	// For now, we don't need the swagger specification to be in memory with the process,
-	// that's just extra overhead that currently won't do anything.
-	// s.spec2, _ = app.GenerateSpec(&app.SwaggerGenOpts{})
-
 	s.IsInitialized = true
 
 	return nil
 }
 
+// newSwagger builds the Swagger 2.0 spec for the application from its registration.
+func newSwagger(reg *manager.SystemRegistrar) *spec.Swagger {
+	version := buildinfo.Get().Version
+	if version == "" {
+		version = "0.0.0"
+	}
+
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Swagger:  "2.0",
+			Consumes: serialization.MediaTypes(),
+			Produces: serialization.MediaTypes(),
+			Info: &spec.Info{
+				InfoProps: spec.InfoProps{
+					Title:   reg.AppName + " API",
+					Version: version,
+				},
+			},
+			Paths:       &spec.Paths{Paths: map[string]spec.PathItem{}},
+			Definitions: spec.Definitions{},
+			Responses:   map[string]spec.Response{},
+		},
+	}
+
+	// Document the generic error responses returned by the serialization package.
+	for _, schema := range serialization.NewSchemasFromObject(&serialization.GenericErrorResponse{}) {
+		swagger.Definitions[schema.Title] = *schema
+	}
+
+	for name, resp := range serialization.GenericErrorResponses {
+		swagger.Responses[name] = resp
+	}
+
+	reg.Registration.RegisterSwagger2(apiServerPrefix.GetString(), swagger.Paths, swagger.Definitions)
+	return swagger
+}
+
 func (s *APIServer) SyncStart() {
-	klog.V(2).Infof("serving apiserver on %s:%d", apiServerListenIp.GetString(), apiServerListenPort.GetUint16())
+	logger.Log(context.Background(), manager.Verbosity(2), "serving apiserver", "address", apiServerListenIp.GetString(), "port", apiServerListenPort.GetUint16())
 	if e := s.server.ListenAndServe(fmt.Sprintf("%s:%d", apiServerListenIp.GetString(), apiServerListenPort.GetUint16())); e != nil {
-		klog.Errorf("unable to start api: %s", e.Error())
+		logger.Error("unable to start api", "error", e)
 		// os.Exit(1) // TODO perhaps make a better exit strategy here.
 	}
 }
 
 func (s *APIServer) Shutdown() {
 	if e := s.server.Shutdown(); e != nil {
-		klog.Errorf("unable to gracefully shutdown apiserver subsystem: %v", e)
+		logger.Error("unable to gracefully shutdown apiserver subsystem", "error", e)
 	}
 
 	s.IsShutdown = true
@@ -0,0 +1,106 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package apiserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	manager "github.com/fire833/go-api-utils/mgr"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader is the header carrying the ID of a request. If a client provides one it is
+// used for the request, otherwise one is generated, and it is always returned in the response.
+const RequestIDHeader string = "X-Request-ID"
+
+// Longest client provided request ID that will be accepted.
+const maxRequestIDLength int = 128
+
+var logger = manager.Logger("api")
+
+// withRequestContext wraps the handler to assign every request an ID, which is attached to the
+// request along with any span context propagated by the caller so that records logged with it
+// can be correlated with the caller. No spans are started here, that is left to the application.
+func withRequestContext(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		id := string(ctx.Request.Header.Peek(RequestIDHeader))
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		ctx.Response.Header.Set(RequestIDHeader, id)
+
+		parent := otel.GetTextMapPropagator().Extract(context.Background(), requestHeaderCarrier{&ctx.Request.Header})
+		manager.SetRequestLogContext(ctx, id, trace.SpanContextFromContext(parent))
+
+		next(ctx)
+
+		logger.DebugContext(ctx, "handled request",
+			"method", string(ctx.Method()),
+			"path", string(ctx.Path()),
+			"status", ctx.Response.StatusCode(),
+			"duration", time.Since(ctx.Time()),
+		)
+	}
+}
+
+// Only accept client request IDs that are reasonably sized and printable, so they can be safely
+// echoed back and logged.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// requestHeaderCarrier adapts fasthttp request headers for OpenTelemetry propagators.
+type requestHeaderCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+func (c requestHeaderCarrier) Get(key string) string { return string(c.header.Peek(key)) }
+
+func (c requestHeaderCarrier) Set(key, value string) { c.header.Set(key, value) }
+
+func (c requestHeaderCarrier) Keys() []string {
+	keys := []string{}
+	for key := range c.header.All() {
+		keys = append(keys, string(key))
+	}
+
+	return keys
+}
@@ -0,0 +1,91 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package apiserver
+
+import (
+	"strings"
+	"testing"
+
+	manager "github.com/fire833/go-api-utils/mgr"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithRequestContext(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	// Spans are left to the application, so none should be recorded even with a provider set.
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	var requestID, traceID string
+	handler := withRequestContext(func(ctx *fasthttp.RequestCtx) {
+		requestID = manager.RequestIDFromContext(ctx)
+		traceID = manager.SpanContextFromContext(ctx).TraceID().String()
+	})
+
+	tests := []struct {
+		name        string
+		requestID   string
+		traceparent string
+		wantID      string
+		wantTrace   string
+	}{
+		{"generated", "", "", "", ""},
+		{"propagated", "abc-123", "", "abc-123", ""},
+		{"invalid", "has space", "", "", ""},
+		{"tooLong", strings.Repeat("a", 129), "", "", ""},
+		{"traceparent", "", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", "4bf92f3577b34da6a3ce929d0e0e4736"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestID, traceID = "", ""
+
+			ctx := &fasthttp.RequestCtx{}
+			if tt.requestID != "" {
+				ctx.Request.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			if tt.traceparent != "" {
+				ctx.Request.Header.Set("traceparent", tt.traceparent)
+			}
+
+			handler(ctx)
+
+			header := string(ctx.Response.Header.Peek(RequestIDHeader))
+			if header == "" || header != requestID {
+				t.Errorf("response request ID = %q, handler saw %q", header, requestID)
+			}
+			if tt.wantID != "" && requestID != tt.wantID {
+				t.Errorf("request ID = %q, want %q", requestID, tt.wantID)
+			}
+			if tt.wantID == "" && requestID == tt.requestID {
+				t.Errorf("request ID %q was not regenerated", requestID)
+			}
+			if tt.wantTrace != "" && traceID != tt.wantTrace {
+				t.Errorf("trace ID = %q, want %q", traceID, tt.wantTrace)
+			}
+			if spans := recorder.Started(); len(spans) != 0 {
+				t.Errorf("started %d spans, want none", len(spans))
+			}
+		})
+	}
+}
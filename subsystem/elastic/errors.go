@@ -0,0 +1,51 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package elastic
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/fire833/go-api-utils/apierror"
+)
+
+// TranslateError translates errors returned by the typed client into API errors, so they can be
+// returned from handlers and responded with the appropriate status code. Missing documents and
+// indices are translated to 404, version conflicts to 409, and rejected requests to 429 or 503.
+// Any other error is returned as is.
+func TranslateError(e error) error {
+	var esErr *types.ElasticsearchError
+	if !errors.As(e, &esErr) {
+		return e
+	}
+
+	switch esErr.Status {
+	case http.StatusNotFound:
+		return apierror.New(http.StatusNotFound, "object_not_found", "object was not found").WithCause(e)
+	case http.StatusConflict:
+		return apierror.New(http.StatusConflict, "version_conflict", "object was modified concurrently").WithCause(e)
+	case http.StatusTooManyRequests:
+		return apierror.TooManyRequests("search backend is overloaded, please try again later", 0).WithCause(e)
+	case http.StatusServiceUnavailable:
+		return apierror.Unavailable("search backend is unavailable, please try again later", 0).WithCause(e)
+	default:
+		return e
+	}
+}
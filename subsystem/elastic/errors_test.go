@@ -0,0 +1,61 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package elastic
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/fire833/go-api-utils/apierror"
+)
+
+func TestTranslateError(t *testing.T) {
+	esError := func(status int, typ string) error {
+		e := types.NewElasticsearchError()
+		e.Status = status
+		e.ErrorCause.Type = typ
+		return e
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"notFound", esError(http.StatusNotFound, "document_missing_exception"), http.StatusNotFound},
+		{"indexNotFound", fmt.Errorf("search: %w", esError(http.StatusNotFound, "index_not_found_exception")), http.StatusNotFound},
+		{"conflict", esError(http.StatusConflict, "version_conflict_engine_exception"), http.StatusConflict},
+		{"tooManyRequests", esError(http.StatusTooManyRequests, "es_rejected_execution_exception"), http.StatusTooManyRequests},
+		{"unavailable", esError(http.StatusServiceUnavailable, "cluster_block_exception"), http.StatusServiceUnavailable},
+		{"badRequest", esError(http.StatusBadRequest, "parsing_exception"), http.StatusInternalServerError},
+		{"other", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := TranslateError(tt.err)
+			if status := apierror.StatusOf(e); status != tt.status || !errors.Is(e, tt.err) {
+				t.Errorf("TranslateError() = %v with status %d, want %d wrapping %v", e, status, tt.status, tt.err)
+			}
+		})
+	}
+}
@@ -22,7 +22,6 @@ import (
 	"errors"
 	"os"
 
-	"github.com/elastic/elastic-transport-go/v8/elastictransport"
 	"github.com/elastic/go-elasticsearch/v9"
 	manager "github.com/fire833/go-api-utils/mgr"
 	"github.com/hashicorp/vault/api"
@@ -87,7 +86,7 @@ func (s *ElasticManager) Initialize(reg *manager.SystemRegistrar) error {
 	client, e := elasticsearch.NewTypedClient(elasticsearch.Config{
 		Username: user,
 		Password: pass,
-		Logger:   &elastictransport.JSONLogger{},
+		Logger:   newTransportLogger(),
 	})
 	if e != nil {
 		return e
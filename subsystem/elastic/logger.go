@@ -0,0 +1,69 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package elastic
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	manager "github.com/fire833/go-api-utils/mgr"
+)
+
+// transportLogger implements elastictransport.Logger, logging every round trip to elastic through
+// the manager logger. Failed requests are logged as errors, server errors as warnings, and everything
+// else at debug level. Since the request context is used as the log context, round trips made with a
+// request context carry its request and trace IDs.
+type transportLogger struct {
+	log *slog.Logger
+}
+
+func newTransportLogger() *transportLogger {
+	return &transportLogger{log: manager.Logger(ElasticSubsystemName)}
+}
+
+func (l *transportLogger) LogRoundTrip(req *http.Request, res *http.Response, err error, start time.Time, dur time.Duration) error {
+	ctx := context.Background()
+	attrs := []any{"duration", dur}
+
+	if req != nil {
+		ctx = req.Context()
+		attrs = append(attrs, "method", req.Method, "url", req.URL.Redacted())
+	}
+
+	if res != nil {
+		attrs = append(attrs, "status", res.StatusCode)
+	}
+
+	switch {
+	case err != nil:
+		l.log.ErrorContext(ctx, "elastic request failed", append(attrs, "error", err)...)
+	case res != nil && res.StatusCode >= 500:
+		l.log.WarnContext(ctx, "elastic request returned server error", attrs...)
+	default:
+		l.log.DebugContext(ctx, "elastic request", attrs...)
+	}
+
+	return nil
+}
+
+func (l *transportLogger) RequestBodyEnabled() bool { return false }
+
+func (l *transportLogger) ResponseBodyEnabled() bool { return false }
@@ -0,0 +1,90 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package elastic
+
+import (
+	"github.com/elastic/go-elasticsearch/v9/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/sortorder"
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/pagination"
+)
+
+// Paginate sets the size, sort and search_after of the search request from params, so that it
+// searches for the page after the cursor of the page token, sorted by the fields of order. scope
+// identifies the list, see pagination.NewToken, and should include the query of the request along
+// with the order. One more hit than the size of the page is requested, which is returned, so pass
+// the response to Page to trim its hits to the page.
+func Paginate(req *search.Request, params pagination.Params, scope string, order ...pagination.Order) (int, error) {
+	cursor, e := params.Cursor(scope)
+	if e != nil {
+		return 0, e
+	}
+
+	size := params.Size()
+	limit := size + 1
+	req.Size = &limit
+
+	req.Sort = make([]types.SortCombinations, 0, len(order))
+	for _, o := range order {
+		dir := sortorder.Asc
+		if o.Desc {
+			dir = sortorder.Desc
+		}
+
+		req.Sort = append(req.Sort, types.SortOptions{SortOptions: map[string]types.FieldSort{o.Key: {Order: &dir}}})
+	}
+
+	req.SearchAfter = nil
+	if cursor != nil {
+		if len(cursor.Keys) != len(order) {
+			return 0, apierror.BadRequest(pagination.ErrInvalidToken.Error() + ": token was issued for a different order").
+				WithCause(pagination.ErrInvalidToken)
+		}
+
+		for _, key := range cursor.Keys {
+			req.SearchAfter = append(req.SearchAfter, key)
+		}
+	}
+
+	return size, nil
+}
+
+// Page trims the hits of a response to a request paginated with Paginate to the page of size hits,
+// returning the ListMeta of the page. The next page token is made of the sort values of the last hit
+// within the page, and the total estimate is the total number of hits, if it was tracked.
+func Page(res *search.Response, size int, scope string) ([]types.Hit, *pagination.ListMeta, error) {
+	hits, meta, e := pagination.Page(res.Hits.Hits, size, scope, func(hit types.Hit) []any {
+		keys := make([]any, 0, len(hit.Sort))
+		for _, value := range hit.Sort {
+			keys = append(keys, value)
+		}
+
+		return keys
+	})
+	if e != nil {
+		return nil, nil, e
+	}
+
+	if total := res.Hits.Total; total != nil {
+		meta.TotalEstimate = &total.Value
+	}
+
+	return hits, meta, nil
+}
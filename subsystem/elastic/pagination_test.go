@@ -0,0 +1,105 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package elastic
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v9/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/pagination"
+)
+
+// testSearchResponse returns a search response with hits sorted by the sort values of each hit.
+func testSearchResponse(t *testing.T, total int, sorts ...[]any) *search.Response {
+	t.Helper()
+
+	hits := []map[string]any{}
+	for i, sort := range sorts {
+		hits = append(hits, map[string]any{"_index": "widgets", "_id": string(rune('a' + i)), "sort": sort})
+	}
+
+	data, e := json.Marshal(map[string]any{
+		"took":      1,
+		"timed_out": false,
+		"_shards":   map[string]any{"total": 1, "successful": 1, "failed": 0},
+		"hits":      map[string]any{"total": map[string]any{"value": total, "relation": "eq"}, "hits": hits},
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	res := search.NewResponse()
+	if e := json.Unmarshal(data, res); e != nil {
+		t.Fatal(e)
+	}
+
+	return res
+}
+
+func TestPaginate(t *testing.T) {
+	order := []pagination.Order{{Key: "created"}, {Key: "id", Desc: true}}
+
+	// The first page has no search_after, and requests one more hit than the page.
+	req := search.NewRequest()
+	size, e := Paginate(req, pagination.Params{Limit: 2}, "/widgets", order...)
+	if e != nil || size != 2 || req.Size == nil || *req.Size != 3 || req.SearchAfter != nil {
+		t.Fatalf("Paginate() = %d, %v, request size %v search_after %v", size, e, req.Size, req.SearchAfter)
+	}
+
+	sort, _ := json.Marshal(req.Sort)
+	if want := `[{"created":{"order":"asc"}},{"id":{"order":"desc"}}]`; string(sort) != want {
+		t.Errorf("Paginate() sort = %s, want %s", sort, want)
+	}
+
+	hits, meta, e := Page(testSearchResponse(t, 5, []any{1, "e"}, []any{1, "c"}, []any{2, "d"}), size, "/widgets")
+	if e != nil || len(hits) != 2 || meta.GetNextPageToken() == "" || meta.GetTotalEstimate() != 5 {
+		t.Fatalf("Page() = %d hits, %v, %v, want 2 hits, a next page and a total of 5", len(hits), meta, e)
+	}
+
+	// The next page is searched for after the sort values of the last hit within the page.
+	params := pagination.Params{Limit: 2, PageToken: meta.GetNextPageToken()}
+
+	req = search.NewRequest()
+	if _, e := Paginate(req, params, "/widgets", order...); e != nil {
+		t.Fatal(e)
+	}
+
+	if want := []types.FieldValue{1.0, "c"}; !reflect.DeepEqual(req.SearchAfter, want) {
+		t.Errorf("Paginate() search_after = %#v, want %#v", req.SearchAfter, want)
+	}
+
+	hits, meta, e = Page(testSearchResponse(t, 5, []any{2, "d"}), size, "/widgets")
+	if e != nil || len(hits) != 1 || meta.GetNextPageToken() != "" {
+		t.Errorf("Page() = %d hits, %v, %v, want the last page", len(hits), meta, e)
+	}
+
+	// The token was issued for two sort keys, so it can't be used with one.
+	if _, e := Paginate(search.NewRequest(), params, "/widgets", pagination.Order{Key: "created"}); apierror.StatusOf(e) != http.StatusBadRequest {
+		t.Errorf("Paginate() with a different order = %v, want 400", e)
+	}
+
+	if _, e := Paginate(search.NewRequest(), params, "/others", order...); apierror.StatusOf(e) != http.StatusBadRequest {
+		t.Errorf("Paginate() with a different scope = %v, want 400", e)
+	}
+}
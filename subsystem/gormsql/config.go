@@ -56,6 +56,12 @@ var (
 		"Specify the TLS validation level for the database connection.",
 		"verify-full",
 	)
+
+	gormSlowQueryThreshold *manager.ConfigValue = manager.NewConfigValue(
+		"gormSlowQueryThreshold",
+		"Specify the duration (in milliseconds) after which a query is logged as a slow query. Set to 0 to disable slow query logging.",
+		uint(200),
+	)
 )
 
 func (g *GormSQLManager) Configs() *[]*manager.ConfigValue {
@@ -66,6 +72,7 @@ func (g *GormSQLManager) Configs() *[]*manager.ConfigValue {
 		gormSqlPort,
 		gormSqlDb,
 		gormTlsverifyLevel,
+		gormSlowQueryThreshold,
 	}
 }
 
@@ -30,7 +30,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"k8s.io/klog/v2"
+	"gorm.io/gorm/logger"
 )
 
 const GormSQLSubsystemName = "gormsql"
@@ -71,7 +71,11 @@ func (g *GormSQLManager) Initialize(reg *manager.SystemRegistrar) error {
 	})
 
 	config := &gorm.Config{
-		Logger: &gormLogger{},
+		Logger: newGormLogger(logger.Warn),
+
+		// Translate driver specific errors, so unique violations are returned as gorm.ErrDuplicatedKey
+		// and can be translated with TranslateError.
+		TranslateError: true,
 	}
 
 	var user, pass string
@@ -147,10 +151,10 @@ func (g *GormSQLManager) SyncStart() {
 		for {
 			select {
 			case done := <-g.credsRenewer.DoneCh():
-				klog.Errorf("received error for db credential renewals: %s", done)
+				dbLogger.Error("received error for db credential renewals", "error", done)
 				return
 			case renew := <-g.credsRenewer.RenewCh():
-				klog.Infof("successfully renewed db credentials at %s for %d seconds, restarting connections", renew.RenewedAt, renew.Secret.LeaseDuration)
+				dbLogger.Info("successfully renewed db credentials, restarting connections", "renewedAt", renew.RenewedAt, "leaseSeconds", renew.Secret.LeaseDuration)
 			}
 		}
 	}
@@ -20,40 +20,74 @@ package gormsql
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"time"
 
+	manager "github.com/fire833/go-api-utils/mgr"
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
-	"k8s.io/klog/v2"
 )
 
+var dbLogger *slog.Logger = manager.Logger(GormSQLSubsystemName)
+
+// gormLogger routes gorm logs through the manager logger. Failed and slow queries are logged
+// according to the gorm log level, and all other queries are logged at debug level.
 type gormLogger struct {
-	logger.Interface
+	lvl logger.LogLevel
+	log *slog.Logger
 
-	lvl int
+	// Queries taking longer than this are logged as warnings, unless it is zero.
+	slow time.Duration
 }
 
-func new(lvl int) *gormLogger {
+func newGormLogger(lvl logger.LogLevel) *gormLogger {
 	return &gormLogger{
-		lvl: lvl,
+		lvl:  lvl,
+		log:  dbLogger,
+		slow: time.Millisecond * time.Duration(gormSlowQueryThreshold.GetUint()),
 	}
 }
 
 func (g *gormLogger) LogMode(lvl logger.LogLevel) logger.Interface {
-	g.lvl = int(lvl)
-	return g
+	return &gormLogger{lvl: lvl, log: g.log, slow: g.slow}
 }
 
 func (g *gormLogger) Info(ctx context.Context, s string, args ...interface{}) {
-	klog.V(klog.Level(g.lvl)).Infof(s, args...)
+	if g.lvl >= logger.Info {
+		g.log.Log(ctx, manager.Verbosity(int(g.lvl)), fmt.Sprintf(s, args...))
+	}
 }
 
 func (g *gormLogger) Warn(ctx context.Context, s string, args ...interface{}) {
-	klog.Warningf(s, args...)
+	if g.lvl >= logger.Warn {
+		g.log.WarnContext(ctx, fmt.Sprintf(s, args...))
+	}
 }
 
 func (g *gormLogger) Error(ctx context.Context, s string, args ...interface{}) {
-	klog.Errorf(s, args...)
+	if g.lvl >= logger.Error {
+		g.log.ErrorContext(ctx, fmt.Sprintf(s, args...))
+	}
 }
 
 func (g *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if g.lvl <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && g.lvl >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		g.log.ErrorContext(ctx, "query failed", "sql", sql, "rows", rows, "duration", elapsed, "error", err)
+	case g.slow > 0 && elapsed > g.slow && g.lvl >= logger.Warn:
+		sql, rows := fc()
+		g.log.WarnContext(ctx, "slow query", "sql", sql, "rows", rows, "duration", elapsed, "threshold", g.slow)
+	case g.log.Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		g.log.DebugContext(ctx, "query", "sql", sql, "rows", rows, "duration", elapsed)
+	}
 }
@@ -0,0 +1,60 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package gormsql
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+func TestGormLoggerSlowQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		slow     time.Duration
+		elapsed  time.Duration
+		wantSlow bool
+	}{
+		{"fast", time.Millisecond * 200, time.Millisecond, false},
+		{"slow", time.Millisecond * 200, time.Millisecond * 300, true},
+		{"disabled", 0, time.Second, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			g := &gormLogger{lvl: logger.Warn, log: slog.New(slog.NewTextHandler(buf, nil)), slow: tt.slow}
+
+			g.Trace(context.Background(), time.Now().Add(-tt.elapsed), func() (string, int64) { return "SELECT 1", 1 }, nil)
+			if got := strings.Contains(buf.String(), "slow query"); got != tt.wantSlow {
+				t.Errorf("logged slow query = %v, want %v: %s", got, tt.wantSlow, buf.String())
+			}
+		})
+	}
+}
+
+func TestNewGormLogger(t *testing.T) {
+	if g := newGormLogger(logger.Warn); g.slow != time.Millisecond*200 {
+		t.Errorf("slow query threshold = %v, want default of 200ms", g.slow)
+	}
+}
@@ -20,7 +20,13 @@ package gormsql
 
 import (
 	"errors"
+	"iter"
+	"net/http"
+	"reflect"
+	"sync"
 
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/object"
 	"gorm.io/gorm"
 )
 
@@ -34,3 +40,77 @@ func Transaction(model any) (*gorm.DB, error) {
 		return nil, errors.New("gormsql subsystem not enabled")
 	}
 }
+
+// TranslateError translates errors returned by gorm into API errors, so they can be returned from
+// handlers and responded with the appropriate status code. Missing records are translated to 404,
+// unique and foreign key violations to 409, and check constraint violations to 422. Any other error
+// is returned as is.
+func TranslateError(e error) error {
+	switch {
+	case e == nil:
+		return nil
+	case errors.Is(e, gorm.ErrRecordNotFound):
+		return apierror.New(http.StatusNotFound, "object_not_found", "object was not found").WithCause(e)
+	case errors.Is(e, gorm.ErrDuplicatedKey):
+		return apierror.New(http.StatusConflict, "object_exists", "object conflicts with an existing object").WithCause(e)
+	case errors.Is(e, gorm.ErrForeignKeyViolated):
+		return apierror.New(http.StatusConflict, "object_referenced", "object references or is referenced by another object").WithCause(e)
+	case errors.Is(e, gorm.ErrCheckConstraintViolated):
+		return apierror.New(http.StatusUnprocessableEntity, "constraint_violated", "object violates a constraint").WithCause(e)
+	default:
+		return e
+	}
+}
+
+// Rows returns an iterator over the rows of the query on the table of T, each scanned into a new
+// object of type T as it is iterated, so that large results can be streamed (ie with
+// serialization.StreamListResponseHandler) without loading them into memory. The query is executed
+// each time the iterator is iterated. The returned function returns the error that ended the last
+// iteration early, if any, so the iterator shouldn't be iterated concurrently, as the error of one
+// iteration may then be reported for another.
+func Rows[T object.Object](db *gorm.DB) (iter.Seq[object.Object], func() error) {
+	var (
+		m   sync.Mutex
+		err error
+	)
+
+	setErr := func(e error) {
+		m.Lock()
+		defer m.Unlock()
+		err = TranslateError(e)
+	}
+
+	typ := reflect.TypeFor[T]().Elem()
+	db = db.Model(reflect.New(typ).Interface())
+
+	items := func(yield func(object.Object) bool) {
+		setErr(nil)
+
+		rows, e := db.Rows()
+		if e != nil {
+			setErr(e)
+			return
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			obj := reflect.New(typ).Interface().(T)
+			if e := db.ScanRows(rows, obj); e != nil {
+				setErr(e)
+				return
+			}
+
+			if !yield(obj) {
+				return
+			}
+		}
+
+		setErr(rows.Err())
+	}
+
+	return items, func() error {
+		m.Lock()
+		defer m.Unlock()
+		return err
+	}
+}
@@ -0,0 +1,139 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package gormsql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/serialization"
+	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gorm.io/gorm"
+)
+
+func TestRows(t *testing.T) {
+	db := openTestDB(t, &wrapperspb.UInt32Value{})
+
+	for i := uint32(1); i <= 3; i++ {
+		if e := db.Create(wrapperspb.UInt32(i)).Error; e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	stream := func() (*fasthttp.RequestCtx, func() error) {
+		// Rows scopes the query to the table of T, so db isn't scoped by the caller.
+		items, err := Rows[*wrapperspb.UInt32Value](db)
+
+		ctx := &fasthttp.RequestCtx{}
+		serialization.StreamListResponseHandler(ctx, 200, items, err)
+		return ctx, err
+	}
+
+	t.Run("complete", func(t *testing.T) {
+		ctx, err := stream()
+
+		items := []uint32{}
+		if e := json.Unmarshal(ctx.Response.Body(), &items); e != nil || !reflect.DeepEqual(items, []uint32{1, 2, 3}) || err() != nil {
+			t.Errorf("response = %s, %v, error %v, want [1,2,3]", ctx.Response.Body(), e, err())
+		}
+	})
+
+	// Values are unsigned integers, so a negative value can't be scanned.
+	stmt := &gorm.Statement{DB: db}
+	if e := stmt.Parse(&wrapperspb.UInt32Value{}); e != nil {
+		t.Fatal(e)
+	}
+
+	if e := db.Exec("INSERT INTO "+stmt.Schema.Table+" (value) VALUES (?)", -1).Error; e != nil {
+		t.Fatal(e)
+	}
+
+	t.Run("scanFailure", func(t *testing.T) {
+		ctx, err := stream()
+
+		body := string(ctx.Response.Body())
+		if !strings.HasPrefix(body, "[1,2,3") || strings.HasSuffix(body, "]") {
+			t.Errorf("response = %s, want the array of the scanned items left open", body)
+		}
+
+		if err() == nil {
+			t.Errorf("err() = nil, want the scan error")
+		}
+	})
+}
+
+func TestTranslateError(t *testing.T) {
+	other := errors.New("connection refused")
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"notFound", gorm.ErrRecordNotFound, http.StatusNotFound, "object_not_found"},
+		{"wrappedNotFound", fmt.Errorf("query: %w", gorm.ErrRecordNotFound), http.StatusNotFound, "object_not_found"},
+		{"duplicatedKey", gorm.ErrDuplicatedKey, http.StatusConflict, "object_exists"},
+		{"foreignKey", gorm.ErrForeignKeyViolated, http.StatusConflict, "object_referenced"},
+		{"checkConstraint", gorm.ErrCheckConstraintViolated, http.StatusUnprocessableEntity, "constraint_violated"},
+		{"other", other, http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := TranslateError(tt.err)
+			if status := apierror.StatusOf(e); status != tt.status || !errors.Is(e, tt.err) {
+				t.Errorf("TranslateError() = %v with status %d, want %d wrapping %v", e, status, tt.status, tt.err)
+			}
+
+			if apiErr, ok := apierror.As(e); ok && apiErr.Code != tt.code {
+				t.Errorf("TranslateError() code = %s, want %s", apiErr.Code, tt.code)
+			}
+		})
+	}
+
+	if TranslateError(nil) != nil {
+		t.Errorf("TranslateError(nil) = non-nil")
+	}
+}
+
+// TestTranslateDriverError checks that errors returned by the driver are translated, which requires
+// gorm's TranslateError option as set by the subsystem.
+func TestTranslateDriverError(t *testing.T) {
+	db := openTestDB(t, &testWidget{})
+
+	if e := db.Create(&testWidget{ID: 1, Name: "a"}).Error; e != nil {
+		t.Fatal(e)
+	}
+
+	if e := TranslateError(db.Create(&testWidget{ID: 1, Name: "b"}).Error); apierror.StatusOf(e) != http.StatusConflict {
+		t.Errorf("TranslateError() of a unique violation = %v, want 409", e)
+	}
+
+	if e := TranslateError(db.First(&testWidget{}, 2).Error); apierror.StatusOf(e) != http.StatusNotFound {
+		t.Errorf("TranslateError() of a missing record = %v, want 404", e)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package gormsql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// List queries a page of objects of type T from db, sorted by the fields of order, after the cursor
+// of the page token within params, and returns the page along with its ListMeta. scope identifies the
+// list, see pagination.NewToken, and should include every filter applied to db along with the order.
+// The keys of order are the Go or column names of fields of T, and the next page token is made of
+// their values for the last object within the page, so the last field should be unique, see
+// pagination.Order.
+func List[T any](db *gorm.DB, params pagination.Params, scope string, order ...pagination.Order) ([]T, *pagination.ListMeta, error) {
+	// The schema of T, to resolve the sort keys to columns and read them from objects.
+	stmt := &gorm.Statement{DB: db}
+	if e := stmt.Parse(new(T)); e != nil {
+		return nil, nil, fmt.Errorf("unable to parse schema of %T: %w", *new(T), e)
+	}
+
+	fields := make([]*schema.Field, 0, len(order))
+	columns := make([]pagination.Order, 0, len(order))
+	for _, o := range order {
+		field := stmt.Schema.LookUpField(o.Key)
+		if field == nil || field.DBName == "" {
+			return nil, nil, fmt.Errorf("sort key %s is not a column of %s", o.Key, stmt.Schema.Name)
+		}
+
+		fields = append(fields, field)
+		columns = append(columns, pagination.Order{Key: field.DBName, Desc: o.Desc})
+	}
+
+	cursor, e := params.Cursor(scope)
+	if e != nil {
+		return nil, nil, e
+	}
+
+	q, e := Keyset(db, cursor, columns...)
+	if e != nil {
+		return nil, nil, e
+	}
+
+	size := params.Size()
+
+	var items []T
+	if e := q.Limit(size + 1).Find(&items).Error; e != nil {
+		return nil, nil, TranslateError(e)
+	}
+
+	return pagination.Page(items, size, scope, func(item T) []any {
+		value := reflect.Indirect(reflect.ValueOf(item))
+
+		keys := make([]any, 0, len(fields))
+		for _, field := range fields {
+			key, _ := field.ValueOf(db.Statement.Context, value)
+			keys = append(keys, key)
+		}
+
+		return keys
+	})
+}
+
+// Keyset returns db sorted by the columns of order, whose keys must be column names rather than
+// field names, and if cursor isn't nil, filtered to the rows after the cursor, ie for columns a and b:
+//
+//	WHERE a > ? OR (a = ? AND b > ?) ORDER BY a, b
+//
+// Rows with NULL values within the columns are never after a cursor, so the columns shouldn't
+// be nullable.
+func Keyset(db *gorm.DB, cursor *pagination.Cursor, order ...pagination.Order) (*gorm.DB, error) {
+	for _, o := range order {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: o.Key}, Desc: o.Desc})
+	}
+
+	if cursor == nil {
+		return db, nil
+	}
+
+	if len(cursor.Keys) != len(order) {
+		return nil, apierror.BadRequest(pagination.ErrInvalidToken.Error() + ": token was issued for a different order").
+			WithCause(pagination.ErrInvalidToken)
+	}
+
+	after := make([]clause.Expression, 0, len(order))
+	for i, o := range order {
+		conds := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			conds = append(conds, clause.Eq{Column: clause.Column{Name: order[j].Key}, Value: cursor.Keys[j]})
+		}
+
+		if o.Desc {
+			conds = append(conds, clause.Lt{Column: clause.Column{Name: o.Key}, Value: cursor.Keys[i]})
+		} else {
+			conds = append(conds, clause.Gt{Column: clause.Column{Name: o.Key}, Value: cursor.Keys[i]})
+		}
+
+		after = append(after, clause.And(conds...))
+	}
+
+	return db.Where(clause.Or(after...)), nil
+}
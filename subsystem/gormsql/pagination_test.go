@@ -0,0 +1,176 @@
+/*
+*	Copyright (C) 2025 Kendall Tauser
+*
+*	This program is free software; you can redistribute it and/or modify
+*	it under the terms of the GNU General Public License as published by
+*	the Free Software Foundation; either version 2 of the License, or
+*	(at your option) any later version.
+*
+*	This program is distributed in the hope that it will be useful,
+*	but WITHOUT ANY WARRANTY; without even the implied warranty of
+*	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+*	GNU General Public License for more details.
+*
+*	You should have received a copy of the GNU General Public License along
+*	with this program; if not, write to the Free Software Foundation, Inc.,
+*	51 Franklin Street, Fifth Floor, Boston, MA 02110-1301 USA.
+ */
+
+package gormsql
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/fire833/go-api-utils/apierror"
+	"github.com/fire833/go-api-utils/pagination"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type testWidget struct {
+	ID       uint `gorm:"primaryKey"`
+	Name     string
+	SortRank int
+}
+
+// openTestDB returns an in memory sqlite database migrated with each model.
+func openTestDB(t *testing.T, models ...any) *gorm.DB {
+	t.Helper()
+
+	db, e := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard, TranslateError: true})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if e := db.AutoMigrate(models...); e != nil {
+		t.Fatal(e)
+	}
+
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	return db
+}
+
+func testWidgetsDB(t *testing.T) *gorm.DB {
+	db := openTestDB(t, &testWidget{})
+
+	widgets := []testWidget{
+		{ID: 1, Name: "a", SortRank: 2},
+		{ID: 2, Name: "b", SortRank: 1},
+		{ID: 3, Name: "c", SortRank: 2},
+		{ID: 4, Name: "d", SortRank: 3},
+		{ID: 5, Name: "e", SortRank: 1},
+	}
+
+	if e := db.Create(&widgets).Error; e != nil {
+		t.Fatal(e)
+	}
+
+	return db
+}
+
+// listAll lists every page of widgets, returning the IDs of the widgets in the order they were listed.
+func listAll(t *testing.T, db *gorm.DB, order ...pagination.Order) []uint {
+	t.Helper()
+
+	ids := []uint{}
+	params := pagination.Params{Limit: 2}
+
+	for pages := 0; pages < 10; pages++ {
+		items, meta, e := List[testWidget](db.Model(&testWidget{}), params, "/widgets", order...)
+		if e != nil {
+			t.Fatalf("List() page %d = %v", pages, e)
+		}
+
+		if len(items) > 2 {
+			t.Fatalf("List() page %d has %d items, want at most 2", pages, len(items))
+		}
+
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+
+		if meta.GetNextPageToken() == "" {
+			return ids
+		}
+
+		params.PageToken = meta.GetNextPageToken()
+	}
+
+	t.Fatalf("List() didn't end after 10 pages")
+	return nil
+}
+
+func TestList(t *testing.T) {
+	db := testWidgetsDB(t)
+
+	tests := []struct {
+		name  string
+		order []pagination.Order
+		want  []uint
+	}{
+		{"field names", []pagination.Order{{Key: "SortRank"}, {Key: "ID"}}, []uint{2, 5, 1, 3, 4}},
+		{"column names", []pagination.Order{{Key: "sort_rank"}, {Key: "id"}}, []uint{2, 5, 1, 3, 4}},
+		{"descending", []pagination.Order{{Key: "SortRank", Desc: true}, {Key: "ID"}}, []uint{4, 1, 3, 2, 5}},
+		{"primary key", []pagination.Order{{Key: "ID", Desc: true}}, []uint{5, 4, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listAll(t, db, tt.order...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListInvalid(t *testing.T) {
+	db := testWidgetsDB(t)
+
+	_, meta, e := List[testWidget](db.Model(&testWidget{}), pagination.Params{Limit: 2}, "/widgets", pagination.Order{Key: "SortRank"}, pagination.Order{Key: "ID"})
+	if e != nil || meta.GetNextPageToken() == "" {
+		t.Fatalf("List() = %v, %v, want a next page", meta, e)
+	}
+
+	// The token was issued for two sort keys, so it can't be used with one.
+	params := pagination.Params{Limit: 2, PageToken: meta.GetNextPageToken()}
+	if _, _, e := List[testWidget](db.Model(&testWidget{}), params, "/widgets", pagination.Order{Key: "ID"}); apierror.StatusOf(e) != http.StatusBadRequest {
+		t.Errorf("List() with a different order = %v, want 400", e)
+	}
+
+	if _, _, e := List[testWidget](db.Model(&testWidget{}), pagination.Params{}, "/widgets", pagination.Order{Key: "Missing"}); e == nil {
+		t.Errorf("List() with an unknown key = nil, want an error")
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	db := testWidgetsDB(t)
+
+	q, e := Keyset(db.Model(&testWidget{}), &pagination.Cursor{Keys: []any{int64(2), int64(1)}}, pagination.Order{Key: "sort_rank"}, pagination.Order{Key: "id"})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	var widgets []testWidget
+	if e := q.Find(&widgets).Error; e != nil {
+		t.Fatal(e)
+	}
+
+	ids := []uint{}
+	for _, w := range widgets {
+		ids = append(ids, w.ID)
+	}
+
+	if want := []uint{3, 4}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Keyset() = %v, want %v", ids, want)
+	}
+
+	if _, e := Keyset(db, &pagination.Cursor{Keys: []any{int64(1)}}, pagination.Order{Key: "sort_rank"}, pagination.Order{Key: "id"}); apierror.StatusOf(e) != http.StatusBadRequest {
+		t.Errorf("Keyset() with a different order = %v, want 400", e)
+	}
+}
@@ -25,19 +25,22 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/fire833/go-api-utils/buildinfo"
 	manager "github.com/fire833/go-api-utils/mgr"
+	"github.com/fire833/go-api-utils/serialization"
+	"github.com/go-logr/logr"
 	"github.com/go-openapi/spec"
 	"github.com/valyala/fasthttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/jaeger"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	oteltrace "go.opentelemetry.io/otel/trace"
-	"k8s.io/klog/v2"
 )
 
 const (
@@ -47,6 +50,8 @@ const (
 
 var OTEL *OTELManager
 
+var logger = manager.Logger(OTelManagerSubsystem)
+
 type OTELManager struct {
 	manager.DefaultSubsystem
 	sdktrace.Sampler
@@ -127,7 +132,7 @@ func (o *OTELManager) Initialize(reg manager.AppRegistration) error {
 		resource.WithOSType(),
 		resource.WithAttributes(
 			semconv.ServiceNameKey.String("app"),
-			// semconv.ServiceVersionKey.String(pkg.Version),
+			semconv.ServiceVersionKey.String(buildinfo.Get().Version),
 		),
 	)
 	if e1 != nil {
@@ -143,7 +148,8 @@ func (o *OTELManager) Initialize(reg manager.AppRegistration) error {
 	)
 
 	otel.SetTracerProvider(o.tracer)
-	otel.SetLogger(klog.NewKlogr())
+	otel.SetLogger(logr.FromSlogHandler(logger.Handler()))
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
 	// set up the sampler code, including SysAPI routes for modifying which operations will be traced.
 	o.sampleToggle = make(map[string]bool)
@@ -160,7 +166,7 @@ func (o *OTELManager) Initialize(reg manager.AppRegistration) error {
 				WithTags("sys", "otel").
 				RespondsWith(200, spec.NewResponse().WithDescription("List of all operations and whether they are enabled.").WithSchema(spec.RefSchema("#/definitions/SamplerStatusList"))),
 		},
-	}, samplerStatus)
+	}, serialization.NewSchemasFromObject(&SamplerStatusList{})...)
 
 	manager.RegisterSysAPIHandler(fasthttp.MethodPut, "/trace/enable/{NAME}", o.enable, spec.PathItem{
 		PathItemProps: spec.PathItemProps{
@@ -189,14 +195,14 @@ func (o *OTELManager) Initialize(reg manager.AppRegistration) error {
 
 // Free all resources from the exporter and shutdown.
 func (o *OTELManager) Shutdown() {
-	klog.V(4).Infoln("otel: shutting down tracer")
+	logger.Log(context.Background(), manager.Verbosity(4), "shutting down tracer")
 	if e := o.tracer.Shutdown(context.Background()); e != nil {
-		klog.Errorf("unable to gracefully shutdown otel tracer subsystem: %v", e)
+		logger.Error("unable to gracefully shutdown otel tracer subsystem", "error", e)
 	}
 
-	klog.V(4).Infoln("otel: shutting down span exporter")
+	logger.Log(context.Background(), manager.Verbosity(4), "shutting down span exporter")
 	if e := o.exporter.Shutdown(context.Background()); e != nil {
-		klog.Errorf("unable to gracefully shutdown otel exporter subsystem: %v", e)
+		logger.Error("unable to gracefully shutdown otel exporter subsystem", "error", e)
 	}
 
 	o.IsShutdown = true
@@ -39,6 +39,8 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Serialized object containing the enablement status of all trace operations
+// within the current running instance.
 type SamplerStatusList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
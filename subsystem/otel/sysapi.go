@@ -19,49 +19,26 @@
 package otel
 
 import (
+	_ "embed"
 	"fmt"
 
 	"github.com/fire833/go-api-utils/serialization"
-	"github.com/go-openapi/spec"
 	"github.com/valyala/fasthttp"
 )
 
 //go:generate protoc --go_out=. --go_opt=Motel.proto=../otel otel.proto
 //go:generate protoc --go_out=. --go_opt=Motel.proto=../otel otel_list.proto
+//go:generate protoc --include_source_info --descriptor_set_out=otel.binpb otel.proto otel_list.proto
 
-var samplerStatus *spec.Schema = &spec.Schema{
-	SchemaProps: spec.SchemaProps{
-		Title:       "SamplerStatusList",
-		Description: "Serialized object containing the enablement status of all trace operations within the current running instance.",
-		Type:        []string{"object"},
-		Format:      "",
-		Properties: spec.SchemaProperties{
-			"items": *spec.ArrayProperty(&spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Title:       "SamplerStatus",
-					Description: "The name and status of a trace operation in the current instance.",
-					Type:        []string{"object"},
-					Format:      "",
-					Properties: spec.SchemaProperties{
-						"name": {
-							SchemaProps: spec.SchemaProps{
-								Title:  "name",
-								Type:   []string{"string"},
-								Format: "",
-							},
-						},
-						"enabled": {
-							SchemaProps: spec.SchemaProps{
-								Title:  "enabled",
-								Type:   []string{"boolean"},
-								Format: "",
-							},
-						},
-					},
-				},
-			}),
-		},
-	},
+// Source info of the otel protos, so schemas generated from their messages are documented.
+//
+//go:embed otel.binpb
+var otelSourceInfo []byte
+
+func init() {
+	if e := serialization.RegisterProtoSourceInfo(otelSourceInfo); e != nil {
+		panic(e)
+	}
 }
 
 // Function to return the status of all trace operations and whether they are enabled.